@@ -0,0 +1,50 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import "context"
+
+// AccountService groups the user account methods of the API.
+type AccountService struct {
+	client *Client
+}
+
+// CheckAccess calls user/nop to verify that the credentials are valid and API
+// calls are allowed from this IP address. Calls from an address outside the
+// API allowlist fail with an IPRestrictedError.
+//
+// The API password and the IP allowlist can only be changed in the account
+// settings on reg.ru; the API has no methods to manage them.
+func (s *AccountService) CheckAccess(ctx context.Context) error {
+	_, err := s.client.apiRequest(ctx, "user/nop", &UserNopRequest{})
+	return err
+}
+
+// GetBalance returns the current balance of the account.
+func (s *AccountService) GetBalance(ctx context.Context) (Balance, error) {
+	resp, err := Call[UserGetBalanceResponse](ctx, s.client, "user/get_balance", &UserGetBalanceRequest{})
+	if err != nil {
+		return Balance{}, err
+	}
+
+	return Balance{
+		Prepay:   parseAmount(resp.Answer.Prepay),
+		Blocked:  parseAmount(resp.Answer.Blocked),
+		Credit:   parseAmount(resp.Answer.Credit),
+		Currency: resp.Answer.Currency,
+	}, nil
+}
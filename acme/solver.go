@@ -0,0 +1,249 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package acme provides the building blocks of ACME DNS-01 challenges on top
+// of the reg.ru client: it publishes and removes the _acme-challenge TXT
+// records of a domain in the account zone that contains it.
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/mixanemca/regru-go"
+	"golang.org/x/net/idna"
+)
+
+const (
+	// ChallengeLabel is the label under which DNS-01 challenge records are published.
+	ChallengeLabel = "_acme-challenge"
+	// DefaultTTL is the TTL in seconds of challenge records.
+	DefaultTTL = 60
+)
+
+// Client is the subset of regru.Client methods used by the solver.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// Solver publishes and removes DNS-01 challenge records.
+type Solver struct {
+	client Client
+	ttl    int
+
+	mu sync.Mutex
+	// created holds the records published per challenge FQDN
+	created map[string][]regru.DNSRecord
+}
+
+// Option represents an option for configuring the solver.
+type Option func(*Solver)
+
+// WithTTL sets the TTL in seconds of challenge records (DefaultTTL by default).
+func WithTTL(ttl int) Option {
+	return func(s *Solver) {
+		s.ttl = ttl
+	}
+}
+
+// NewSolver creates a new challenge solver backed by the client.
+func NewSolver(client Client, opts ...Option) *Solver {
+	s := &Solver{
+		client:  client,
+		ttl:     DefaultTTL,
+		created: make(map[string][]regru.DNSRecord),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ChallengeFQDN returns the name of the challenge record for a domain, without
+// a trailing dot. Wildcard domains share the record of their base domain, and
+// names that already start with the challenge label are returned as they are.
+func ChallengeFQDN(domain string) string {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	domain = strings.TrimPrefix(domain, "*.")
+	if strings.HasPrefix(domain, ChallengeLabel+".") {
+		return domain
+	}
+	return ChallengeLabel + "." + domain
+}
+
+// ChallengeValue returns the TXT record value for a key authorization: the
+// unpadded base64url encoding of its SHA-256 digest (RFC 8555, section 8.4).
+func ChallengeValue(keyAuth string) string {
+	digest := sha256.Sum256([]byte(keyAuth))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+// Present publishes the challenge record for the domain or challenge FQDN
+// with the value derived from keyAuth.
+func (s *Solver) Present(ctx context.Context, fqdn, keyAuth string) error {
+	return s.PresentValue(ctx, fqdn, ChallengeValue(keyAuth))
+}
+
+// PresentValue publishes the challenge record for the domain or challenge FQDN
+// with a value computed by the caller, as provided by cert-manager. A record
+// the solver has already published with the value is not published again.
+func (s *Solver) PresentValue(ctx context.Context, fqdn, value string) error {
+	fqdn = ChallengeFQDN(fqdn)
+
+	s.mu.Lock()
+	for _, rr := range s.created[fqdn] {
+		if regru.Canonicalize(rr).Content == value {
+			s.mu.Unlock()
+			return nil
+		}
+	}
+	s.mu.Unlock()
+
+	zone, name, err := s.FindZone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	record, err := s.client.AddRR(ctx, zone, regru.CreateDNSRecordParams{
+		Name:    name,
+		Type:    regru.RecordTypeTXT,
+		Content: value,
+		TTL:     s.ttl,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.created[fqdn] = append(s.created[fqdn], record)
+	s.mu.Unlock()
+	return nil
+}
+
+// CleanUp removes the challenge records of the domain or challenge FQDN. The
+// records published by Present are removed; if there are none, for example
+// after a restart, all TXT records with the challenge name are removed.
+func (s *Solver) CleanUp(ctx context.Context, fqdn string) error {
+	return s.cleanUp(ctx, fqdn, "")
+}
+
+// CleanUpValue removes the challenge record of the domain or challenge FQDN
+// with the value, leaving records of concurrent challenges for the same name.
+func (s *Solver) CleanUpValue(ctx context.Context, fqdn, value string) error {
+	return s.cleanUp(ctx, fqdn, value)
+}
+
+// cleanUp removes the challenge records of fqdn with the value, or all of them
+// if value is empty.
+func (s *Solver) cleanUp(ctx context.Context, fqdn, value string) error {
+	fqdn = ChallengeFQDN(fqdn)
+	zone, name, err := s.FindZone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	matches := func(rr regru.DNSRecord) bool {
+		return value == "" || regru.Canonicalize(rr).Content == value
+	}
+
+	var records []regru.DNSRecord
+	s.mu.Lock()
+	remaining := s.created[fqdn][:0]
+	for _, rr := range s.created[fqdn] {
+		if matches(rr) {
+			records = append(records, rr)
+		} else {
+			remaining = append(remaining, rr)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(s.created, fqdn)
+	} else {
+		s.created[fqdn] = remaining
+	}
+	s.mu.Unlock()
+
+	if len(records) == 0 {
+		current, err := s.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zone})
+		if err != nil {
+			return err
+		}
+		for _, rr := range current {
+			if rr.Type.Canonical() == regru.RecordTypeTXT && strings.EqualFold(rr.Name, name) && matches(rr) {
+				records = append(records, rr)
+			}
+		}
+	}
+
+	var errs []error
+	for _, rr := range records {
+		if err := s.client.DeleteRR(ctx, zone, rr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FindZone returns the longest account zone containing fqdn and the name of
+// fqdn relative to it. Names are compared in punycode form.
+func (s *Solver) FindZone(ctx context.Context, fqdn string) (string, string, error) {
+	fqdn = asciiName(fqdn)
+	zones, err := s.client.ListZones(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	var zone, ascii string
+	for _, z := range zones {
+		name := asciiName(z.Name)
+		if name == "" || (fqdn != name && !strings.HasSuffix(fqdn, "."+name)) {
+			continue
+		}
+		if len(name) > len(ascii) {
+			zone, ascii = z.Name, name
+		}
+	}
+	if zone == "" {
+		return "", "", &regru.ZoneNotFoundError{ZoneID: fqdn}
+	}
+
+	if fqdn == ascii {
+		return zone, "@", nil
+	}
+	return zone, strings.TrimSuffix(fqdn, "."+ascii), nil
+}
+
+// idnaProfile converts internationalized names, accepting the underscore of
+// the challenge label.
+var idnaProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.Transitional(false))
+
+// asciiName returns the lowercase punycode form of a name without a trailing dot.
+func asciiName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if ascii, err := idnaProfile.ToASCII(name); err == nil {
+		return ascii
+	}
+	return name
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps the records of its zones in memory.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient(zones ...string) *fakeClient {
+	f := &fakeClient{records: make(map[string][]regru.DNSRecord)}
+	for _, zone := range zones {
+		f.zones = append(f.zones, regru.Zone{Name: zone})
+	}
+	return f
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	record := regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}
+	f.records[zone] = append(f.records[zone], record)
+	return record, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func TestChallengeFQDN(t *testing.T) {
+	assert.Equal(t, "_acme-challenge.example.com", ChallengeFQDN("Example.com."))
+	assert.Equal(t, "_acme-challenge.example.com", ChallengeFQDN("*.example.com"))
+	assert.Equal(t, "_acme-challenge.www.example.com", ChallengeFQDN("_acme-challenge.www.example.com"))
+}
+
+func TestChallengeValue(t *testing.T) {
+	assert.Equal(t, "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs", ChallengeValue("token.key"))
+	assert.Len(t, ChallengeValue("anything"), 43)
+}
+
+func TestSolver_PresentAndCleanUp(t *testing.T) {
+	client := newFakeClient("example.com", "sub.example.com")
+	solver := NewSolver(client, WithTTL(120))
+
+	require.NoError(t, solver.Present(context.Background(), "www.sub.example.com", "key-auth"))
+	records := client.records["sub.example.com"]
+	require.Len(t, records, 1)
+	assert.Equal(t, "_acme-challenge.www", records[0].Name)
+	assert.Equal(t, regru.RecordTypeTXT, records[0].Type)
+	assert.Equal(t, ChallengeValue("key-auth"), records[0].Content)
+	assert.Equal(t, 120, records[0].TTL)
+
+	require.NoError(t, solver.CleanUp(context.Background(), "www.sub.example.com"))
+	assert.Equal(t, records, client.deleted)
+}
+
+func TestSolver_CleanUpWithoutPresent(t *testing.T) {
+	client := newFakeClient("example.com")
+	client.records["example.com"] = []regru.DNSRecord{
+		{Name: "_acme-challenge", Type: regru.RecordTypeTXT, Content: "a"},
+		{Name: "_acme-challenge", Type: regru.RecordTypeTXT, Content: "b"},
+		{Name: "@", Type: regru.RecordTypeTXT, Content: "v=spf1 -all"},
+	}
+	solver := NewSolver(client)
+
+	require.NoError(t, solver.CleanUp(context.Background(), "*.example.com"))
+	assert.Len(t, client.deleted, 2)
+}
+
+func TestSolver_CleanUpValue(t *testing.T) {
+	client := newFakeClient("example.com")
+	solver := NewSolver(client)
+
+	// Apex and wildcard challenges share the same record name
+	require.NoError(t, solver.PresentValue(context.Background(), "example.com", "first"))
+	require.NoError(t, solver.PresentValue(context.Background(), "*.example.com", "second"))
+	require.Len(t, client.records["example.com"], 2)
+
+	require.NoError(t, solver.CleanUpValue(context.Background(), "example.com", "first"))
+	require.Len(t, client.deleted, 1)
+	assert.Equal(t, "first", client.deleted[0].Content)
+
+	require.NoError(t, solver.CleanUpValue(context.Background(), "*.example.com", "second"))
+	require.Len(t, client.deleted, 2)
+	assert.Equal(t, "second", client.deleted[1].Content)
+}
+
+func TestSolver_FindZone(t *testing.T) {
+	solver := NewSolver(newFakeClient("example.com", "пример.рф"))
+
+	zone, name, err := solver.FindZone(context.Background(), "_acme-challenge.xn--e1afmkfd.xn--p1ai")
+	require.NoError(t, err)
+	assert.Equal(t, "пример.рф", zone)
+	assert.Equal(t, "_acme-challenge", name)
+
+	zone, name, err = solver.FindZone(context.Background(), "example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", zone)
+	assert.Equal(t, "@", name)
+
+	_, _, err = solver.FindZone(context.Background(), "_acme-challenge.example.org")
+	assert.True(t, errors.Is(err, regru.ErrZoneNotFound))
+}
@@ -16,6 +16,9 @@ limitations under the License.
 
 package regru
 
+// Request and response types are generated from internal/apigen/schema.json.
+//go:generate go run ./internal/apigen -schema internal/apigen/schema.json -out api_types_gen.go
+
 // APIRequest represents an API request that can set credentials.
 type APIRequest interface {
 	SetCredentials(username, password string)
@@ -32,168 +35,3 @@ func (b *BaseRequest) SetCredentials(username, password string) {
 	b.Username = username
 	b.Password = password
 }
-
-// AddRecordDomain represents a domain in add record requests.
-type AddRecordDomain struct {
-	DName     string `json:"dname"`
-	Subdomain string `json:"subdomain"`
-	Content   string `json:"content"`
-}
-
-// AddAliasDomain represents a domain in add_alias requests (only dname).
-type AddAliasDomain struct {
-	DName string `json:"dname"`
-}
-
-// AddRecordRequest represents base structure for adding DNS records.
-type AddRecordRequest struct {
-	BaseRequest
-	Domains []AddRecordDomain `json:"domains"`
-	TTL     int               `json:"ttl,omitempty"`
-}
-
-// AddNSRequest represents parameters for zone/add_ns API method.
-// For add_ns, dns_server and subdomain are at the request level, not in domains.
-type AddNSRequest struct {
-	BaseRequest
-	Domains      []AddAliasDomain `json:"domains"`
-	Subdomain    string           `json:"subdomain"`
-	DNSServer    string           `json:"dns_server"`
-	RecordNumber string           `json:"record_number,omitempty"`
-	TTL          int              `json:"ttl,omitempty"`
-}
-
-// AddAliasRequest represents parameters for zone/add_alias API method (A records).
-// For add_alias, ipaddr and subdomain are at the request level, not in domains.
-type AddAliasRequest struct {
-	BaseRequest
-	Domains   []AddAliasDomain `json:"domains"`
-	Subdomain string           `json:"subdomain"`
-	IPAddr    string           `json:"ipaddr"`
-	TTL       int              `json:"ttl,omitempty"`
-}
-
-// AddAAAARequest represents parameters for zone/add_aaaa API method.
-// For add_aaaa, ipaddr and subdomain are at the request level, not in domains.
-type AddAAAARequest struct {
-	BaseRequest
-	Domains   []AddAliasDomain `json:"domains"`
-	Subdomain string           `json:"subdomain"`
-	IPAddr    string           `json:"ipaddr"`
-	TTL       int              `json:"ttl,omitempty"`
-}
-
-// AddCNAMERequest represents parameters for zone/add_cname API method.
-// For add_cname, canonical_name and subdomain are at the request level, not in domains.
-type AddCNAMERequest struct {
-	BaseRequest
-	Domains       []AddAliasDomain `json:"domains"`
-	Subdomain     string           `json:"subdomain"`
-	CanonicalName string           `json:"canonical_name"`
-	TTL           int              `json:"ttl,omitempty"`
-}
-
-// AddMXRequest represents parameters for zone/add_mx API method.
-// For add_mx, mail_server and subdomain are at the request level, not in domains.
-type AddMXRequest struct {
-	BaseRequest
-	Domains    []AddAliasDomain `json:"domains"`
-	Subdomain  string           `json:"subdomain"`
-	MailServer string           `json:"mail_server"`
-	TTL        int              `json:"ttl,omitempty"`
-}
-
-// AddTXTRequest represents parameters for zone/add_txt API method.
-// For add_txt, text and subdomain are at the request level, not in domains.
-type AddTXTRequest struct {
-	BaseRequest
-	Domains   []AddAliasDomain `json:"domains"`
-	Subdomain string           `json:"subdomain"`
-	Text      string           `json:"text"`
-	TTL       int              `json:"ttl,omitempty"`
-}
-
-// AddSRVRequest represents parameters for zone/add_srv API method.
-// For add_srv, service, priority, port, and target are at the request level, not in domains.
-type AddSRVRequest struct {
-	BaseRequest
-	Domains  []AddAliasDomain `json:"domains"`
-	Service  string           `json:"service"`
-	Priority string           `json:"priority"`
-	Port     string           `json:"port"`
-	Target   string           `json:"target"`
-	TTL      int              `json:"ttl,omitempty"`
-}
-
-// RemoveRecordDomain represents a domain in remove record requests.
-type RemoveRecordDomain struct {
-	DName string `json:"dname"`
-}
-
-// RemoveRecordRequest represents base structure for removing DNS records.
-// For remove_record, subdomain, content, and record_type are at the request level.
-type RemoveRecordRequest struct {
-	BaseRequest
-	Domains    []RemoveRecordDomain `json:"domains"`
-	Subdomain  string               `json:"subdomain"`
-	Content    string               `json:"content"`
-	RecordType string               `json:"record_type"`
-}
-
-// RemoveNSRequest represents parameters for zone/remove_ns API method.
-type RemoveNSRequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveSRVRequest represents parameters for zone/remove_srv API method.
-type RemoveSRVRequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveAliasRequest represents parameters for zone/remove_alias API method (A records).
-type RemoveAliasRequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveAAAARequest represents parameters for zone/remove_aaaa API method.
-type RemoveAAAARequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveCNAMERequest represents parameters for zone/remove_cname API method.
-type RemoveCNAMERequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveMXRequest represents parameters for zone/remove_mx API method.
-type RemoveMXRequest struct {
-	RemoveRecordRequest
-}
-
-// RemoveTXTRequest represents parameters for zone/remove_txt API method.
-type RemoveTXTRequest struct {
-	RemoveRecordRequest
-}
-
-// ServiceListRequest represents parameters for service/get_list API method.
-type ServiceListRequest struct {
-	BaseRequest
-	PageSize int `json:"page_size,omitempty"`
-}
-
-// ZoneGetNSRequest represents parameters for zone/get_ns API method.
-type ZoneGetNSRequest struct {
-	BaseRequest
-	Domains []string `json:"domains"`
-}
-
-// ZoneGetResourceRecordsRequest represents parameters for zone/get_resource_records API method.
-type ZoneGetResourceRecordsRequest struct {
-	BaseRequest
-	Domains []ZoneGetResourceRecordsDomain `json:"domains"`
-}
-
-// ZoneGetResourceRecordsDomain represents a domain in get_resource_records request.
-type ZoneGetResourceRecordsDomain struct {
-	DName string `json:"dname"`
-}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// AuditEntry describes an API call that changes data.
+type AuditEntry struct {
+	Time time.Time
+	// Profile is the name of the configuration profile the client was created from.
+	Profile string
+	// Method is the API method path, such as "zone/add_alias".
+	Method string
+	// Params are the request parameters without credentials.
+	Params map[string]interface{}
+	// Duration is the time spent on the call including retries.
+	Duration time.Duration
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// WithProfileName sets the profile name reported in logs and audit entries.
+// Clients created from a configuration profile have it set automatically.
+func WithProfileName(name string) ClientOption {
+	return func(c *Client) {
+		c.profile = name
+	}
+}
+
+// WithLogger logs every API call to the logger at debug level, and failed
+// calls at warn level. Records carry the method, duration and profile name.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithAuditHook calls fn after every API call that changes data, whether it
+// succeeded or not. The hook runs synchronously and should not block.
+func WithAuditHook(fn func(ctx context.Context, entry AuditEntry)) ClientOption {
+	return func(c *Client) {
+		c.audit = fn
+	}
+}
+
+// Profile returns the name of the configuration profile the client was created
+// from, or an empty string.
+func (c *Client) Profile() string {
+	return c.profile
+}
+
+// observe logs the completed API call and reports it to the audit hook.
+func (c *Client) observe(ctx context.Context, path string, input []byte, start time.Time, err error) {
+	duration := time.Since(start)
+
+	if c.logger != nil {
+		attrs := []slog.Attr{
+			slog.String("method", path),
+			slog.Duration("duration", duration),
+		}
+		if c.profile != "" {
+			attrs = append(attrs, slog.String("profile", c.profile))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "reg.ru API call failed", attrs...)
+		} else {
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "reg.ru API call", attrs...)
+		}
+	}
+
+	if c.audit != nil && !isReadPath(path) {
+		c.audit(ctx, AuditEntry{
+			Time:     start,
+			Profile:  c.profile,
+			Method:   path,
+			Params:   requestParams(input),
+			Duration: duration,
+			Err:      err,
+		})
+	}
+}
+
+// requestParams decodes JSON request input without the credentials.
+func requestParams(input []byte) map[string]interface{} {
+	var params map[string]interface{}
+	if json.Unmarshal(input, &params) != nil {
+		return nil
+	}
+	delete(params, "username")
+	delete(params, "password")
+	return params
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AuditHook(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	var entries []AuditEntry
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithProfileName("prod"),
+		WithAuditHook(func(ctx context.Context, entry AuditEntry) { entries = append(entries, entry) }),
+	)
+
+	_, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, entries, "reads are not audited")
+
+	_, err = client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "prod", entries[0].Profile)
+	assert.Equal(t, "zone/add_alias", entries[0].Method)
+	assert.Equal(t, "192.0.2.1", entries[0].Params["ipaddr"])
+	assert.NotContains(t, entries[0].Params, "password")
+	assert.NoError(t, entries[0].Err)
+}
+
+func TestClient_Logger(t *testing.T) {
+	server, _ := setupFlakyServer(t, 1, http.StatusInternalServerError)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithProfileName("staging"),
+		WithLogger(logger),
+	)
+
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	require.Error(t, err)
+	_, err = client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "level=WARN")
+	assert.Contains(t, lines[0], "profile=staging")
+	assert.Contains(t, lines[1], "level=DEBUG")
+	assert.Contains(t, lines[1], "method=service/get_list")
+}
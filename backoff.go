@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffStrategy computes the delay before a retry. Attempt is zero for the
+// first retry; previous is the delay used before the previous retry (zero for
+// the first one).
+type BackoffStrategy interface {
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same interval before every retry.
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Delay returns the constant interval.
+func (b ConstantBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	return b.Interval
+}
+
+// ExponentialBackoff multiplies the delay after every retry, up to Max.
+// Jitter randomizes each delay by up to the given fraction (0.2 means ±20%)
+// to keep concurrent clients from retrying in lockstep.
+type ExponentialBackoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	// Multiplier defaults to 2 if not positive.
+	Multiplier float64
+	Jitter     float64
+}
+
+// Delay returns Initial*Multiplier^attempt, capped at Max and randomized by Jitter.
+func (b ExponentialBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitterBackoff picks each delay at random between Base and three
+// times the previous delay, capped at Max. It spreads retries of many clients
+// well while still backing off quickly.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Delay returns a random delay in [Base, 3*previous], capped at Max.
+func (b DecorrelatedJitterBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	upper := 3 * previous
+	if upper <= b.Base {
+		return b.Base
+	}
+
+	delay := b.Base + time.Duration(rand.Int64N(int64(upper-b.Base)))
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	return delay
+}
+
+// defaultBackoff is used when no strategy is configured.
+var defaultBackoff = ExponentialBackoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}
+
+// WithBackoff sets the strategy computing the delay between retries.
+// The default is exponential backoff from 500ms up to 10s.
+func WithBackoff(strategy BackoffStrategy) ClientOption {
+	return func(c *Client) {
+		c.backoff = strategy
+	}
+}
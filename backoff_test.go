@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff{Interval: time.Second}
+	assert.Equal(t, time.Second, b.Delay(0, 0))
+	assert.Equal(t, time.Second, b.Delay(5, time.Second))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	assert.Equal(t, 100*time.Millisecond, b.Delay(0, 0))
+	assert.Equal(t, 400*time.Millisecond, b.Delay(2, 0))
+	assert.Equal(t, time.Second, b.Delay(10, 0))
+
+	b.Multiplier = 3
+	assert.Equal(t, 900*time.Millisecond, b.Delay(2, 0))
+}
+
+func TestExponentialBackoff_Jitter(t *testing.T) {
+	b := ExponentialBackoff{Initial: time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		delay := b.Delay(0, 0)
+		assert.GreaterOrEqual(t, delay, 800*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1200*time.Millisecond)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: 2 * time.Second}
+	assert.Equal(t, 100*time.Millisecond, b.Delay(0, 0))
+
+	previous := 100 * time.Millisecond
+	for i := 0; i < 100; i++ {
+		delay := b.Delay(i, previous)
+		assert.GreaterOrEqual(t, delay, b.Base)
+		assert.LessOrEqual(t, delay, b.Max)
+		assert.LessOrEqual(t, delay, 3*previous)
+		previous = delay
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BatchOperation is the kind of change applied to a single batch item.
+type BatchOperation string
+
+const (
+	// BatchOperationCreate creates a record.
+	BatchOperationCreate BatchOperation = "create"
+	// BatchOperationDelete deletes a record.
+	BatchOperationDelete BatchOperation = "delete"
+	// BatchOperationUpdate replaces a record with a changed copy.
+	BatchOperationUpdate BatchOperation = "update"
+)
+
+// BatchResult is the outcome of one item of a bulk operation.
+type BatchResult struct {
+	// Item is the record the operation was applied to.
+	Item DNSRecord
+	// Operation is the change applied to the item.
+	Operation BatchOperation
+	// Success is true if the operation was performed. It is false for dry runs.
+	Success bool
+	// Err is the error returned for the item, if any.
+	Err error
+	// DNSID is the identifier of a created record, if reported by the API.
+	DNSID string
+}
+
+// MarshalJSON encodes the result with snake_case keys and the error as a string.
+func (r BatchResult) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Item      DNSRecord      `json:"item"`
+		Operation BatchOperation `json:"operation"`
+		Success   bool           `json:"success"`
+		Error     string         `json:"error,omitempty"`
+		DNSID     string         `json:"dns_id,omitempty"`
+	}{
+		Item:      r.Item,
+		Operation: r.Operation,
+		Success:   r.Success,
+		DNSID:     r.DNSID,
+	}
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// BatchResults holds the per-item outcomes of a bulk operation, in execution order.
+type BatchResults []BatchResult
+
+// Failed returns the items that failed.
+func (r BatchResults) Failed() BatchResults {
+	var failed BatchResults
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of all failed items joined together, or nil if no item failed.
+func (r BatchResults) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s %s %s %q: %w",
+			result.Operation, result.Item.Name, result.Item.Type, result.Item.Content, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// AddRRs creates the records in the zone. A failing record does not stop the
+// batch; check the per-item results or BatchResults.Err. With WithDryRun no
+// records are created.
+func (c *Client) AddRRs(ctx context.Context, zone string, params []CreateDNSRecordParams, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	results := make(BatchResults, 0, len(params))
+	for _, p := range params {
+		results = append(results, c.batchCreate(ctx, zone, p, o.dryRun))
+	}
+
+	return results, nil
+}
+
+// DeleteRRs deletes the records from the zone. A failing record does not stop
+// the batch; check the per-item results or BatchResults.Err. With WithDryRun no
+// records are deleted.
+func (c *Client) DeleteRRs(ctx context.Context, zone string, records []DNSRecord, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	results := make(BatchResults, 0, len(records))
+	for _, rr := range records {
+		results = append(results, c.batchDelete(ctx, zone, rr, o.dryRun))
+	}
+
+	return results, nil
+}
+
+// ImportRecordsCSV creates records read from CSV with the columns name, type,
+// content and an optional TTL in seconds or duration form ("300", "1h").
+// A header row starting with "name" is skipped. Malformed input is rejected
+// before any record is created.
+func (c *Client) ImportRecordsCSV(ctx context.Context, zone string, r io.Reader, opts ...BulkOption) (BatchResults, error) {
+	params, err := parseRecordsCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.AddRRs(ctx, zone, params, opts...)
+}
+
+// SyncZone makes the zone contain exactly the desired records: missing records
+// are created first, then records not in the desired set are deleted. CNAME
+// records not in the desired set are deleted before anything is created. Records
+// are compared by canonical name, type and content. Apex NS records and record
+// types the client cannot manage are never deleted, and WithRecordFilter limits
+// deletions to matching records. With WithDryRun the planned operations are
+// returned without changing the zone. With WithOwner only records owned by the
+// owner are deleted. With WithTTLSync records whose TTL differs from the
+// desired TTL are updated as well.
+func (c *Client) SyncZone(ctx context.Context, zone string, desired []DNSRecord, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	current, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return nil, err
+	}
+	if o.owner != "" {
+		return c.syncOwned(ctx, zone, current, desired, o), nil
+	}
+
+	present := make(map[recordKey]DNSRecord, len(current))
+	for _, rr := range current {
+		present[keyOf(rr)] = rr
+	}
+	wanted := make(map[recordKey]bool, len(desired))
+	for _, rr := range desired {
+		wanted[keyOf(rr)] = true
+	}
+	stale := func(rr DNSRecord) bool {
+		return !wanted[keyOf(rr)] && syncDeletable(rr) && o.filter.match(rr)
+	}
+
+	var results BatchResults
+	for _, rr := range current {
+		if deleteFirst(rr) && stale(rr) {
+			results = append(results, c.batchDelete(ctx, zone, rr, o.dryRun))
+		}
+	}
+
+	seen := make(map[recordKey]bool, len(desired))
+	for _, rr := range desired {
+		key := keyOf(rr)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if existing, ok := present[key]; ok {
+			if o.syncTTL && ttlDiffers(existing, rr) && o.filter.match(existing) {
+				results = append(results, c.batchUpdate(ctx, zone, existing, rr, o.dryRun))
+			}
+			continue
+		}
+		results = append(results, c.batchCreate(ctx, zone, paramsFromRecord(rr), o.dryRun))
+	}
+
+	for _, rr := range current {
+		if !deleteFirst(rr) && stale(rr) {
+			results = append(results, c.batchDelete(ctx, zone, rr, o.dryRun))
+		}
+	}
+
+	return results, nil
+}
+
+// deleteFirst reports whether a record being replaced must be deleted before
+// its replacement is created. A name can hold only one CNAME record and
+// reg.ru rejects a second one, so old CNAME records are deleted first.
+func deleteFirst(rr DNSRecord) bool {
+	return rr.Type.Canonical() == RecordTypeCNAME
+}
+
+// syncDeletable reports whether SyncZone may delete the record.
+func syncDeletable(rr DNSRecord) bool {
+	c := Canonicalize(rr)
+	if !c.Type.Supported() {
+		return false
+	}
+	return !(c.Type == RecordTypeNS && c.Name == "@")
+}
+
+// ttlDiffers reports whether the desired record sets a TTL different from the
+// known TTL of the current record. A TTL inherited from the zone is not the
+// TTL of the record and never differs.
+func ttlDiffers(current, desired DNSRecord) bool {
+	if current.TTLInherited {
+		return false
+	}
+	currentTTL := effectiveTTL(current.TTL, current.TTLDuration)
+	desiredTTL := effectiveTTL(desired.TTL, desired.TTLDuration)
+	return currentTTL > 0 && desiredTTL > 0 && currentTTL != desiredTTL
+}
+
+// batchUpdate replaces a record with the desired copy and reports the outcome.
+func (c *Client) batchUpdate(ctx context.Context, zone string, current, desired DNSRecord, dryRun bool) BatchResult {
+	item := desired
+	item.TTL = effectiveTTL(desired.TTL, desired.TTLDuration)
+	result := BatchResult{Item: item, Operation: BatchOperationUpdate, DNSID: current.ID}
+	if dryRun {
+		return result
+	}
+
+	updated, err := c.UpdateRRFrom(ctx, zone, current, desired)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Item = updated
+	result.Success = true
+	result.DNSID = updated.ID
+	return result
+}
+
+// batchCreate creates a single record and reports the outcome.
+func (c *Client) batchCreate(ctx context.Context, zone string, params CreateDNSRecordParams, dryRun bool) BatchResult {
+	ttl := effectiveTTL(params.TTL, params.TTLDuration)
+	result := BatchResult{
+		Item:      DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: ttl},
+		Operation: BatchOperationCreate,
+	}
+	if dryRun {
+		return result
+	}
+
+	created, err := c.AddRR(ctx, zone, params)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Item = created
+	result.Success = true
+	result.DNSID = created.ID
+	return result
+}
+
+// batchDelete deletes a single record and reports the outcome.
+func (c *Client) batchDelete(ctx context.Context, zone string, rr DNSRecord, dryRun bool) BatchResult {
+	result := BatchResult{Item: rr, Operation: BatchOperationDelete, DNSID: rr.ID}
+	if dryRun {
+		return result
+	}
+
+	if err := c.DeleteRR(ctx, zone, rr); err != nil {
+		result.Err = err
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+// parseRecordsCSV reads record parameters from CSV.
+func parseRecordsCSV(r io.Reader) ([]CreateDNSRecordParams, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var params []CreateDNSRecordParams
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(params) == 0 && strings.EqualFold(strings.TrimSpace(fields[0]), "name") {
+			continue
+		}
+		if len(fields) < 3 || len(fields) > 4 {
+			return nil, fmt.Errorf("csv line %d: expected 3 or 4 fields, got %d", line, len(fields))
+		}
+
+		p := CreateDNSRecordParams{
+			Name:    strings.TrimSpace(fields[0]),
+			Type:    RecordType(strings.TrimSpace(fields[1])).Canonical(),
+			Content: strings.TrimSpace(fields[2]),
+		}
+		if len(fields) == 4 && strings.TrimSpace(fields[3]) != "" {
+			ttl, err := ParseTTL(strings.TrimSpace(fields[3]))
+			if err != nil {
+				return nil, fmt.Errorf("csv line %d: %w", line, err)
+			}
+			p.TTL = ttl
+		}
+		params = append(params, p)
+	}
+
+	return params, nil
+}
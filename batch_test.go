@@ -0,0 +1,264 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AddRRs(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_alias": AddNSResponse{
+			Answer: AddNSAnswer{Domains: []DomainResult{{DName: "example.com", Result: "success", DNSID: "42"}}},
+		},
+		"zone/add_txt": map[string]interface{}{"result": "error", "error_text": "invalid content"},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.AddRRs(context.Background(), "example.com", []CreateDNSRecordParams{
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.10"},
+		{Name: "www", Type: RecordTypeTXT, Content: "bad"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Len(t, *calls, 2)
+
+	assert.True(t, results[0].Success)
+	assert.Equal(t, BatchOperationCreate, results[0].Operation)
+	assert.Equal(t, "42", results[0].DNSID)
+
+	assert.False(t, results[1].Success)
+	var apiErr *APIError
+	assert.True(t, errors.As(results[1].Err, &apiErr))
+
+	require.Len(t, results.Failed(), 1)
+	assert.ErrorContains(t, results.Err(), "invalid content")
+
+	data, err := json.Marshal(results[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"item":{"name":"www","type":"TXT","content":"bad"},"operation":"create","success":false,"error":"API error: invalid content"}`, string(data))
+}
+
+func TestClient_DeleteRRs_DryRun(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.DeleteRRs(context.Background(), "example.com", []DNSRecord{
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+	}, WithDryRun())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, BatchOperationDelete, results[0].Operation)
+	assert.False(t, results[0].Success)
+	assert.NoError(t, results.Err())
+	assert.Empty(t, *calls)
+}
+
+func TestClient_ImportRecordsCSV(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	csv := "name,type,content,ttl\n" +
+		"www,a,192.0.2.10,300\n" +
+		"# comment\n" +
+		"@,MX,10 mail.example.com,1h\n" +
+		"txt,TXT,\"v=spf1, -all\"\n"
+
+	results, err := client.ImportRecordsCSV(context.Background(), "example.com", strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results.Err())
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.10", TTL: 300}, results[0].Item)
+	assert.Equal(t, 3600, results[1].Item.TTL)
+	assert.Equal(t, "v=spf1, -all", results[2].Item.Content)
+	assert.Len(t, *calls, 3)
+}
+
+func TestClient_ImportRecordsCSV_Invalid(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.ImportRecordsCSV(context.Background(), "example.com", strings.NewReader("www,A,192.0.2.1\nwww,A\n"))
+	assert.ErrorContains(t, err, "csv line 2")
+
+	_, err = client.ImportRecordsCSV(context.Background(), "example.com", strings.NewReader("www,A,192.0.2.1,soon\n"))
+	assert.ErrorIs(t, err, ErrInvalidTTL)
+	assert.Empty(t, *calls)
+}
+
+func TestClient_SyncZone(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "NS", Content: "ns1.reg.ru"},
+		ResourceRecord{Subname: "@", Rectype: "SOA", Content: "ns1.reg.ru. hostmaster.reg.ru. 1 2 3 4 5"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	desired := []DNSRecord{
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"},
+		{Name: "WWW", Type: RecordTypeA, Content: "192.0.2.2"},
+		{Name: "api", Type: RecordTypeA, Content: "192.0.2.3"},
+		{Name: "api", Type: RecordTypeA, Content: "192.0.2.3"},
+	}
+
+	results, err := client.SyncZone(context.Background(), "example.com", desired)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var summary []string
+	for _, result := range results {
+		summary = append(summary, string(result.Operation)+" "+result.Item.Name+" "+string(result.Item.Type))
+	}
+	assert.Equal(t, []string{"create api A", "delete www AAAA", "delete @ MX"}, summary)
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	assert.Equal(t, []string{"zone/get_resource_records", "zone/add_alias", "zone/remove_record", "zone/remove_record"}, paths)
+}
+
+func TestClient_SyncZone_CNAMEDeletedFirst(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "blog", Rectype: "CNAME", Content: "old.example.net"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	desired := []DNSRecord{
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"},
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+		{Name: "blog", Type: RecordTypeCNAME, Content: "new.example.net"},
+	}
+
+	results, err := client.SyncZone(context.Background(), "example.com", desired)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var summary []string
+	for _, result := range results {
+		summary = append(summary, string(result.Operation)+" "+result.Item.Name+" "+string(result.Item.Type))
+	}
+	assert.Equal(t, []string{"delete blog CNAME", "create blog CNAME", "delete www AAAA", "delete @ MX"}, summary)
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	assert.Equal(t, []string{
+		"zone/get_resource_records", "zone/remove_record", "zone/add_cname", "zone/remove_record", "zone/remove_record",
+	}, paths)
+}
+
+func TestClient_SyncZone_Filter(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.SyncZone(context.Background(), "example.com", nil,
+		WithRecordFilter(FilterByType(RecordTypeMX)), WithDryRun())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, RecordTypeMX, results[0].Item.Type)
+	assert.False(t, results[0].Success)
+	assert.Len(t, *calls, 1)
+}
+
+func TestClient_SyncZone_TTLSync(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].SOA = &SOAInfo{TTL: "1h"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+		"zone/get_ns": ZoneListResponse{
+			Answer: ZoneListAnswer{
+				Domains: []DomainWithRecords{
+					{
+						DName: "example.com",
+						NSList: []NSRecord{
+							{Subdomain: "@", Type: "A", Content: "192.0.2.1", TTL: 3600, DNSID: "101"},
+							{Subdomain: "www", Type: "A", Content: "192.0.2.2", TTL: 3600, DNSID: "102"},
+						},
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	desired := []DNSRecord{
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300},
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.2", TTL: 3600},
+		{Name: "www", Type: RecordTypeAAAA, Content: "2001:db8::1", TTL: 300},
+		{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"},
+	}
+
+	client := setupTestClient(t, server)
+	results, err := client.SyncZone(context.Background(), "example.com", desired)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Listed TTLs are inherited from the zone and never differ, so repeated
+	// syncs make no writes
+	for i := 0; i < 2; i++ {
+		results, err = client.SyncZone(context.Background(), "example.com", desired, WithTTLSync())
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	}
+	assert.Equal(t, 3, countCalls(*calls, "zone/get_resource_records"))
+	assert.Len(t, *calls, 3)
+
+	client = setupTestClient(t, server, WithRecordIDs())
+	results, err = client.SyncZone(context.Background(), "example.com", desired, WithTTLSync())
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+	require.Len(t, results, 1)
+	assert.Equal(t, BatchOperationUpdate, results[0].Operation)
+	assert.Equal(t, "@", results[0].Item.Name)
+	assert.Equal(t, 300, results[0].Item.TTL)
+	assert.True(t, results[0].Success)
+
+	assert.Equal(t, 1, countCalls(*calls, "zone/remove_record"))
+	assert.Equal(t, 1, countCalls(*calls, "zone/add_alias"))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RecordAction is a single change applied by BatchUpdate.
+type RecordAction struct {
+	// Operation is BatchOperationCreate or BatchOperationDelete.
+	Operation BatchOperation
+	// Record is the record to create or delete.
+	Record DNSRecord
+}
+
+// BatchUpdate applies the actions to the zone in a single zone/update_records
+// call instead of one call per record. The results follow the order of the
+// actions; an action rejected by the API does not stop the others. Actions
+// are checked before the call, so an unsupported or invalid record fails the
+// whole batch without changing the zone.
+func (c *Client) BatchUpdate(ctx context.Context, zone string, actions []RecordAction) (BatchResults, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	actionList := make([]map[string]interface{}, 0, len(actions))
+	results := make(BatchResults, 0, len(actions))
+	for i, action := range actions {
+		var (
+			path   string
+			apiReq APIRequest
+			err    error
+		)
+		item := action.Record
+		switch action.Operation {
+		case BatchOperationCreate:
+			var params CreateDNSRecordParams
+			path, apiReq, params, err = c.Records.addRequest(zone, paramsFromRecord(action.Record))
+			item.Type = params.Type
+		case BatchOperationDelete:
+			path, apiReq, err = c.Records.removeRequest(zone, action.Record)
+		default:
+			err = fmt.Errorf("unsupported operation %q", action.Operation)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+
+		entry, err := batchAction(path, apiReq)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		actionList = append(actionList, entry)
+		results = append(results, BatchResult{Item: item, Operation: action.Operation, DNSID: action.Record.ID})
+	}
+
+	apiZone := c.asciiName(zone)
+	apiReq := ZoneUpdateRecordsRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneUpdateRecordsDomain{
+			{DName: apiZone, ActionList: actionList},
+		},
+	}
+
+	resp, err := Call[ZoneUpdateRecordsResponse](ctx, c, "zone/update_records", &apiReq)
+	c.InvalidateRecordsCache(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var domain *ZoneUpdateRecordsDomainResult
+	for i := range resp.Answer.Domains {
+		if resp.Answer.Domains[i].DName == apiZone {
+			domain = &resp.Answer.Domains[i]
+			break
+		}
+	}
+	if domain == nil {
+		return nil, fmt.Errorf("no update_records result for zone %s", zone)
+	}
+
+	for i := range results {
+		if i >= len(domain.ActionList) {
+			results[i].Err = newAPIError(domain.ErrorCode, "no result reported for action")
+			continue
+		}
+		actionResult := domain.ActionList[i]
+		if actionResult.Result != "success" {
+			results[i].Err = newAPIError(actionResult.ErrorCode, actionResult.ErrorText)
+			continue
+		}
+		results[i].Success = true
+		if actionResult.DNSID != "" {
+			results[i].DNSID = actionResult.DNSID
+			results[i].Item.ID = actionResult.DNSID
+		}
+	}
+
+	return results, nil
+}
+
+// batchAction returns the action_list entry for a single-record request: its
+// parameters without domains and credentials, and the method name as "action".
+func batchAction(path string, apiReq APIRequest) (map[string]interface{}, error) {
+	entry, err := requestFields(apiReq)
+	if err != nil {
+		return nil, err
+	}
+	delete(entry, "domains")
+	delete(entry, "username")
+	delete(entry, "password")
+	entry["action"] = strings.TrimPrefix(path, "zone/")
+	return entry, nil
+}
+
+// requestFields returns the JSON fields of a request.
+func requestFields(apiReq APIRequest) (map[string]interface{}, error) {
+	data, err := json.Marshal(apiReq)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
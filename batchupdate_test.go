@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_BatchUpdate(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/update_records": ZoneUpdateRecordsResponse{
+			Answer: ZoneUpdateRecordsAnswer{
+				Domains: []ZoneUpdateRecordsDomainResult{
+					{
+						DName:  "example.com",
+						Result: "success",
+						ActionList: []ZoneUpdateRecordsActionResult{
+							{Action: "add_alias", Result: "success", DNSID: "101"},
+							{Action: "add_mx", Result: "error", ErrorCode: "INVALID_MAIL_SERVER", ErrorText: "Invalid mail server"},
+							{Action: "remove_record", Result: "success"},
+						},
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.BatchUpdate(context.Background(), "example.com", []RecordAction{
+		{Operation: BatchOperationCreate, Record: DNSRecord{Name: "www", Type: "a", Content: "192.0.2.1", TTL: 300}},
+		{Operation: BatchOperationCreate, Record: DNSRecord{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"}},
+		{Operation: BatchOperationDelete, Record: DNSRecord{Name: "old", Type: RecordTypeA, Content: "192.0.2.9"}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/update_records", (*calls)[0].Path)
+	domains := (*calls)[0].Input["domains"].([]interface{})
+	domain := domains[0].(map[string]interface{})
+	assert.Equal(t, "example.com", domain["dname"])
+	actions := domain["action_list"].([]interface{})
+	require.Len(t, actions, 3)
+	assert.Equal(t, map[string]interface{}{
+		"action": "add_alias", "subdomain": "www", "ipaddr": "192.0.2.1", "ttl": float64(300),
+	}, actions[0])
+	assert.Equal(t, "add_mx", actions[1].(map[string]interface{})["action"])
+	assert.Equal(t, "mail.example.com", actions[1].(map[string]interface{})["mail_server"])
+	assert.Equal(t, map[string]interface{}{
+		"action": "remove_record", "subdomain": "old", "content": "192.0.2.9", "record_type": "A",
+	}, actions[2])
+
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, "101", results[0].DNSID)
+	assert.Equal(t, RecordTypeA, results[0].Item.Type)
+	assert.False(t, results[1].Success)
+	var apiErr *APIError
+	require.ErrorAs(t, results[1].Err, &apiErr)
+	assert.Equal(t, "INVALID_MAIL_SERVER", apiErr.Code)
+	assert.True(t, results[2].Success)
+	assert.Len(t, results.Failed(), 1)
+}
+
+func TestClient_BatchUpdate_InvalidAction(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.BatchUpdate(context.Background(), "example.com", []RecordAction{
+		{Operation: BatchOperationCreate, Record: DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"}},
+		{Operation: BatchOperationDelete, Record: DNSRecord{Name: "www", Type: "BOGUS", Content: "x"}},
+	})
+	assert.ErrorIs(t, err, ErrUnsupportedRecordType)
+	assert.ErrorContains(t, err, "action 1")
+	assert.Empty(t, *calls)
+
+	results, err := client.BatchUpdate(context.Background(), "example.com", nil)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
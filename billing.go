@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import "context"
+
+// BillingService groups the bill and payment methods of the API.
+type BillingService struct {
+	client *Client
+}
+
+// ListUnpaidBills returns the bills of the account that have not been paid yet.
+func (s *BillingService) ListUnpaidBills(ctx context.Context) ([]Bill, error) {
+	resp, err := Call[BillListResponse](ctx, s.client, "bill/get_not_payed", &BillGetNotPayedRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	bills := make([]Bill, 0, len(resp.Answer.Bills))
+	for _, bill := range resp.Answer.Bills {
+		bills = append(bills, Bill{
+			ID:       bill.GetBillID(),
+			Date:     bill.BillDate,
+			Currency: bill.Currency,
+			PayType:  bill.PayType,
+			Status:   bill.Status,
+			Total:    parseAmount(bill.Total),
+		})
+	}
+
+	return bills, nil
+}
+
+// PayFromBalance switches the bill to payment from the prepaid balance, which
+// pays it at once if the balance is sufficient. It returns the updated bill.
+func (s *BillingService) PayFromBalance(ctx context.Context, billID string) (Bill, error) {
+	apiReq := BillChangePayTypeRequest{
+		Bills:   []BillRef{{BillID: billID}},
+		PayType: "prepay",
+	}
+
+	resp, err := Call[BillChangePayTypeResponse](ctx, s.client, "bill/change_pay_type", &apiReq)
+	if err != nil {
+		return Bill{}, err
+	}
+
+	if len(resp.Answer.Bills) == 0 {
+		return Bill{}, &APIError{Message: "no result for bill " + billID}
+	}
+	result := resp.Answer.Bills[0]
+	if result.Result != "" && result.Result != "success" {
+		message := result.ErrorText
+		if message == "" {
+			message = result.Result
+		}
+		return Bill{}, newAPIError(result.ErrorCode, message)
+	}
+
+	id := result.GetBillID()
+	if id == "" {
+		id = billID
+	}
+	return Bill{
+		ID:       id,
+		Currency: result.Currency,
+		PayType:  "prepay",
+		Status:   result.Status,
+		Total:    parseAmount(result.Payment),
+	}, nil
+}
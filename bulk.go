@@ -0,0 +1,403 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// RecordFilter reports whether a record should be included in a bulk operation.
+// A nil filter matches all records.
+type RecordFilter func(rr DNSRecord) bool
+
+// match reports whether the record passes the filter.
+func (f RecordFilter) match(rr DNSRecord) bool {
+	return f == nil || f(rr)
+}
+
+// FilterByType returns a filter matching records of any of the given types.
+func FilterByType(types ...RecordType) RecordFilter {
+	return func(rr DNSRecord) bool {
+		for _, t := range types {
+			if rr.Type.Canonical() == t.Canonical() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByName returns a filter matching records with any of the given names.
+func FilterByName(names ...string) RecordFilter {
+	return func(rr DNSRecord) bool {
+		for _, name := range names {
+			if canonicalName(rr.Name) == canonicalName(name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// BulkOption represents an option for configuring bulk operations.
+type BulkOption func(*bulkOptions)
+
+// bulkOptions holds settings shared by bulk operations.
+type bulkOptions struct {
+	dryRun      bool
+	filter      RecordFilter
+	keepTargets bool
+	ttl         int
+	progress    func(step PlanStep)
+	owner       string
+	syncTTL     bool
+	replace     bool
+}
+
+// WithDryRun makes a bulk operation compute and report its changes without calling any mutating API method.
+func WithDryRun() BulkOption {
+	return func(o *bulkOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithRecordFilter restricts a bulk operation to records matching the filter.
+func WithRecordFilter(filter RecordFilter) BulkOption {
+	return func(o *bulkOptions) {
+		o.filter = filter
+	}
+}
+
+// WithKeepTargets disables rewriting of hostname targets that point into the
+// source zone when records are cloned into another zone.
+func WithKeepTargets() BulkOption {
+	return func(o *bulkOptions) {
+		o.keepTargets = true
+	}
+}
+
+// WithTTL sets the TTL in seconds of records created by a bulk operation.
+// By default the zone default TTL is used.
+func WithTTL(ttl int) BulkOption {
+	return func(o *bulkOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithProgress sets a function called after each step of ApplyPlan.
+func WithProgress(fn func(step PlanStep)) BulkOption {
+	return func(o *bulkOptions) {
+		o.progress = fn
+	}
+}
+
+// WithTTLSync makes SyncZone update records that already exist but whose TTL
+// differs from the desired TTL. Records without a known or desired TTL are
+// left unchanged.
+func WithTTLSync() BulkOption {
+	return func(o *bulkOptions) {
+		o.syncTTL = true
+	}
+}
+
+// WithReplace makes ImportZoneFile replace the zone contents instead of merging:
+// records missing from the file are deleted, as with SyncZone.
+func WithReplace() BulkOption {
+	return func(o *bulkOptions) {
+		o.replace = true
+	}
+}
+
+// newBulkOptions applies the options over the defaults.
+func newBulkOptions(opts []BulkOption) bulkOptions {
+	var o bulkOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// TTLUpdateReport describes the outcome of SetZoneTTL.
+type TTLUpdateReport struct {
+	// DryRun is true if no changes were made.
+	DryRun bool
+	// TTL is the requested TTL in seconds.
+	TTL int
+	// Matched contains all records selected by the filter.
+	Matched []DNSRecord
+	// Updated contains the records that were updated (empty for dry runs).
+	Updated []DNSRecord
+}
+
+// SetZoneTTL sets the TTL of all records in the zone matching the filter, for
+// example to lower TTLs ahead of a migration. With WithDryRun the matched
+// records are reported but not changed. The changes are sent in a single
+// zone/update_records request; on error the report contains the records that
+// were updated.
+func (c *Client) SetZoneTTL(ctx context.Context, zone string, ttl int, filter RecordFilter, opts ...BulkOption) (TTLUpdateReport, error) {
+	o := newBulkOptions(opts)
+	report := TTLUpdateReport{DryRun: o.dryRun, TTL: ttl}
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return report, err
+	}
+
+	for _, record := range records {
+		if filter.match(record) {
+			report.Matched = append(report.Matched, record)
+		}
+	}
+
+	if o.dryRun {
+		return report, nil
+	}
+
+	if len(report.Matched) == 0 {
+		return report, nil
+	}
+
+	// Each record is replaced by a delete and a create with the new TTL, all
+	// sent in one zone/update_records call.
+	actions := make([]RecordAction, 0, 2*len(report.Matched))
+	for _, record := range report.Matched {
+		updated := record
+		updated.TTL = ttl
+		updated.TTLInherited = false
+		actions = append(actions,
+			RecordAction{Operation: BatchOperationDelete, Record: record},
+			RecordAction{Operation: BatchOperationCreate, Record: updated},
+		)
+	}
+
+	results, err := c.BatchUpdate(ctx, zone, actions)
+	if err != nil {
+		return report, err
+	}
+	for _, result := range results {
+		if result.Operation == BatchOperationCreate && result.Success {
+			report.Updated = append(report.Updated, result.Item)
+		}
+	}
+
+	return report, results.Err()
+}
+
+// paramsFromRecord returns the parameters needed to create a copy of the record.
+func paramsFromRecord(rr DNSRecord) CreateDNSRecordParams {
+	return CreateDNSRecordParams{
+		Name:        rr.Name,
+		Type:        rr.Type,
+		Content:     rr.Content,
+		TTL:         rr.TTL,
+		TTLDuration: rr.TTLDuration,
+		Priority:    rr.Priority,
+	}
+}
+
+// MoveReport describes the outcome of MoveRR.
+type MoveReport struct {
+	// DryRun is true if no changes were made.
+	DryRun bool
+	// Source contains the records found under the old name.
+	Source []DNSRecord
+	// Created contains the records created under the new name.
+	Created []DNSRecord
+	// Removed contains the records removed from the old name.
+	Removed []DNSRecord
+}
+
+// MoveRR renames records by cloning all records of the given type (or of all
+// types if rtype is empty) from oldName to newName and then removing the
+// originals. Content and TTL are preserved; MX priority is part of the content.
+// If creating a copy fails, copies created so far are removed again and the
+// originals are left untouched.
+func (c *Client) MoveRR(ctx context.Context, zone, oldName, newName string, rtype RecordType, opts ...BulkOption) (MoveReport, error) {
+	o := newBulkOptions(opts)
+	report := MoveReport{DryRun: o.dryRun}
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return report, err
+	}
+
+	filter := FilterByName(oldName)
+	for _, record := range records {
+		if filter.match(record) && (rtype == "" || record.Type == rtype.Canonical()) {
+			report.Source = append(report.Source, record)
+		}
+	}
+	if len(report.Source) == 0 {
+		return report, &RecordNotFoundError{RecordName: oldName}
+	}
+
+	if o.dryRun {
+		return report, nil
+	}
+
+	// Create all copies first so that a failure never leaves the name without records
+	for _, record := range report.Source {
+		params := paramsFromRecord(record)
+		params.Name = newName
+		created, err := c.AddRR(ctx, zone, params)
+		if err != nil {
+			for _, rollback := range report.Created {
+				_ = c.DeleteRR(ctx, zone, rollback)
+			}
+			report.Created = nil
+			return report, err
+		}
+		report.Created = append(report.Created, created)
+	}
+
+	for _, record := range report.Source {
+		if err := c.DeleteRR(ctx, zone, record); err != nil {
+			return report, err
+		}
+		report.Removed = append(report.Removed, record)
+	}
+
+	return report, nil
+}
+
+// CloneReport describes the outcome of CloneZone.
+type CloneReport struct {
+	// DryRun is true if no changes were made.
+	DryRun bool
+	// Planned contains the records to be created in the destination zone.
+	Planned []DNSRecord
+	// Created contains the records created in the destination zone (empty for dry runs).
+	Created []DNSRecord
+	// Skipped contains source records that were not cloned because their type
+	// is not supported or an equal record already exists in the destination zone.
+	Skipped []DNSRecord
+}
+
+// CloneZone copies all supported records from srcZone into dstZone in the same
+// account. Hostname targets pointing at srcZone or its subdomains (CNAME, NS, PTR,
+// MX and SRV) are rewritten to dstZone unless WithKeepTargets is given.
+// Records already present in dstZone are skipped, so the operation can be repeated.
+// Use WithRecordFilter to clone a subset of records and WithDryRun to preview.
+func (c *Client) CloneZone(ctx context.Context, srcZone, dstZone string, opts ...BulkOption) (CloneReport, error) {
+	o := newBulkOptions(opts)
+	report := CloneReport{DryRun: o.dryRun}
+
+	source, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: srcZone})
+	if err != nil {
+		return report, err
+	}
+
+	existing, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: dstZone})
+	if err != nil {
+		return report, err
+	}
+	present := make(map[recordKey]bool, len(existing))
+	for _, record := range existing {
+		present[keyOf(record)] = true
+	}
+
+	for _, record := range source {
+		if !o.filter.match(record) {
+			continue
+		}
+		if !record.Type.Supported() {
+			report.Skipped = append(report.Skipped, record)
+			continue
+		}
+
+		clone := record
+		clone.ID = ""
+		clone.Name = relativeName(record.Name, srcZone)
+		if !o.keepTargets {
+			clone.Content = rewriteTarget(clone.Type, clone.Content, srcZone, dstZone)
+		}
+
+		if present[keyOf(clone)] {
+			report.Skipped = append(report.Skipped, record)
+			continue
+		}
+		present[keyOf(clone)] = true
+		report.Planned = append(report.Planned, clone)
+	}
+
+	if o.dryRun {
+		return report, nil
+	}
+
+	for _, record := range report.Planned {
+		created, err := c.AddRR(ctx, dstZone, paramsFromRecord(record))
+		if err != nil {
+			return report, err
+		}
+		report.Created = append(report.Created, created)
+	}
+
+	return report, nil
+}
+
+// relativeName converts an absolute name inside the zone into a name relative
+// to the zone ("www.example.com." -> "www", "example.com" -> "@").
+func relativeName(name, zone string) string {
+	canonical := canonicalName(name)
+	zone = canonicalHost(zone)
+	switch {
+	case canonical == zone:
+		return "@"
+	case strings.HasSuffix(canonical, "."+zone):
+		return strings.TrimSuffix(canonical, "."+zone)
+	default:
+		return name
+	}
+}
+
+// rewriteHost replaces the zone suffix of a hostname pointing into srcZone with dstZone.
+func rewriteHost(host, srcZone, dstZone string) string {
+	canonical := canonicalHost(host)
+	srcZone = canonicalHost(srcZone)
+	switch {
+	case canonical == srcZone:
+		return dstZone
+	case strings.HasSuffix(canonical, "."+srcZone):
+		return strings.TrimSuffix(canonical, srcZone) + dstZone
+	default:
+		return host
+	}
+}
+
+// rewriteTarget rewrites hostname targets in record content from srcZone to dstZone.
+func rewriteTarget(recordType RecordType, content, srcZone, dstZone string) string {
+	switch recordType {
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		return rewriteHost(content, srcZone, dstZone)
+	case RecordTypeMX:
+		if priority, host, ok := splitMXContent(content); ok {
+			return strconv.Itoa(priority) + " " + rewriteHost(host, srcZone, dstZone)
+		}
+		return rewriteHost(content, srcZone, dstZone)
+	case RecordTypeSRV:
+		fields := strings.Fields(content)
+		if len(fields) == 0 {
+			return content
+		}
+		fields[len(fields)-1] = rewriteHost(fields[len(fields)-1], srcZone, dstZone)
+		return strings.Join(fields, " ")
+	default:
+		return content
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testZoneRecords returns a get_resource_records response with a few records in example.com.
+func testZoneRecords() ZoneGetResourceRecordsResponse {
+	return ZoneGetResourceRecordsResponse{
+		Answer: ZoneGetResourceRecordsAnswer{
+			Domains: []DomainWithResourceRecords{
+				{
+					DName:  "example.com",
+					Result: "success",
+					RRList: []ResourceRecord{
+						{Subname: "@", Rectype: "A", Content: "192.0.2.1"},
+						{Subname: "www", Rectype: "A", Content: "192.0.2.2"},
+						{Subname: "www", Rectype: "AAAA", Content: "2001:db8::1"},
+						{Subname: "@", Rectype: "MX", Content: "10 mail.example.com"},
+					},
+				},
+			},
+		},
+		Result: "success",
+	}
+}
+
+func TestRecordFilters(t *testing.T) {
+	rr := DNSRecord{Name: "WWW", Type: RecordTypeA, Content: "192.0.2.1"}
+
+	assert.True(t, RecordFilter(nil).match(rr))
+	assert.True(t, FilterByType(RecordTypeAAAA, "a").match(rr))
+	assert.False(t, FilterByType(RecordTypeMX).match(rr))
+	assert.True(t, FilterByName("www").match(rr))
+	assert.False(t, FilterByName("mail").match(rr))
+}
+
+// updateRecordsResponse returns a zone/update_records response for example.com
+// with the given action results.
+func updateRecordsResponse(actions ...ZoneUpdateRecordsActionResult) ZoneUpdateRecordsResponse {
+	return ZoneUpdateRecordsResponse{
+		Answer: ZoneUpdateRecordsAnswer{
+			Domains: []ZoneUpdateRecordsDomainResult{
+				{DName: "example.com", Result: "success", ActionList: actions},
+			},
+		},
+	}
+}
+
+func TestClient_SetZoneTTL(t *testing.T) {
+	success := ZoneUpdateRecordsActionResult{Result: "success"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+		"zone/update_records":       updateRecordsResponse(success, success, success, success, success, success),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.SetZoneTTL(context.Background(), "example.com", 300, FilterByType(RecordTypeA, RecordTypeAAAA))
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Len(t, report.Matched, 3)
+	require.Len(t, report.Updated, 3)
+	assert.Equal(t, 300, report.Updated[0].TTL)
+
+	// One list call plus a single batch with a remove/add pair per record
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/update_records", (*calls)[1].Path)
+	domain := (*calls)[1].Input["domains"].([]interface{})[0].(map[string]interface{})
+	actions := domain["action_list"].([]interface{})
+	require.Len(t, actions, 6)
+	assert.Equal(t, "remove_record", actions[0].(map[string]interface{})["action"])
+	assert.Equal(t, "add_alias", actions[1].(map[string]interface{})["action"])
+	assert.EqualValues(t, 300, actions[1].(map[string]interface{})["ttl"])
+}
+
+func TestClient_SetZoneTTL_PartialFailure(t *testing.T) {
+	success := ZoneUpdateRecordsActionResult{Result: "success"}
+	failure := ZoneUpdateRecordsActionResult{Result: "error", ErrorCode: "IP_INVALID", ErrorText: "Invalid IP"}
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+		"zone/update_records":       updateRecordsResponse(success, success, success, failure),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.SetZoneTTL(context.Background(), "example.com", 300, FilterByType(RecordTypeA))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "IP_INVALID", apiErr.Code)
+	assert.Len(t, report.Matched, 2)
+	require.Len(t, report.Updated, 1)
+	assert.Equal(t, "@", report.Updated[0].Name)
+}
+
+func TestClient_SetZoneTTL_DryRun(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.SetZoneTTL(context.Background(), "example.com", 300, nil, WithDryRun())
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Matched, 4)
+	assert.Empty(t, report.Updated)
+	assert.Len(t, *calls, 1, "dry run should only list records")
+}
+
+func TestClient_MoveRR(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.MoveRR(context.Background(), "example.com", "www", "web", "")
+	require.NoError(t, err)
+	require.Len(t, report.Source, 2)
+	require.Len(t, report.Created, 2)
+	require.Len(t, report.Removed, 2)
+	assert.Equal(t, "web", report.Created[0].Name)
+
+	// List, two adds, then two removes
+	require.Len(t, *calls, 5)
+	assert.Equal(t, "zone/add_alias", (*calls)[1].Path)
+	assert.Equal(t, "web", (*calls)[1].Input["subdomain"])
+	assert.Equal(t, "zone/add_aaaa", (*calls)[2].Path)
+	assert.Equal(t, "zone/remove_record", (*calls)[3].Path)
+	assert.Equal(t, "www", (*calls)[3].Input["subdomain"])
+}
+
+func TestClient_MoveRR_ByType(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.MoveRR(context.Background(), "example.com", "www", "web", RecordTypeAAAA)
+	require.NoError(t, err)
+	require.Len(t, report.Created, 1)
+	assert.Equal(t, RecordTypeAAAA, report.Created[0].Type)
+	assert.Len(t, *calls, 3)
+}
+
+func TestClient_MoveRR_NotFound(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.MoveRR(context.Background(), "example.com", "missing", "web", "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRecordNotFound))
+}
+
+func TestClient_MoveRR_RollbackOnFailure(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+		"zone/add_aaaa":             APIResponse{ErrorText: "add failed"},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.MoveRR(context.Background(), "example.com", "www", "web", "")
+	require.Error(t, err)
+
+	// List, add A, failed add AAAA, rollback of the A copy; originals untouched
+	require.Len(t, *calls, 4)
+	assert.Equal(t, "zone/remove_record", (*calls)[3].Path)
+	assert.Equal(t, "web", (*calls)[3].Input["subdomain"])
+}
+
+func TestRewriteTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		recordType RecordType
+		content    string
+		want       string
+	}{
+		{name: "CNAME apex", recordType: RecordTypeCNAME, content: "example.com.", want: "example.ru"},
+		{name: "CNAME subdomain", recordType: RecordTypeCNAME, content: "www.example.com", want: "www.example.ru"},
+		{name: "CNAME external", recordType: RecordTypeCNAME, content: "example.github.io", want: "example.github.io"},
+		{name: "CNAME suffix lookalike", recordType: RecordTypeCNAME, content: "notexample.com", want: "notexample.com"},
+		{name: "MX", recordType: RecordTypeMX, content: "10 mail.example.com", want: "10 mail.example.ru"},
+		{name: "SRV", recordType: RecordTypeSRV, content: "10 0 5060 sip.example.com", want: "10 0 5060 sip.example.ru"},
+		{name: "TXT untouched", recordType: RecordTypeTXT, content: "example.com", want: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rewriteTarget(tt.recordType, tt.content, "example.com", "example.ru"))
+		})
+	}
+}
+
+func TestRelativeName(t *testing.T) {
+	assert.Equal(t, "www", relativeName("www.example.com.", "example.com"))
+	assert.Equal(t, "@", relativeName("example.com", "example.com"))
+	assert.Equal(t, "www", relativeName("www", "example.com"))
+}
+
+func TestClient_CloneZone(t *testing.T) {
+	destination := ZoneGetResourceRecordsResponse{
+		Answer: ZoneGetResourceRecordsAnswer{
+			Domains: []DomainWithResourceRecords{
+				{
+					DName:  "example.ru",
+					Result: "success",
+					RRList: []ResourceRecord{
+						{Subname: "@", Rectype: "A", Content: "192.0.2.1"},
+					},
+				},
+			},
+		},
+	}
+
+	var calls []apiCall
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		var input map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("input_data")), &input))
+		calls = append(calls, apiCall{Path: strings.TrimPrefix(r.URL.Path, "/"), Input: input})
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/zone/get_resource_records" {
+			domains := input["domains"].([]interface{})
+			if domains[0].(map[string]interface{})["dname"] == "example.ru" {
+				require.NoError(t, json.NewEncoder(w).Encode(destination))
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(testZoneRecords()))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"result": "success"}))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.CloneZone(context.Background(), "example.com", "example.ru")
+	require.NoError(t, err)
+	require.Len(t, report.Skipped, 1, "apex A record already exists in destination")
+	require.Len(t, report.Created, 3)
+	assert.Equal(t, "10 mail.example.ru", report.Created[2].Content)
+
+	// Two list calls plus three adds into the destination zone
+	require.Len(t, calls, 5)
+	for _, call := range calls[2:] {
+		domains := call.Input["domains"].([]interface{})
+		assert.Equal(t, "example.ru", domains[0].(map[string]interface{})["dname"])
+	}
+}
+
+func TestClient_CloneZone_DryRunWithFilter(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.CloneZone(context.Background(), "example.com", "example.ru",
+		WithDryRun(),
+		WithRecordFilter(FilterByType(RecordTypeMX)),
+		WithKeepTargets(),
+	)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Planned, 1)
+	assert.Equal(t, "10 mail.example.com", report.Planned[0].Content)
+	assert.Len(t, *calls, 2)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"strings"
+)
+
+// CAA issuer domains of common certificate authorities.
+const (
+	CAAIssuerLetsEncrypt = "letsencrypt.org"
+	// CAAIssuerZeroSSL is the issuer domain of ZeroSSL, which issues through Sectigo.
+	CAAIssuerZeroSSL = "sectigo.com"
+	CAAIssuerSectigo = "sectigo.com"
+)
+
+// CAAPolicy is the complete set of CAA records of a name, which together
+// decide which certificate authorities may issue certificates for it.
+type CAAPolicy struct {
+	// Name is the record name the policy applies to. Defaults to the zone apex.
+	Name string
+	// Issuers are the CA domains allowed to issue certificates, such as
+	// CAAIssuerLetsEncrypt. Without issuers no CA may issue at all.
+	Issuers []string
+	// Wildcard allows the issuers to issue wildcard certificates as well;
+	// otherwise wildcard issuance is forbidden.
+	Wildcard bool
+	// IodefMail is the address CAs report policy violations to, if any.
+	IodefMail string
+	// Records are the CAA records of the policy. If empty, SetCAAPolicy
+	// generates them from Issuers, Wildcard and IodefMail.
+	Records []DNSRecord
+}
+
+// BuildCAAPolicy returns a CAA policy for the zone apex allowing the issuers
+// (CA domains such as "letsencrypt.org") to issue certificates. If wildcard is
+// true the same issuers may issue wildcard certificates; otherwise wildcard
+// issuance is forbidden. Without issuers no CA may issue at all. If iodefMail is
+// not empty, CAs report policy violations to that address.
+func BuildCAAPolicy(issuers []string, wildcard bool, iodefMail string) CAAPolicy {
+	policy := CAAPolicy{Name: "@", Issuers: issuers, Wildcard: wildcard, IodefMail: iodefMail}
+	policy.Records = policy.buildRecords()
+	return policy
+}
+
+// buildRecords returns the CAA records for the issuers, wildcard flag and iodef address.
+func (p CAAPolicy) buildRecords() []DNSRecord {
+	name := p.Name
+	if name == "" {
+		name = "@"
+	}
+
+	var records []DNSRecord
+	add := func(tag, value string) {
+		records = append(records, DNSRecord{
+			Name:    name,
+			Type:    RecordTypeCAA,
+			Content: CAAContent(0, tag, value),
+		})
+	}
+
+	var cleaned []string
+	seen := make(map[string]bool)
+	for _, issuer := range p.Issuers {
+		if issuer = canonicalHost(issuer); issuer != "" && !seen[issuer] {
+			seen[issuer] = true
+			cleaned = append(cleaned, issuer)
+		}
+	}
+
+	if len(cleaned) == 0 {
+		add("issue", ";")
+	}
+	for _, issuer := range cleaned {
+		add("issue", issuer)
+	}
+
+	switch {
+	case p.Wildcard && len(cleaned) > 0:
+		for _, issuer := range cleaned {
+			add("issuewild", issuer)
+		}
+	default:
+		add("issuewild", ";")
+	}
+
+	if iodefMail := p.IodefMail; iodefMail != "" {
+		if !strings.Contains(iodefMail, ":") {
+			iodefMail = "mailto:" + iodefMail
+		}
+		add("iodef", iodefMail)
+	}
+
+	return records
+}
+
+// SetCAAPolicy replaces the CAA records of the policy name with the policy,
+// generating the records from the issuers, wildcard flag and iodef address
+// unless the policy has explicit Records. The replacement is applied like
+// EnsureCAAPolicy:
+//
+//	client.SetCAAPolicy(ctx, "example.com", regru.CAAPolicy{
+//		Issuers:  []string{regru.CAAIssuerLetsEncrypt, regru.CAAIssuerSectigo},
+//		Wildcard: true,
+//	})
+func (c *Client) SetCAAPolicy(ctx context.Context, zone string, policy CAAPolicy) (BatchResults, error) {
+	if len(policy.Records) == 0 {
+		policy.Records = policy.buildRecords()
+	}
+	return c.EnsureCAAPolicy(ctx, zone, policy)
+}
+
+// EnsureCAAPolicy reconciles the CAA records of the policy name with the policy:
+// missing records are created and CAA records not in the policy are deleted in
+// a single zone/update_records request, creates first. Invalid records fail
+// the whole request before anything is sent, but the API applies each action
+// on its own, so check the results: a rejected create does not stop the
+// deletes. Records of other types are not touched.
+func (c *Client) EnsureCAAPolicy(ctx context.Context, zone string, policy CAAPolicy) (BatchResults, error) {
+	name := policy.Name
+	if name == "" {
+		name = "@"
+	}
+
+	current, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone, Type: RecordTypeCAA})
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[recordKey]bool)
+	var existing []DNSRecord
+	for _, rr := range current {
+		if canonicalName(rr.Name) == canonicalName(name) {
+			present[keyOf(rr)] = true
+			existing = append(existing, rr)
+		}
+	}
+
+	var actions []RecordAction
+	wanted := make(map[recordKey]bool, len(policy.Records))
+	for _, rr := range policy.Records {
+		rr.Name = name
+		rr.Type = RecordTypeCAA
+		key := keyOf(rr)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		if !present[key] {
+			actions = append(actions, RecordAction{Operation: BatchOperationCreate, Record: rr})
+		}
+	}
+
+	for _, rr := range existing {
+		if !wanted[keyOf(rr)] {
+			actions = append(actions, RecordAction{Operation: BatchOperationDelete, Record: rr})
+		}
+	}
+
+	results, err := c.BatchUpdate(ctx, zone, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, results.Err()
+}
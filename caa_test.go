@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// caaContents returns the content of the policy records.
+func caaContents(policy CAAPolicy) []string {
+	var contents []string
+	for _, rr := range policy.Records {
+		contents = append(contents, rr.Content)
+	}
+	return contents
+}
+
+func TestBuildCAAPolicy(t *testing.T) {
+	policy := BuildCAAPolicy([]string{"letsencrypt.org", "Sectigo.com."}, false, "security@example.com")
+	assert.Equal(t, "@", policy.Name)
+	assert.Equal(t, []string{
+		`0 issue "letsencrypt.org"`,
+		`0 issue "sectigo.com"`,
+		`0 issuewild ";"`,
+		`0 iodef "mailto:security@example.com"`,
+	}, caaContents(policy))
+
+	policy = BuildCAAPolicy([]string{"letsencrypt.org"}, true, "")
+	assert.Equal(t, []string{
+		`0 issue "letsencrypt.org"`,
+		`0 issuewild "letsencrypt.org"`,
+	}, caaContents(policy))
+
+	policy = BuildCAAPolicy(nil, true, "")
+	assert.Equal(t, []string{`0 issue ";"`, `0 issuewild ";"`}, caaContents(policy))
+
+	policy = BuildCAAPolicy([]string{CAAIssuerZeroSSL, CAAIssuerSectigo}, true, "")
+	assert.Equal(t, []string{`0 issue "sectigo.com"`, `0 issuewild "sectigo.com"`}, caaContents(policy))
+}
+
+func TestCanonicalize_CAA(t *testing.T) {
+	a := DNSRecord{Name: "@", Type: RecordTypeCAA, Content: `0 ISSUE "letsencrypt.org"`}
+	b := DNSRecord{Name: "@", Type: "caa", Content: "0 issue letsencrypt.org"}
+	assert.True(t, a.Equal(b))
+}
+
+func TestClient_AddRR_CAA(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{
+		Name: "@", Type: RecordTypeCAA, Content: `128 issue "letsencrypt.org"`,
+	})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/add_caa", (*calls)[0].Path)
+	assert.Equal(t, float64(128), (*calls)[0].Input["flags"])
+	assert.Equal(t, "issue", (*calls)[0].Input["tag"])
+	assert.Equal(t, "letsencrypt.org", (*calls)[0].Input["value"])
+
+	_, err = client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{
+		Name: "@", Type: RecordTypeCAA, Content: "letsencrypt.org",
+	})
+	assert.ErrorContains(t, err, "invalid CAA content")
+}
+
+func TestClient_DeleteRR_CAA(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	err := client.DeleteRR(context.Background(), "example.com", DNSRecord{
+		Name: "@", Type: RecordTypeCAA, Content: CAAContent(0, "iodef", "mailto:security@example.com"),
+	})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/remove_record", (*calls)[0].Path)
+	assert.Equal(t, "CAA", (*calls)[0].Input["record_type"])
+	assert.Equal(t, `0 iodef "mailto:security@example.com"`, (*calls)[0].Input["content"])
+}
+
+func TestClient_EnsureCAAPolicy(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "CAA", Content: `0 issue "letsencrypt.org"`},
+		ResourceRecord{Subname: "@", Rectype: "CAA", Content: `0 issue "comodoca.com"`},
+		ResourceRecord{Subname: "www", Rectype: "CAA", Content: `0 issue "digicert.com"`},
+	)
+	success := ZoneUpdateRecordsActionResult{Result: "success"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+		"zone/update_records":       updateRecordsResponse(success, success),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.EnsureCAAPolicy(context.Background(), "example.com",
+		BuildCAAPolicy([]string{"letsencrypt.org"}, false, ""))
+	require.NoError(t, err)
+
+	var summary []string
+	for _, result := range results {
+		summary = append(summary, string(result.Operation)+" "+result.Item.Name+" "+result.Item.Content)
+	}
+	assert.Equal(t, []string{
+		`create @ 0 issuewild ";"`,
+		`delete @ 0 issue "comodoca.com"`,
+	}, summary)
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/update_records", (*calls)[1].Path)
+}
+
+func TestClient_EnsureCAAPolicy_CreateFails(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "CAA", Content: `0 issue "comodoca.com"`},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+		"zone/update_records": updateRecordsResponse(
+			ZoneUpdateRecordsActionResult{Result: "error", ErrorCode: "INVALID_CAA", ErrorText: "invalid"},
+			ZoneUpdateRecordsActionResult{Result: "error", ErrorCode: "INVALID_CAA", ErrorText: "invalid"},
+			ZoneUpdateRecordsActionResult{Result: "success"},
+		),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	// Invalid records are rejected before the zone is changed
+	_, err := client.EnsureCAAPolicy(context.Background(), "example.com", CAAPolicy{
+		Records: []DNSRecord{{Content: "letsencrypt.org"}},
+	})
+	assert.ErrorContains(t, err, "invalid CAA content")
+	require.Len(t, *calls, 1)
+
+	// Records rejected by the API are reported in the results
+	results, err := client.EnsureCAAPolicy(context.Background(), "example.com",
+		BuildCAAPolicy([]string{"letsencrypt.org"}, false, ""))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "INVALID_CAA", apiErr.Code)
+	require.Len(t, results, 3)
+	assert.Len(t, results.Failed(), 2)
+}
+
+func TestClient_SetCAAPolicy(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "www", Rectype: "CAA", Content: `0 issue "letsencrypt.org"`},
+		ResourceRecord{Subname: "www", Rectype: "CAA", Content: `0 issuewild ";"`},
+		ResourceRecord{Subname: "www", Rectype: "CAA", Content: `0 issue "digicert.com"`},
+	)
+	success := ZoneUpdateRecordsActionResult{Result: "success"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+		"zone/update_records":       updateRecordsResponse(success, success, success, success, success, success),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.SetCAAPolicy(context.Background(), "example.com", CAAPolicy{
+		Name:      "www",
+		Issuers:   []string{CAAIssuerLetsEncrypt, CAAIssuerZeroSSL},
+		Wildcard:  true,
+		IodefMail: "security@example.com",
+	})
+	require.NoError(t, err)
+
+	var summary []string
+	for _, result := range results {
+		summary = append(summary, string(result.Operation)+" "+result.Item.Name+" "+result.Item.Content)
+	}
+	assert.Equal(t, []string{
+		`create www 0 issue "sectigo.com"`,
+		`create www 0 issuewild "letsencrypt.org"`,
+		`create www 0 issuewild "sectigo.com"`,
+		`create www 0 iodef "mailto:security@example.com"`,
+		`delete www 0 issuewild ";"`,
+		`delete www 0 issue "digicert.com"`,
+	}, summary)
+	require.Len(t, *calls, 2)
+	actions := (*calls)[1].Input["domains"].([]interface{})[0].(map[string]interface{})["action_list"].([]interface{})
+	assert.Len(t, actions, 6)
+}
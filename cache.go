@@ -0,0 +1,148 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"sync"
+	"time"
+)
+
+// WithRecordsCache caches the records returned by zone/get_resource_records
+// per zone for ttl, so repeated ListRecords and GetRRByName calls do not
+// download the same zone again. Records added, updated or deleted through the
+// client invalidate the cache of their zone; changes made elsewhere become
+// visible once the entry expires. The cache is disabled by default.
+func WithRecordsCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.recordsCache = nil
+			return
+		}
+		c.recordsCache = &recordsCache{ttl: ttl, entries: make(map[string]recordsCacheEntry)}
+	}
+}
+
+// InvalidateRecordsCache drops the cached records of the zone, or of all
+// zones if zone is empty. It does nothing if the cache is disabled.
+func (c *Client) InvalidateRecordsCache(zone string) {
+	if c.recordsCache == nil {
+		return
+	}
+	if zone == "" {
+		c.recordsCache.clear()
+		return
+	}
+	c.recordsCache.invalidate(zone)
+}
+
+// recordsCache stores the unfiltered records of zones with an expiry time.
+type recordsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]recordsCacheEntry
+}
+
+type recordsCacheEntry struct {
+	records []DNSRecord
+	expires time.Time
+	// serial is the SOA serial of the zone when the records were fetched, if known.
+	serial    uint32
+	hasSerial bool
+}
+
+// get returns a copy of the cached records of the zone if they have not expired.
+func (rc *recordsCache) get(zone string, now time.Time) ([]DNSRecord, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	key := recordsCacheKey(zone)
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expires) {
+		// Expired entries with a serial are kept for revalidation
+		if !entry.hasSerial {
+			delete(rc.entries, key)
+		}
+		return nil, false
+	}
+	return append([]DNSRecord(nil), entry.records...), true
+}
+
+// put stores a copy of the records of the zone.
+func (rc *recordsCache) put(zone string, records []DNSRecord, now time.Time) {
+	rc.putSerial(zone, records, 0, false, now)
+}
+
+// putSerial stores a copy of the records of the zone with the SOA serial they were fetched at.
+func (rc *recordsCache) putSerial(zone string, records []DNSRecord, serial uint32, hasSerial bool, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[recordsCacheKey(zone)] = recordsCacheEntry{
+		records:   append([]DNSRecord(nil), records...),
+		expires:   now.Add(rc.ttl),
+		serial:    serial,
+		hasSerial: hasSerial,
+	}
+}
+
+// renew extends the expiry of the cached records of the zone whose serial is
+// still current and returns a copy of them.
+func (rc *recordsCache) renew(zone string, serial uint32, now time.Time) ([]DNSRecord, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	key := recordsCacheKey(zone)
+	entry, ok := rc.entries[key]
+	if !ok || !entry.hasSerial || entry.serial != serial {
+		return nil, false
+	}
+	entry.expires = now.Add(rc.ttl)
+	rc.entries[key] = entry
+	return append([]DNSRecord(nil), entry.records...), true
+}
+
+// invalidate drops the cached records of the zone.
+func (rc *recordsCache) invalidate(zone string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, recordsCacheKey(zone))
+}
+
+// recordsCacheKey returns the cache key of the zone: its lowercase punycode
+// form, so the Unicode and ASCII names of a zone share one entry.
+func recordsCacheKey(zone string) string {
+	key := canonicalHost(zone)
+	if isASCII(key) {
+		return key
+	}
+	if ascii, err := idnaProfile.ToASCII(key); err == nil {
+		return ascii
+	}
+	return key
+}
+
+// clear drops all cached records.
+func (rc *recordsCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = make(map[string]recordsCacheEntry)
+}
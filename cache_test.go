@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countCalls returns the number of calls to the API method.
+func countCalls(calls []apiCall, path string) int {
+	n := 0
+	for _, call := range calls {
+		if call.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRecordsCache(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server, WithRecordsCache(time.Minute))
+	ctx := context.Background()
+
+	records, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+
+	rr, err := client.GetRRByName(ctx, "example.com", "www")
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", rr.Content)
+
+	filtered, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com", Type: RecordTypeAAAA})
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1)
+
+	assert.Equal(t, 1, countCalls(*calls, "zone/get_resource_records"))
+
+	// Changing the returned slice does not affect the cache
+	records[0].Content = "changed"
+	records, err = client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", records[0].Content)
+}
+
+func TestRecordsCache_WriteInvalidates(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server, WithRecordsCache(time.Minute))
+	ctx := context.Background()
+
+	_, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{Name: "api", Type: RecordTypeA, Content: "192.0.2.3"})
+	require.NoError(t, err)
+	_, err = client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, countCalls(*calls, "zone/get_resource_records"))
+
+	require.NoError(t, client.DeleteRR(ctx, "example.com", DNSRecord{Name: "api", Type: RecordTypeA, Content: "192.0.2.3"}))
+	_, err = client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, countCalls(*calls, "zone/get_resource_records"))
+
+	client.InvalidateRecordsCache("")
+	_, err = client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, countCalls(*calls, "zone/get_resource_records"))
+}
+
+func TestRecordsCache_IDNKey(t *testing.T) {
+	cache := &recordsCache{ttl: time.Minute, entries: make(map[string]recordsCacheEntry)}
+	now := time.Now()
+
+	cache.put("пример.рф", []DNSRecord{{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"}}, now)
+	records, ok := cache.get("XN--E1AFMKFD.XN--P1AI.", now)
+	require.True(t, ok)
+	assert.Len(t, records, 1)
+
+	cache.invalidate("xn--e1afmkfd.xn--p1ai")
+	_, ok = cache.get("Пример.рф", now)
+	assert.False(t, ok)
+}
+
+func TestRecordsCache_Expiry(t *testing.T) {
+	cache := &recordsCache{ttl: time.Minute, entries: make(map[string]recordsCacheEntry)}
+	now := time.Now()
+
+	cache.put("example.com", []DNSRecord{{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"}}, now)
+
+	records, ok := cache.get("example.com.", now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Len(t, records, 1)
+
+	_, ok = cache.get("example.com", now.Add(time.Minute))
+	assert.False(t, ok)
+}
+
+func TestRecordsCache_Disabled(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, countCalls(*calls, "zone/get_resource_records"))
+	client.InvalidateRecordsCache("example.com")
+}
@@ -0,0 +1,44 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Call performs the API method at path and decodes the response into T.
+// Credentials, deadlines, retries, the write policy and API error responses
+// are handled as for the built-in methods, so endpoints the client does not
+// wrap yet can be called with the generated request and response types or
+// with custom ones:
+//
+//	resp, err := regru.Call[regru.UserGetBalanceResponse](ctx, client, "user/get_balance", &regru.UserGetBalanceRequest{})
+func Call[T any](ctx context.Context, c *Client, path string, req APIRequest) (T, error) {
+	var resp T
+
+	body, err := c.apiRequest(ctx, path, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nopRequest and nopResponse model an endpoint without generated types.
+type nopRequest struct {
+	BaseRequest
+	Domain string `json:"dname"`
+}
+
+type nopResponse struct {
+	Answer struct {
+		User string `json:"user"`
+	} `json:"answer"`
+}
+
+func TestCall(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"user/nop": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"user": "test"},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	resp, err := Call[nopResponse](context.Background(), client, "user/nop", &nopRequest{Domain: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "test", resp.Answer.User)
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "example.com", (*calls)[0].Input["dname"])
+	assert.Equal(t, "test-username", (*calls)[0].Input["username"])
+}
+
+func TestCall_Errors(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"user/nop":         map[string]interface{}{"result": "error", "error_text": "denied"},
+		"user/get_balance": []string{"not", "an", "object"},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := Call[nopResponse](context.Background(), client, "user/nop", &UserNopRequest{})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "denied", apiErr.Message)
+
+	_, err = Call[nopResponse](context.Background(), client, "user/get_balance", &UserGetBalanceRequest{})
+	assert.ErrorContains(t, err, "failed to parse response")
+}
@@ -0,0 +1,291 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Canonicalize returns a copy of the record in canonical form so that records
+// describing the same data compare equal:
+//   - the type is upper-cased;
+//   - the name is lower-cased, stripped of a trailing dot, and "" becomes "@";
+//   - IP addresses are normalized ("2001:DB8::0001" -> "2001:db8::1");
+//   - hostname targets (CNAME, NS, PTR, MX, SRV) are lower-cased and stripped of a trailing dot;
+//   - MX content is normalized to "<priority> <host>", taking the priority from the Priority field when Content holds only the host;
+//   - TXT and SPF content is unquoted and multiple quoted strings are joined;
+//   - CAA content is normalized to "<flags> <tag> \"<value>\"" with a lower-case tag;
+//   - SSHFP content is normalized to "<algorithm> <type> <fingerprint>" with a lower-case fingerprint;
+//   - LOC content is normalized to the full RFC 1876 form, including default size and precision.
+func Canonicalize(rr DNSRecord) DNSRecord {
+	rr.Type = rr.Type.Canonical()
+	rr.Name = canonicalName(rr.Name)
+
+	switch rr.Type {
+	case RecordTypeA, RecordTypeAAAA:
+		rr.Content = canonicalIP(rr.Content)
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		rr.Content = canonicalHost(rr.Content)
+	case RecordTypeMX:
+		rr.Content = canonicalMX(rr.Content, rr.Priority)
+	case RecordTypeSRV:
+		rr.Content = canonicalSRV(rr.Content)
+	case RecordTypeTXT, RecordTypeSPF:
+		rr.Content = canonicalTXT(rr.Content)
+	case RecordTypeCAA:
+		rr.Content = canonicalCAA(rr.Content)
+	case RecordTypeSSHFP:
+		rr.Content = canonicalSSHFP(rr.Content)
+	case RecordTypeLOC:
+		rr.Content = canonicalLOC(rr.Content)
+	default:
+		rr.Content = strings.TrimSpace(rr.Content)
+	}
+
+	return rr
+}
+
+// Equal reports whether both records describe the same DNS data after
+// canonicalization. Only name, type and content are compared: TTL and ID are
+// not returned by every API method and would otherwise cause false differences.
+func (r *DNSRecord) Equal(other DNSRecord) bool {
+	a := Canonicalize(*r)
+	b := Canonicalize(other)
+	return a.Name == b.Name && a.Type == b.Type && a.Content == b.Content
+}
+
+// recordKey identifies a record by its canonical name, type and content.
+type recordKey struct {
+	name       string
+	recordType RecordType
+	content    string
+}
+
+// keyOf returns the canonical identity of the record.
+func keyOf(rr DNSRecord) recordKey {
+	c := Canonicalize(rr)
+	return recordKey{name: c.Name, recordType: c.Type, content: c.Content}
+}
+
+// dedupeRecords removes records that repeat an earlier record with the same
+// canonical name, type and content. If keep is true, repeats are retained but
+// marked as Duplicate instead.
+func dedupeRecords(records []DNSRecord, keep bool) []DNSRecord {
+	seen := make(map[recordKey]bool, len(records))
+	result := records[:0]
+	for _, record := range records {
+		key := keyOf(record)
+		if seen[key] {
+			if !keep {
+				continue
+			}
+			record.Duplicate = true
+		}
+		seen[key] = true
+		result = append(result, record)
+	}
+	return result
+}
+
+// canonicalName returns the canonical form of a subdomain name.
+func canonicalName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// canonicalHost returns the canonical form of a hostname target.
+func canonicalHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// canonicalIP returns the canonical textual form of an IP address,
+// or the trimmed input if it is not a valid address.
+func canonicalIP(value string) string {
+	value = strings.TrimSpace(value)
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.String()
+	}
+	return value
+}
+
+// splitMXContent splits MX content of the form "<priority> <host>".
+func splitMXContent(content string) (int, string, bool) {
+	fields := strings.Fields(content)
+	if len(fields) != 2 {
+		return 0, "", false
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return priority, fields[1], true
+}
+
+// canonicalMX returns the canonical form of MX content. Content holding only
+// the host is combined with the priority reported separately by the API.
+func canonicalMX(content string, priority int) string {
+	p, host, ok := splitMXContent(content)
+	switch {
+	case ok:
+		return strconv.Itoa(p) + " " + canonicalHost(host)
+	case len(strings.Fields(content)) == 1:
+		return strconv.Itoa(priority) + " " + canonicalHost(content)
+	default:
+		return canonicalHost(content)
+	}
+}
+
+// splitSRVContent splits SRV content "<priority> <weight> <port> <target>" into its fields.
+func splitSRVContent(content string) (int, int, int, string, bool) {
+	fields := strings.Fields(content)
+	if len(fields) != 4 {
+		return 0, 0, 0, "", false
+	}
+	var numbers [3]int
+	for i := range numbers {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n < 0 || n > 65535 {
+			return 0, 0, 0, "", false
+		}
+		numbers[i] = n
+	}
+	return numbers[0], numbers[1], numbers[2], fields[3], true
+}
+
+// canonicalSRV returns the canonical form of SRV content: whitespace is
+// collapsed and the trailing target field is treated as a hostname.
+func canonicalSRV(content string) string {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return ""
+	}
+	fields[len(fields)-1] = canonicalHost(fields[len(fields)-1])
+	return strings.Join(fields, " ")
+}
+
+// splitCAAContent splits CAA content into flags, tag and the unquoted value.
+func splitCAAContent(content string) (int, string, string, bool) {
+	fields := strings.Fields(content)
+	if len(fields) < 3 {
+		return 0, "", "", false
+	}
+	flags, err := strconv.Atoi(fields[0])
+	if err != nil || flags < 0 || flags > 255 {
+		return 0, "", "", false
+	}
+	value := strings.Join(fields[2:], " ")
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return flags, strings.ToLower(fields[1]), value, true
+}
+
+// canonicalCAA returns CAA content as "<flags> <tag> \"<value>\"".
+func canonicalCAA(content string) string {
+	flags, tag, value, ok := splitCAAContent(content)
+	if !ok {
+		return strings.TrimSpace(content)
+	}
+	return CAAContent(flags, tag, value)
+}
+
+// CAAContent returns the content of a CAA record with the flags, tag ("issue",
+// "issuewild" or "iodef") and value, in the presentation format expected by AddRR.
+func CAAContent(flags int, tag, value string) string {
+	return fmt.Sprintf("%d %s %s", flags, tag, strconv.Quote(value))
+}
+
+// splitSSHFPContent splits SSHFP content "<algorithm> <type> <fingerprint>" into its fields.
+func splitSSHFPContent(content string) (int, int, string, bool) {
+	fields := strings.Fields(content)
+	if len(fields) != 3 {
+		return 0, 0, "", false
+	}
+	algorithm, err := strconv.Atoi(fields[0])
+	if err != nil || algorithm < 0 || algorithm > 255 {
+		return 0, 0, "", false
+	}
+	fpType, err := strconv.Atoi(fields[1])
+	if err != nil || fpType < 0 || fpType > 255 {
+		return 0, 0, "", false
+	}
+	if _, err := hex.DecodeString(fields[2]); err != nil {
+		return 0, 0, "", false
+	}
+	return algorithm, fpType, strings.ToLower(fields[2]), true
+}
+
+// canonicalSSHFP returns SSHFP content as "<algorithm> <type> <fingerprint>".
+func canonicalSSHFP(content string) string {
+	algorithm, fpType, fingerprint, ok := splitSSHFPContent(content)
+	if !ok {
+		return strings.TrimSpace(content)
+	}
+	return SSHFPContent(algorithm, fpType, fingerprint)
+}
+
+// SSHFPContent returns the content of an SSHFP record with the key algorithm,
+// fingerprint type and hex-encoded fingerprint, in the format expected by AddRR.
+func SSHFPContent(algorithm, fpType int, fingerprint string) string {
+	return fmt.Sprintf("%d %d %s", algorithm, fpType, strings.ToLower(fingerprint))
+}
+
+// canonicalTXT returns TXT content without quoting. Content consisting of one or
+// more quoted strings ("part1" "part2") is unquoted and joined; anything else is
+// returned trimmed.
+func canonicalTXT(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var (
+		result  strings.Builder
+		inQuote bool
+		escaped bool
+	)
+	for _, c := range content {
+		switch {
+		case escaped:
+			result.WriteRune(c)
+			escaped = false
+		case c == '\\' && inQuote:
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			result.WriteRune(c)
+		case c == ' ' || c == '\t':
+			// Separator between quoted strings
+		default:
+			// Unquoted data outside of strings: not a quoted TXT value
+			return content
+		}
+	}
+	if inQuote || escaped {
+		return content
+	}
+
+	return result.String()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		record DNSRecord
+		want   DNSRecord
+	}{
+		{
+			name:   "type and name casing",
+			record: DNSRecord{Name: "WWW.", Type: "a", Content: "192.0.2.1"},
+			want:   DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"},
+		},
+		{
+			name:   "empty name becomes apex",
+			record: DNSRecord{Name: "", Type: RecordTypeA, Content: "192.0.2.1"},
+			want:   DNSRecord{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"},
+		},
+		{
+			name:   "IPv6 normalization",
+			record: DNSRecord{Name: "www", Type: RecordTypeAAAA, Content: "2001:DB8:0:0::0001"},
+			want:   DNSRecord{Name: "www", Type: RecordTypeAAAA, Content: "2001:db8::1"},
+		},
+		{
+			name:   "CNAME trailing dot",
+			record: DNSRecord{Name: "blog", Type: RecordTypeCNAME, Content: "Example.GitHub.io."},
+			want:   DNSRecord{Name: "blog", Type: RecordTypeCNAME, Content: "example.github.io"},
+		},
+		{
+			name:   "MX priority and host",
+			record: DNSRecord{Name: "@", Type: RecordTypeMX, Content: "10   Mail.Example.com."},
+			want:   DNSRecord{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"},
+		},
+		{
+			name:   "MX host with separate priority",
+			record: DNSRecord{Name: "@", Type: RecordTypeMX, Content: "Mail.Example.com.", Priority: 20},
+			want:   DNSRecord{Name: "@", Type: RecordTypeMX, Content: "20 mail.example.com", Priority: 20},
+		},
+		{
+			name:   "SRV target",
+			record: DNSRecord{Name: "_sip._tcp", Type: RecordTypeSRV, Content: "10 0  5060 SIP.example.com."},
+			want:   DNSRecord{Name: "_sip._tcp", Type: RecordTypeSRV, Content: "10 0 5060 sip.example.com"},
+		},
+		{
+			name:   "quoted TXT",
+			record: DNSRecord{Name: "@", Type: RecordTypeTXT, Content: `"v=spf1 -all"`},
+			want:   DNSRecord{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 -all"},
+		},
+		{
+			name:   "multi-string TXT",
+			record: DNSRecord{Name: "@", Type: RecordTypeTXT, Content: `"part one " "part \"two\""`},
+			want:   DNSRecord{Name: "@", Type: RecordTypeTXT, Content: `part one part "two"`},
+		},
+		{
+			name:   "unquoted TXT is preserved",
+			record: DNSRecord{Name: "@", Type: RecordTypeTXT, Content: `v=spf1 "odd" -all`},
+			want:   DNSRecord{Name: "@", Type: RecordTypeTXT, Content: `v=spf1 "odd" -all`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Canonicalize(tt.record))
+		})
+	}
+}
+
+func TestDNSRecord_Equal(t *testing.T) {
+	a := DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "example.github.io", TTL: 3600}
+	b := DNSRecord{Name: "WWW", Type: "cname", Content: "example.github.io.", ID: "123"}
+	c := DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "other.github.io"}
+
+	assert.True(t, a.Equal(b), "records differing only in casing, trailing dot, TTL and ID should be equal")
+	assert.False(t, a.Equal(c), "records with different content should not be equal")
+}
+
+func TestDedupeRecords(t *testing.T) {
+	records := func() []DNSRecord {
+		return []DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"},
+			{Name: "WWW", Type: RecordTypeA, Content: "192.0.2.1"},
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+		}
+	}
+
+	deduped := dedupeRecords(records(), false)
+	assert.Len(t, deduped, 2)
+	assert.Equal(t, "192.0.2.2", deduped[1].Content)
+
+	marked := dedupeRecords(records(), true)
+	assert.Len(t, marked, 3)
+	assert.False(t, marked[0].Duplicate)
+	assert.True(t, marked[1].Duplicate)
+	assert.False(t, marked[2].Duplicate)
+}
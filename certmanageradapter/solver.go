@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certmanageradapter adapts the acme package to the challenge requests
+// of a cert-manager DNS-01 webhook for reg.ru zones.
+//
+// It is not a cert-manager webhook.Solver: the package does not import
+// cert-manager, which would pull the Kubernetes client libraries into every
+// user of this module. ChallengeRequest mirrors the fields of cert-manager's
+// v1alpha1.ChallengeRequest used by the adapter, and the webhook binary
+// implements webhook.Solver (including Initialize) by forwarding its calls:
+//
+//	func (s *regruSolver) Present(ch *v1alpha1.ChallengeRequest) error {
+//		return s.solver.Present(certmanageradapter.NewChallengeRequest(ch.ResolvedFQDN, ch.Key))
+//	}
+package certmanageradapter
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixanemca/regru-go/acme"
+)
+
+const (
+	// DefaultName is the solver name referenced by the solverName of Issuer webhook configurations.
+	DefaultName = "regru"
+	// DefaultTimeout bounds the API calls of a single Present or CleanUp.
+	DefaultTimeout = 60 * time.Second
+)
+
+// ChallengeRequest holds the fields of a cert-manager challenge request used by
+// the solver. The zone is looked up in the account, so ResolvedZone is not needed.
+type ChallengeRequest struct {
+	// ResolvedFQDN is the fully qualified name of the challenge record,
+	// for example "_acme-challenge.example.com.".
+	ResolvedFQDN string `json:"resolvedFQDN"`
+	// Key is the value of the challenge TXT record.
+	Key string `json:"key"`
+}
+
+// NewChallengeRequest returns a challenge request with the fields of a cert-manager request.
+func NewChallengeRequest(resolvedFQDN, key string) *ChallengeRequest {
+	return &ChallengeRequest{ResolvedFQDN: resolvedFQDN, Key: key}
+}
+
+// Solver presents and cleans up challenge records for cert-manager challenge
+// requests. A webhook.Solver implementation forwards its Name, Present and
+// CleanUp calls to it.
+type Solver struct {
+	solver  *acme.Solver
+	name    string
+	ttl     int
+	timeout time.Duration
+}
+
+// Option represents an option for configuring the solver.
+type Option func(*Solver)
+
+// WithName sets the solver name (DefaultName by default).
+func WithName(name string) Option {
+	return func(s *Solver) {
+		s.name = name
+	}
+}
+
+// WithTTL sets the TTL in seconds of challenge records (acme.DefaultTTL by default).
+func WithTTL(ttl int) Option {
+	return func(s *Solver) {
+		s.ttl = ttl
+	}
+}
+
+// WithTimeout sets the maximum duration of the API calls of a single Present or CleanUp.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Solver) {
+		s.timeout = timeout
+	}
+}
+
+// NewSolver creates a new challenge adapter backed by the client.
+func NewSolver(client acme.Client, opts ...Option) *Solver {
+	s := &Solver{
+		name:    DefaultName,
+		ttl:     acme.DefaultTTL,
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.solver = acme.NewSolver(client, acme.WithTTL(s.ttl))
+
+	return s
+}
+
+// Name returns the solver name.
+func (s *Solver) Name() string {
+	return s.name
+}
+
+// Present publishes the challenge record. cert-manager calls it again while
+// the challenge is pending; records already published by the solver are kept.
+func (s *Solver) Present(ch *ChallengeRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.solver.PresentValue(ctx, ch.ResolvedFQDN, ch.Key)
+}
+
+// CleanUp removes the challenge record with the request key, leaving the
+// records of other challenges for the same name in place.
+func (s *Solver) CleanUp(ch *ChallengeRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.solver.CleanUpValue(ctx, ch.ResolvedFQDN, ch.Key)
+}
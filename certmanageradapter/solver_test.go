@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanageradapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps the records of its zones in memory.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient(zones ...string) *fakeClient {
+	f := &fakeClient{records: make(map[string][]regru.DNSRecord)}
+	for _, zone := range zones {
+		f.zones = append(f.zones, regru.Zone{Name: zone})
+	}
+	return f
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	record := regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}
+	f.records[zone] = append(f.records[zone], record)
+	return record, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func TestSolver_Name(t *testing.T) {
+	assert.Equal(t, DefaultName, NewSolver(newFakeClient()).Name())
+	assert.Equal(t, "custom", NewSolver(newFakeClient(), WithName("custom")).Name())
+}
+
+func TestSolver_PresentAndCleanUp(t *testing.T) {
+	client := newFakeClient("example.com")
+	solver := NewSolver(client, WithTTL(120))
+
+	apex := NewChallengeRequest("_acme-challenge.example.com.", "apex-key")
+	wildcard := NewChallengeRequest("_acme-challenge.example.com.", "wildcard-key")
+
+	require.NoError(t, solver.Present(apex))
+	require.NoError(t, solver.Present(wildcard))
+	// Repeated calls while the challenge is pending publish nothing new
+	require.NoError(t, solver.Present(apex))
+
+	records := client.records["example.com"]
+	require.Len(t, records, 2)
+	assert.Equal(t, regru.DNSRecord{Name: "_acme-challenge", Type: regru.RecordTypeTXT, Content: "apex-key", TTL: 120}, records[0])
+
+	require.NoError(t, solver.CleanUp(apex))
+	assert.Equal(t, records[:1], client.deleted)
+
+	require.NoError(t, solver.CleanUp(wildcard))
+	assert.Equal(t, records, client.deleted)
+}
+
+func TestSolver_UnknownZone(t *testing.T) {
+	solver := NewSolver(newFakeClient("example.com"))
+	assert.Error(t, solver.Present(NewChallengeRequest("_acme-challenge.example.org.", "key")))
+}
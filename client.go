@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -36,10 +38,52 @@ const (
 
 // Client represents a client for working with reg.ru API.
 type Client struct {
-	username   string
-	password   string
-	baseURL    string
-	httpClient *http.Client
+	credentials *credentialStore
+	baseURL     string
+	httpClient  *http.Client
+
+	readDeadline  time.Duration
+	writeDeadline time.Duration
+
+	maxRetries     int
+	maxElapsedTime time.Duration
+	retryBudget    *retryBudget
+	backoff        BackoffStrategy
+
+	stats *callStats
+
+	// semaphore limits concurrent requests if set
+	semaphore chan struct{}
+
+	profile string
+	logger  *slog.Logger
+	audit   func(ctx context.Context, entry AuditEntry)
+
+	writePolicy WritePolicy
+
+	recordsCache      *recordsCache
+	cacheRevalidation bool
+	serialNameservers []string
+	recordIDs         bool
+
+	noTXTChunking   bool
+	noIDNConversion bool
+	validate        bool
+
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+
+	// Zones groups zone methods.
+	Zones *ZonesService
+	// Records groups DNS record methods.
+	Records *RecordsService
+	// Domains groups domain registration methods.
+	Domains *DomainsService
+	// Billing groups bill and payment methods.
+	Billing *BillingService
+	// Account groups user account methods.
+	Account *AccountService
 }
 
 // ClientOption represents an option for configuring the client.
@@ -59,7 +103,9 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
-// WithTimeout sets the timeout for HTTP requests.
+// WithTimeout sets the timeout for HTTP requests, covering connection setup and
+// reading the whole response. Use WithDialTimeout, WithTLSHandshakeTimeout and
+// WithResponseHeaderTimeout to bound the individual phases instead.
 // If a custom HTTP client is set via WithHTTPClient, this option will update its timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
@@ -70,28 +116,139 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithDialTimeout limits the time to establish a TCP connection, independently
+// of the overall request timeout set by WithTimeout.
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.dialTimeout = timeout
+	}
+}
+
+// WithTLSHandshakeTimeout limits the time spent on the TLS handshake.
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tlsHandshakeTimeout = timeout
+	}
+}
+
+// WithResponseHeaderTimeout limits the time to wait for the response headers
+// after the request was sent. Reading a large response body is not limited
+// by it, so big zone exports are not cut off.
+func WithResponseHeaderTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.responseHeaderTimeout = timeout
+	}
+}
+
+// WithReadDeadline sets the deadline applied to read operations (such as
+// listing zones or records) when the caller's context has no deadline.
+// Zero disables the default deadline.
+func WithReadDeadline(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.readDeadline = d
+	}
+}
+
+// WithWriteDeadline sets the deadline applied to write operations (such as
+// adding or removing records) when the caller's context has no deadline.
+// Zero disables the default deadline.
+func WithWriteDeadline(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.writeDeadline = d
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of HTTP requests in flight
+// across all goroutines sharing the client. Requests over the limit wait for
+// a free slot or until their context is done.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.semaphore = make(chan struct{}, n)
+		}
+	}
+}
+
 // NewClient creates a new instance of reg.ru client.
 func NewClient(username, password string, opts ...ClientOption) *Client {
 	client := &Client{
-		username: username,
-		password: password,
-		baseURL:  DefaultBaseURL,
+		credentials: &credentialStore{username: username, password: password},
+		baseURL:     DefaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
+		stats: newCallStats(),
 	}
 
 	for _, opt := range opts {
 		opt(client)
 	}
+	client.applyTransportTimeouts()
+
+	client.Zones = &ZonesService{client: client}
+	client.Records = &RecordsService{client: client}
+	client.Domains = &DomainsService{client: client}
+	client.Billing = &BillingService{client: client}
+	client.Account = &AccountService{client: client}
 
 	return client
 }
 
+// applyTransportTimeouts sets the configured connection timeouts on copies of
+// the HTTP client and its transport. Custom transports that are not
+// *http.Transport are left unchanged.
+func (c *Client) applyTransportTimeouts() {
+	if c.dialTimeout == 0 && c.tlsHandshakeTimeout == 0 && c.responseHeaderTimeout == 0 {
+		return
+	}
+
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport = transport.Clone()
+
+	if c.dialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: c.dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if c.tlsHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = c.tlsHandshakeTimeout
+	}
+	if c.responseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = c.responseHeaderTimeout
+	}
+
+	// Copy the client too, so a client passed to WithHTTPClient is not changed
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
 // apiRequest performs a request to reg.ru API.
 func (c *Client) apiRequest(ctx context.Context, path string, apiReq APIRequest) ([]byte, error) {
+	// Apply the default deadline of the operation class if the caller set none
+	if _, ok := ctx.Deadline(); !ok {
+		deadline := c.writeDeadline
+		if isReadPath(path) {
+			deadline = c.readDeadline
+		}
+		if deadline > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadline)
+			defer cancel()
+		}
+	}
+
 	// Set credentials in the request
-	apiReq.SetCredentials(c.username, c.password)
+	username, password, err := c.credentials.get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	apiReq.SetCredentials(username, password)
 
 	// Build URL
 	apiURL := fmt.Sprintf("%s/%s", c.baseURL, path)
@@ -102,15 +259,43 @@ func (c *Client) apiRequest(ctx context.Context, path string, apiReq APIRequest)
 		return nil, fmt.Errorf("failed to marshal request params: %w", err)
 	}
 
+	// Let the write policy veto mutations before anything is sent
+	if err := c.checkWritePolicy(ctx, path, jsonData); err != nil {
+		return nil, err
+	}
+
 	// Create form data with required parameters
 	formData := url.Values{}
 	formData.Set("input_format", "json")
 	formData.Set("input_data", string(jsonData))
-	formData.Set("username", c.username)
-	formData.Set("password", c.password)
+	formData.Set("username", username)
+	formData.Set("password", password)
+
+	payload := formData.Encode()
+	start := time.Now()
+	body, err := c.withRetry(ctx, isReadPath(path), func() ([]byte, error) {
+		if c.semaphore != nil {
+			select {
+			case c.semaphore <- struct{}{}:
+				defer func() { <-c.semaphore }()
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		body, err := c.doRequest(ctx, apiURL, payload)
+		c.stats.record(path, err, time.Now())
+		return body, err
+	})
+
+	c.observe(ctx, path, jsonData, start, err)
+	return body, err
+}
 
+// doRequest performs a single HTTP request with the encoded form payload.
+func (c *Client) doRequest(ctx context.Context, apiURL, payload string) ([]byte, error) {
 	// Create HTTP request with form data
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(formData.Encode()))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -141,16 +326,49 @@ func (c *Client) apiRequest(ctx context.Context, path string, apiReq APIRequest)
 	// Check for errors in response
 	var apiResp APIResponse
 	if err := json.Unmarshal(body, &apiResp); err == nil {
-		if apiResp.ErrorText != "" {
-			return nil, &APIError{Message: apiResp.ErrorText}
+		if apiResp.ErrorText != "" || apiResp.ErrorCode != "" {
+			return nil, newAPIError(apiResp.ErrorCode, apiResp.ErrorText)
 		}
 	}
 
 	return body, nil
 }
 
+// ipRestrictionCodes are the API error codes reporting a call from an IP address
+// outside the API access allowlist.
+var ipRestrictionCodes = map[string]bool{
+	"ACCESS_DENIED_FROM_IP": true,
+}
+
+// newAPIError returns the error for an API error response.
+func newAPIError(code, message string) error {
+	if message == "" {
+		message = code
+	}
+	apiErr := &APIError{Code: code, Message: message}
+	if ipRestrictionCodes[code] {
+		return &IPRestrictedError{Err: apiErr}
+	}
+	return apiErr
+}
+
+// readMethodPrefixes are the prefixes of API methods that only read data,
+// such as get_resource_records, check or is_delegated.
+var readMethodPrefixes = []string{"get", "check", "is_", "nop"}
+
+// isReadPath reports whether the API method at path only reads data.
+func isReadPath(path string) bool {
+	method := path[strings.LastIndex(path, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // getAddRecordPath returns the API path for adding a record of the specified type.
-func getAddRecordPath(recordType string) (string, error) {
+func getAddRecordPath(recordType RecordType) (string, error) {
 	switch recordType {
 	case RecordTypeA:
 		return "zone/add_alias", nil
@@ -166,24 +384,36 @@ func getAddRecordPath(recordType string) (string, error) {
 		return "zone/add_srv", nil
 	case RecordTypeTXT:
 		return "zone/add_txt", nil
+	case RecordTypeCAA:
+		return "zone/add_caa", nil
+	case RecordTypePTR:
+		return "zone/add_ptr", nil
+	case RecordTypeSSHFP:
+		return "zone/add_sshfp", nil
+	case RecordTypeSPF:
+		return "zone/add_spf", nil
+	case RecordTypeLOC:
+		return "zone/add_loc", nil
 	default:
-		return "", &UnsupportedRecordTypeError{RecordType: recordType}
+		return "", &UnsupportedRecordTypeError{RecordType: string(recordType)}
 	}
 }
 
 // getRemoveRecordPath returns the API path for removing a record of the specified type.
 // According to reg.ru API documentation, all record types use the same endpoint: zone/remove_record
-func getRemoveRecordPath(recordType string) (string, error) {
+func getRemoveRecordPath(recordType RecordType) (string, error) {
 	switch recordType {
-	case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeNS, RecordTypeSRV, RecordTypeTXT:
+	case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeNS, RecordTypeSRV, RecordTypeTXT, RecordTypeCAA, RecordTypePTR, RecordTypeSSHFP, RecordTypeSPF, RecordTypeLOC:
 		return "zone/remove_record", nil
 	default:
-		return "", &UnsupportedRecordTypeError{RecordType: recordType}
+		return "", &UnsupportedRecordTypeError{RecordType: string(recordType)}
 	}
 }
 
 // createAddRecordRequest creates an appropriate request structure based on record type.
 func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIRequest, error) {
+	ttl := effectiveTTL(params.TTL, params.TTLDuration)
+
 	switch params.Type {
 	case RecordTypeA:
 		// For A records (add_alias), ipaddr and subdomain are at request level
@@ -195,8 +425,8 @@ func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIReque
 			Subdomain: params.Name,
 			IPAddr:    params.Content,
 		}
-		if params.TTL > 0 {
-			aliasReq.TTL = params.TTL
+		if ttl > 0 {
+			aliasReq.TTL = ttl
 		}
 		return aliasReq, nil
 	case RecordTypeAAAA:
@@ -209,8 +439,8 @@ func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIReque
 			Subdomain: params.Name,
 			IPAddr:    params.Content,
 		}
-		if params.TTL > 0 {
-			aaaaReq.TTL = params.TTL
+		if ttl > 0 {
+			aaaaReq.TTL = ttl
 		}
 		return aaaaReq, nil
 	case RecordTypeCNAME:
@@ -223,22 +453,24 @@ func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIReque
 			Subdomain:     params.Name,
 			CanonicalName: params.Content,
 		}
-		if params.TTL > 0 {
-			cnameReq.TTL = params.TTL
+		if ttl > 0 {
+			cnameReq.TTL = ttl
 		}
 		return cnameReq, nil
 	case RecordTypeMX:
-		// For MX records (add_mx), mail_server and subdomain are at request level
+		// For MX records (add_mx), mail_server, priority and subdomain are at request level
+		mailServer, priority := mxParams(params)
 		mxReq := &AddMXRequest{
 			BaseRequest: BaseRequest{},
 			Domains: []AddAliasDomain{
 				{DName: zone},
 			},
 			Subdomain:  params.Name,
-			MailServer: params.Content,
+			MailServer: mailServer,
+			Priority:   priority,
 		}
-		if params.TTL > 0 {
-			mxReq.TTL = params.TTL
+		if ttl > 0 {
+			mxReq.TTL = ttl
 		}
 		return mxReq, nil
 	case RecordTypeNS:
@@ -251,24 +483,26 @@ func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIReque
 			Subdomain: params.Name,
 			DNSServer: params.Content,
 		}
-		if params.TTL > 0 {
-			nsReq.TTL = params.TTL
+		if ttl > 0 {
+			nsReq.TTL = ttl
 		}
 		return nsReq, nil
 	case RecordTypeSRV:
-		// For SRV records (add_srv), service, priority, port, and target are at request level
+		// For SRV records (add_srv), service, priority, weight, port, and target are at request level
+		priority, weight, port, target := srvParams(params)
 		srvReq := &AddSRVRequest{
 			BaseRequest: BaseRequest{},
 			Domains: []AddAliasDomain{
 				{DName: zone},
 			},
 			Service:  params.Name,
-			Priority: fmt.Sprintf("%d", params.Priority),
-			Port:     fmt.Sprintf("%d", params.Port),
-			Target:   params.Content,
+			Priority: fmt.Sprintf("%d", priority),
+			Weight:   fmt.Sprintf("%d", weight),
+			Port:     fmt.Sprintf("%d", port),
+			Target:   target,
 		}
-		if params.TTL > 0 {
-			srvReq.TTL = params.TTL
+		if ttl > 0 {
+			srvReq.TTL = ttl
 		}
 		return srvReq, nil
 	case RecordTypeTXT:
@@ -281,13 +515,125 @@ func createAddRecordRequest(zone string, params CreateDNSRecordParams) (APIReque
 			Subdomain: params.Name,
 			Text:      params.Content,
 		}
-		if params.TTL > 0 {
-			txtReq.TTL = params.TTL
+		if ttl > 0 {
+			txtReq.TTL = ttl
 		}
 		return txtReq, nil
+	case RecordTypeCAA:
+		// For CAA records (add_caa), content "<flags> <tag> <value>" is split into request-level fields
+		flags, tag, value, ok := splitCAAContent(params.Content)
+		if !ok {
+			return nil, fmt.Errorf("invalid CAA content %q: expected \"<flags> <tag> <value>\"", params.Content)
+		}
+		caaReq := &AddCAARequest{
+			BaseRequest: BaseRequest{},
+			Domains: []AddAliasDomain{
+				{DName: zone},
+			},
+			Subdomain: params.Name,
+			Flags:     flags,
+			Tag:       tag,
+			Value:     value,
+		}
+		if ttl > 0 {
+			caaReq.TTL = ttl
+		}
+		return caaReq, nil
+	case RecordTypePTR:
+		// For PTR records (add_ptr), domain_name and subdomain are at request level
+		ptrReq := &AddPTRRequest{
+			BaseRequest: BaseRequest{},
+			Domains: []AddAliasDomain{
+				{DName: zone},
+			},
+			Subdomain:  params.Name,
+			DomainName: params.Content,
+		}
+		if ttl > 0 {
+			ptrReq.TTL = ttl
+		}
+		return ptrReq, nil
+	case RecordTypeSSHFP:
+		// For SSHFP records (add_sshfp), content "<algorithm> <type> <fingerprint>" is split into request-level fields
+		algorithm, fpType, fingerprint, ok := splitSSHFPContent(params.Content)
+		if !ok {
+			return nil, fmt.Errorf("invalid SSHFP content %q: expected \"<algorithm> <type> <fingerprint>\"", params.Content)
+		}
+		sshfpReq := &AddSSHFPRequest{
+			BaseRequest: BaseRequest{},
+			Domains: []AddAliasDomain{
+				{DName: zone},
+			},
+			Subdomain:       params.Name,
+			Algorithm:       algorithm,
+			FingerprintType: fpType,
+			Fingerprint:     fingerprint,
+		}
+		if ttl > 0 {
+			sshfpReq.TTL = ttl
+		}
+		return sshfpReq, nil
+	case RecordTypeSPF:
+		// For SPF records (add_spf), text and subdomain are at request level, as for TXT
+		spfReq := &AddSPFRequest{
+			BaseRequest: BaseRequest{},
+			Domains: []AddAliasDomain{
+				{DName: zone},
+			},
+			Subdomain: params.Name,
+			Text:      params.Content,
+		}
+		if ttl > 0 {
+			spfReq.TTL = ttl
+		}
+		return spfReq, nil
+	case RecordTypeLOC:
+		// For LOC records (add_loc), the position is sent as request-level fields
+		latitude, longitude, altitude, err := locParams(params)
+		if err != nil {
+			return nil, err
+		}
+		locReq := &AddLOCRequest{
+			BaseRequest: BaseRequest{},
+			Domains: []AddAliasDomain{
+				{DName: zone},
+			},
+			Subdomain: params.Name,
+			Latitude:  latitude,
+			Longitude: longitude,
+			Altitude:  altitude,
+		}
+		if ttl > 0 {
+			locReq.TTL = ttl
+		}
+		return locReq, nil
 	default:
-		return nil, &UnsupportedRecordTypeError{RecordType: params.Type}
+		return nil, &UnsupportedRecordTypeError{RecordType: string(params.Type)}
+	}
+}
+
+// mxParams returns the mail server and priority of an MX record to add. Content
+// in the "<priority> <host>" form is split; a non-zero Priority field overrides
+// the priority found in Content.
+func mxParams(params CreateDNSRecordParams) (string, int) {
+	mailServer, priority := params.Content, params.Priority
+	if p, host, ok := splitMXContent(params.Content); ok {
+		mailServer = host
+		if priority == 0 {
+			priority = p
+		}
 	}
+	return mailServer, priority
+}
+
+// srvParams returns the priority, weight, port and target of an SRV record to
+// add: Content in the "<priority> <weight> <port> <target>" form is split,
+// otherwise Content is the target and the Priority and Port fields are used.
+func srvParams(params CreateDNSRecordParams) (int, int, int, string) {
+	if priority, weight, port, target, ok := splitSRVContent(params.Content); ok {
+		return priority, weight, port, target
+	}
+	return params.Priority, 0, params.Port, params.Content
 }
 
 // createRemoveRecordRequest creates an appropriate request structure based on record type.
@@ -301,7 +647,10 @@ func createRemoveRecordRequest(zone string, rr DNSRecord) (APIRequest, error) {
 		},
 		Subdomain:  rr.Name,
 		Content:    rr.Content,
-		RecordType: rr.Type,
+		RecordType: string(rr.Type),
+	}
+	if rr.Type == RecordTypeMX {
+		req.Priority = rr.Priority
 	}
 
 	// All remove requests use the same structure, but we return typed requests for consistency
@@ -320,248 +669,183 @@ func createRemoveRecordRequest(zone string, rr DNSRecord) (APIRequest, error) {
 		return &RemoveSRVRequest{RemoveRecordRequest: *req}, nil
 	case RecordTypeTXT:
 		return &RemoveTXTRequest{RemoveRecordRequest: *req}, nil
+	case RecordTypeCAA:
+		return &RemoveCAARequest{RemoveRecordRequest: *req}, nil
+	case RecordTypePTR:
+		return &RemovePTRRequest{RemoveRecordRequest: *req}, nil
+	case RecordTypeSSHFP:
+		return &RemoveSSHFPRequest{RemoveRecordRequest: *req}, nil
+	case RecordTypeSPF:
+		return &RemoveSPFRequest{RemoveRecordRequest: *req}, nil
+	case RecordTypeLOC:
+		return &RemoveLOCRequest{RemoveRecordRequest: *req}, nil
 	default:
-		return nil, &UnsupportedRecordTypeError{RecordType: rr.Type}
+		return nil, &UnsupportedRecordTypeError{RecordType: string(rr.Type)}
 	}
 }
 
-// AddRR creates a new DNS record for the specified zone.
-func (c *Client) AddRR(ctx context.Context, zone string, params CreateDNSRecordParams) (DNSRecord, error) {
-	// Get the appropriate API path for this record type
-	path, err := getAddRecordPath(params.Type)
-	if err != nil {
-		return DNSRecord{}, err
-	}
-
-	// Create the appropriate request structure
-	apiReq, err := createAddRecordRequest(zone, params)
-	if err != nil {
-		return DNSRecord{}, err
-	}
-
-	// Execute API request
-	body, err := c.apiRequest(ctx, path, apiReq)
-	if err != nil {
-		return DNSRecord{}, err
-	}
-
-	// Parse response
-	var resp AddNSResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return DNSRecord{}, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Convert response to DNSRecord
-	record := DNSRecord{
-		Name:    params.Name,
-		Type:    params.Type,
-		Content: params.Content,
-		TTL:     params.TTL,
-	}
-
-	// Extract record ID from response if available
-	if len(resp.Answer.Domains) > 0 {
-		domain := resp.Answer.Domains[0]
-		if domain.Result == "success" {
-			record.ID = domain.DNSID
-		}
+// checkRecordType returns the canonical form of the record type, or an error
+// if records of this type cannot be managed through the client.
+func checkRecordType(recordType RecordType) (RecordType, error) {
+	canonical := recordType.Canonical()
+	if !canonical.Supported() {
+		return "", &UnsupportedRecordTypeError{RecordType: string(recordType)}
 	}
+	return canonical, nil
+}
 
-	return record, nil
+// AddRR creates a new DNS record for the specified zone. It is equivalent to c.Records.Add.
+func (c *Client) AddRR(ctx context.Context, zone string, params CreateDNSRecordParams) (DNSRecord, error) {
+	return c.Records.Add(ctx, zone, params)
 }
 
-// DeleteRR deletes a DNS record from the specified zone.
+// DeleteRR deletes a DNS record from the specified zone. It is equivalent to c.Records.Delete.
 func (c *Client) DeleteRR(ctx context.Context, zone string, rr DNSRecord) error {
-	// Get the appropriate API path for this record type
-	path, err := getRemoveRecordPath(rr.Type)
-	if err != nil {
-		return err
-	}
-
-	// Create the appropriate request structure
-	apiReq, err := createRemoveRecordRequest(zone, rr)
-	if err != nil {
-		return err
-	}
-
-	// Execute API request
-	_, err = c.apiRequest(ctx, path, apiReq)
-	if err != nil {
-		return err
-	}
+	return c.Records.Delete(ctx, zone, rr)
+}
 
-	return nil
+// DeleteRRByID deletes the DNS record with the dns_id from the specified zone. It is equivalent to c.Records.DeleteByID.
+func (c *Client) DeleteRRByID(ctx context.Context, zone, dnsID string) error {
+	return c.Records.DeleteByID(ctx, zone, dnsID)
 }
 
-// GetRRByName returns a DNS record by name in the specified zone.
+// GetRRByName returns a DNS record by name in the specified zone. It is equivalent to c.Records.GetByName.
 func (c *Client) GetRRByName(ctx context.Context, zone, name string) (DNSRecord, error) {
-	// Get all zone records
-	params := ListDNSRecordsParams{
-		ZoneName: zone,
-	}
+	return c.Records.GetByName(ctx, zone, name)
+}
 
-	records, err := c.ListRecords(ctx, params)
-	if err != nil {
-		return DNSRecord{}, err
-	}
+// GetRRByID returns a DNS record by its dns_id in the specified zone. It is equivalent to c.Records.GetByID.
+func (c *Client) GetRRByID(ctx context.Context, zone, dnsID string) (DNSRecord, error) {
+	return c.Records.GetByID(ctx, zone, dnsID)
+}
 
-	// Search for record by name
-	for _, record := range records {
-		if record.Name == name {
-			return record, nil
-		}
-	}
+// ListZones returns a list of all zones in the account. It is equivalent to c.Zones.List.
+func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
+	return c.Zones.List(ctx)
+}
 
-	return DNSRecord{}, &RecordNotFoundError{RecordName: name}
+// ListZonesByName returns a list of zones by name. It is equivalent to c.Zones.ListByName.
+func (c *Client) ListZonesByName(ctx context.Context, name string) ([]Zone, error) {
+	return c.Zones.ListByName(ctx, name)
 }
 
-// ListZones returns a list of all zones in the account.
-func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
-	// Prepare API request
-	apiReq := ServiceListRequest{
-		BaseRequest: BaseRequest{},
-		PageSize:    1000, // Maximum number of zones per request
-	}
+// CreateZone enables reg.ru DNS hosting for the domain and returns the default
+// records created. It is equivalent to c.Zones.Create.
+func (c *Client) CreateZone(ctx context.Context, domain string) ([]DNSRecord, error) {
+	return c.Zones.Create(ctx, domain)
+}
 
-	body, err := c.apiRequest(ctx, "service/get_list", &apiReq)
-	if err != nil {
-		return nil, err
-	}
+// IsDelegated reports whether the domain is delegated to the reg.ru nameservers.
+// It is equivalent to c.Zones.IsDelegated.
+func (c *Client) IsDelegated(ctx context.Context, zone string) (bool, error) {
+	return c.Zones.IsDelegated(ctx, zone)
+}
 
-	// Parse response
-	var resp ServiceListResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// GetZoneSOA returns the zone TTL settings. It is equivalent to c.Zones.GetSOA.
+func (c *Client) GetZoneSOA(ctx context.Context, zone string) (SOAParams, error) {
+	return c.Zones.GetSOA(ctx, zone)
+}
 
-	var zones []Zone
-	for _, service := range resp.Answer.Services {
-		serviceType := service.GetServiceType()
-		if serviceType == "domain" {
-			zones = append(zones, Zone{
-				Name: service.GetDomain(),
-				ID:   service.GetServiceID(),
-			})
-		}
-	}
+// UpdateZoneSOA changes the zone TTL settings. It is equivalent to c.Zones.UpdateSOA.
+func (c *Client) UpdateZoneSOA(ctx context.Context, zone string, params SOAParams) error {
+	return c.Zones.UpdateSOA(ctx, zone, params)
+}
 
-	return zones, nil
+// SetForwarding points a name at the reg.ru web forwarding servers. It is
+// equivalent to c.Zones.SetForwarding.
+func (c *Client) SetForwarding(ctx context.Context, zone string, params ForwardingParams) error {
+	return c.Zones.SetForwarding(ctx, zone, params)
 }
 
-// ListZonesByName returns a list of zones by name.
-func (c *Client) ListZonesByName(ctx context.Context, name string) ([]Zone, error) {
-	zones, err := c.ListZones(ctx)
-	if err != nil {
-		return nil, err
-	}
+// RemoveForwarding removes the web forwarding records of the zone. It is
+// equivalent to c.Zones.RemoveForwarding.
+func (c *Client) RemoveForwarding(ctx context.Context, zone string) error {
+	return c.Zones.RemoveForwarding(ctx, zone)
+}
 
-	var filtered []Zone
-	for _, zone := range zones {
-		if zone.Name == name {
-			filtered = append(filtered, zone)
-		}
-	}
+// EnableParking parks the domain. It is equivalent to c.Zones.EnableParking.
+func (c *Client) EnableParking(ctx context.Context, zone string) error {
+	return c.Zones.EnableParking(ctx, zone)
+}
 
-	return filtered, nil
+// DisableParking unparks the domain. It is equivalent to c.Zones.DisableParking.
+func (c *Client) DisableParking(ctx context.Context, zone string) error {
+	return c.Zones.DisableParking(ctx, zone)
 }
 
-// ListRecords returns a list of DNS records for the specified zone.
+// ClearZone removes all resource records of the zone. It is equivalent to c.Zones.Clear.
+func (c *Client) ClearZone(ctx context.Context, zone string) error {
+	return c.Zones.Clear(ctx, zone)
+}
+
+// ListRecords returns a list of DNS records for the specified zone. It is equivalent to c.Records.List.
 func (c *Client) ListRecords(ctx context.Context, params ListDNSRecordsParams) ([]DNSRecord, error) {
-	zoneName := params.ZoneName
-	if zoneName == "" {
-		zoneName = params.ZoneID // Fallback to ZoneID if ZoneName is not set
-	}
+	return c.Records.List(ctx, params)
+}
 
-	// Prepare API request
-	apiReq := ZoneGetResourceRecordsRequest{
-		BaseRequest: BaseRequest{},
-		Domains: []ZoneGetResourceRecordsDomain{
-			{
-				DName: zoneName,
-			},
-		},
-	}
+// ListRecordsByZoneID returns a list of DNS records by zone identifier. It is equivalent to c.Records.ListByZoneID.
+func (c *Client) ListRecordsByZoneID(ctx context.Context, id string, params ListDNSRecordsParams) ([]DNSRecord, error) {
+	return c.Records.ListByZoneID(ctx, id, params)
+}
 
-	body, err := c.apiRequest(ctx, "zone/get_resource_records", &apiReq)
-	if err != nil {
-		return nil, err
-	}
+// UpdateRR updates an existing DNS record in the specified zone. It is equivalent to c.Records.Update.
+func (c *Client) UpdateRR(ctx context.Context, zone string, rr DNSRecord) (DNSRecord, error) {
+	return c.Records.Update(ctx, zone, rr)
+}
 
-	// Parse response
-	var resp ZoneGetResourceRecordsResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	var records []DNSRecord
-	for _, domain := range resp.Answer.Domains {
-		if domain.DName == zoneName {
-			for _, rr := range domain.RRList {
-				record := DNSRecord{
-					Name:    rr.Subname,
-					Type:    rr.Rectype,
-					Content: rr.Content,
-					// TTL and ID are not available in get_resource_records response
-					// TTL:     rr.TTL,
-					// ID:      rr.DNSID,
-				}
-
-				// Apply filters if specified
-				if params.Name != "" && record.Name != params.Name {
-					continue
-				}
-				if params.Type != "" && record.Type != params.Type {
-					continue
-				}
-
-				records = append(records, record)
-			}
-		}
-	}
+// ListDomains returns the domains registered in the account. It is equivalent to c.Domains.List.
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	return c.Domains.List(ctx)
+}
 
-	return records, nil
+// GetNameservers returns the registrar nameservers of the domain. It is equivalent to c.Domains.GetNameservers.
+func (c *Client) GetNameservers(ctx context.Context, domain string) ([]string, error) {
+	return c.Domains.GetNameservers(ctx, domain)
 }
 
-// ListRecordsByZoneID returns a list of DNS records by zone identifier.
-func (c *Client) ListRecordsByZoneID(ctx context.Context, id string, params ListDNSRecordsParams) ([]DNSRecord, error) {
-	// In reg.ru API, zone identifier usually matches zone name
-	// Get zone by ID and use its name
-	zones, err := c.ListZones(ctx)
-	if err != nil {
-		return nil, err
-	}
+// GetDomain returns the registration details of a domain. It is equivalent to c.Domains.Get.
+func (c *Client) GetDomain(ctx context.Context, domain string) (Domain, error) {
+	return c.Domains.Get(ctx, domain)
+}
 
-	var zoneName string
-	for _, zone := range zones {
-		if zone.ID == id {
-			zoneName = zone.Name
-			break
-		}
-	}
+// RegisterDomain orders the registration of a domain. It is equivalent to c.Domains.Register.
+func (c *Client) RegisterDomain(ctx context.Context, order DomainOrder) (Order, error) {
+	return c.Domains.Register(ctx, order)
+}
 
-	if zoneName == "" {
-		return nil, &ZoneNotFoundError{ZoneID: id}
-	}
+// RenewDomain orders the renewal of a domain. It is equivalent to c.Domains.Renew.
+func (c *Client) RenewDomain(ctx context.Context, domain string, period int) (Order, error) {
+	return c.Domains.Renew(ctx, domain, period)
+}
 
-	params.ZoneName = zoneName
-	return c.ListRecords(ctx, params)
+// CheckAccess verifies that the credentials are valid and API calls are allowed
+// from this IP address. It is equivalent to c.Account.CheckAccess.
+func (c *Client) CheckAccess(ctx context.Context) error {
+	return c.Account.CheckAccess(ctx)
 }
 
-// UpdateRR updates an existing DNS record in the specified zone.
-func (c *Client) UpdateRR(ctx context.Context, zone string, rr DNSRecord) (DNSRecord, error) {
-	// In reg.ru API, record update is usually performed through delete and create
-	// First, delete the old record
-	if err := c.DeleteRR(ctx, zone, rr); err != nil {
-		return DNSRecord{}, err
-	}
+// GetBalance returns the current balance of the account. It is equivalent to c.Account.GetBalance.
+func (c *Client) GetBalance(ctx context.Context) (Balance, error) {
+	return c.Account.GetBalance(ctx)
+}
 
-	// Create a new record with updated data
-	createParams := CreateDNSRecordParams{
-		Name:    rr.Name,
-		Type:    rr.Type,
-		Content: rr.Content,
-		TTL:     rr.TTL,
-	}
+// ListUnpaidBills returns the unpaid bills of the account. It is equivalent to c.Billing.ListUnpaidBills.
+func (c *Client) ListUnpaidBills(ctx context.Context) ([]Bill, error) {
+	return c.Billing.ListUnpaidBills(ctx)
+}
+
+// PayFromBalance pays a bill from the prepaid balance. It is equivalent to c.Billing.PayFromBalance.
+func (c *Client) PayFromBalance(ctx context.Context, billID string) (Bill, error) {
+	return c.Billing.PayFromBalance(ctx, billID)
+}
+
+// UpdateRRFrom replaces the record oldRR with newRR in the specified zone. It is equivalent to c.Records.UpdateFrom.
+func (c *Client) UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR DNSRecord) (DNSRecord, error) {
+	return c.Records.UpdateFrom(ctx, zone, oldRR, newRR)
+}
 
-	return c.AddRR(ctx, zone, createParams)
+// UpdateRRWithResult updates a DNS record and reports the previous and new record
+// state along with the API calls made. It is equivalent to c.Records.UpdateWithResult.
+func (c *Client) UpdateRRWithResult(ctx context.Context, zone string, rr DNSRecord) (UpdateResult, error) {
+	return c.Records.UpdateWithResult(ctx, zone, rr)
 }
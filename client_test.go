@@ -22,6 +22,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -45,15 +48,58 @@ func setupTestServer(t *testing.T, responseBody interface{}, statusCode int) *ht
 	return httptest.NewServer(handler)
 }
 
-// setupTestClient creates a test client with a test server.
-func setupTestClient(t *testing.T, server *httptest.Server) *Client {
+// apiCall records a request received by a routed test server.
+type apiCall struct {
+	Path  string
+	Input map[string]interface{}
+}
+
+// setupRoutedTestServer creates a test HTTP server that returns a response
+// depending on the API method path (e.g. "zone/add_alias") and records all calls.
+// Methods without a configured response get an empty successful answer.
+func setupRoutedTestServer(t *testing.T, responses map[string]interface{}) (*httptest.Server, *[]apiCall) {
 	t.Helper()
 
-	client := NewClient("test-username", "test-password",
-		WithBaseURL(server.URL),
-		WithTimeout(5*time.Second),
+	var (
+		mu    sync.Mutex
+		calls []apiCall
 	)
 
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		call := apiCall{Path: strings.TrimPrefix(r.URL.Path, "/")}
+		if input := r.PostForm.Get("input_data"); input != "" {
+			require.NoError(t, json.Unmarshal([]byte(input), &call.Input))
+		}
+
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		response, ok := responses[call.Path]
+		if !ok {
+			response = map[string]interface{}{"result": "success"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	})
+
+	return httptest.NewServer(handler), &calls
+}
+
+// setupTestClient creates a test client with a test server and extra options.
+func setupTestClient(t *testing.T, server *httptest.Server, opts ...ClientOption) *Client {
+	t.Helper()
+
+	opts = append([]ClientOption{
+		WithBaseURL(server.URL),
+		WithTimeout(5 * time.Second),
+	}, opts...)
+	client := NewClient("test-username", "test-password", opts...)
+
 	return client
 }
 
@@ -80,7 +126,7 @@ func TestNewClient_WithOptions(t *testing.T) {
 func TestClient_AddRR(t *testing.T) {
 	tests := []struct {
 		name           string
-		recordType     string
+		recordType     RecordType
 		content        string
 		response       AddNSResponse
 		expectedMethod string
@@ -240,6 +286,61 @@ func TestClient_AddRR_UnsupportedType(t *testing.T) {
 	assert.True(t, errors.As(err, &unsupportedErr), "error should be UnsupportedRecordTypeError")
 }
 
+func TestClient_AddRR_LowerCaseType(t *testing.T) {
+	response := AddNSResponse{
+		Answer: AddNSAnswer{
+			Domains: []DomainResult{
+				{
+					DName:  "example.com",
+					Result: "success",
+					DNSID:  "12345",
+				},
+			},
+		},
+	}
+
+	server := setupTestServer(t, response, http.StatusOK)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	params := CreateDNSRecordParams{
+		Name:    "www",
+		Type:    "a",
+		Content: "192.0.2.1",
+	}
+
+	record, err := client.AddRR(context.Background(), "example.com", params)
+	require.NoError(t, err)
+	assert.Equal(t, RecordTypeA, record.Type)
+}
+
+func TestClient_SPFRecords(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Type: "spf", Content: "v=spf1 mx -all", TTL: 3600,
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteRR(ctx, "example.com", DNSRecord{Name: "@", Type: RecordTypeSPF, Content: "v=spf1 mx -all"})
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/add_spf", (*calls)[0].Path)
+	assert.Equal(t, "@", (*calls)[0].Input["subdomain"])
+	assert.Equal(t, "v=spf1 mx -all", (*calls)[0].Input["text"])
+	assert.Equal(t, float64(3600), (*calls)[0].Input["ttl"])
+
+	assert.Equal(t, "zone/remove_record", (*calls)[1].Path)
+	assert.Equal(t, "SPF", (*calls)[1].Input["record_type"])
+	assert.Equal(t, "v=spf1 mx -all", (*calls)[1].Input["content"])
+}
+
 func TestClient_ListZones(t *testing.T) {
 	response := ServiceListResponse{
 		Answer: ServiceListAnswer{
@@ -275,6 +376,37 @@ func TestClient_ListZones(t *testing.T) {
 	assert.Equal(t, "12345", zones[0].ID)
 }
 
+func TestClient_ListZones_Status(t *testing.T) {
+	response := ServiceListResponse{
+		Answer: ServiceListAnswer{
+			Services: []Service{
+				{ServiceType: "domain", Domain: "active.com", State: "A", ExpirationDate: "2999-01-01"},
+				{ServiceType: "domain", Domain: "expired.com", State: "A", ExpirationDate: "2000-01-01"},
+				{ServiceType: "domain", Domain: "suspended.com", State: "S"},
+				{ServiceType: "domain", Domain: "new.com", State: "N"},
+				{ServiceType: "domain", Domain: "unknown.com"},
+			},
+		},
+	}
+
+	server := setupTestServer(t, response, http.StatusOK)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	zones, err := client.ListZones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 5)
+
+	var statuses []string
+	for _, zone := range zones {
+		statuses = append(statuses, zone.Status)
+	}
+	assert.Equal(t, []string{ZoneStatusActive, ZoneStatusExpired, ZoneStatusSuspended, ZoneStatusInactive, ""}, statuses)
+	assert.Equal(t, "A", zones[0].State)
+	assert.Equal(t, 2999, zones[0].ExpirationDate.Year())
+}
+
 func TestClient_ListZonesByName(t *testing.T) {
 	response := ServiceListResponse{
 		Answer: ServiceListAnswer{
@@ -304,6 +436,201 @@ func TestClient_ListZonesByName(t *testing.T) {
 	assert.Equal(t, "example.com", zones[0].Name)
 }
 
+func TestClient_ClearZone(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/clear": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{
+					{DName: "example.com", Result: "success"},
+					{DName: "example.net", Result: "error", ErrorCode: "DOMAIN_NOT_FOUND", ErrorText: "Domain not found"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	require.NoError(t, client.ClearZone(context.Background(), "example.com"))
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/clear", (*calls)[0].Path)
+	assert.Equal(t, []interface{}{map[string]interface{}{"dname": "example.com"}}, (*calls)[0].Input["domains"])
+
+	var apiErr *APIError
+	err := client.ClearZone(context.Background(), "example.net")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "DOMAIN_NOT_FOUND", apiErr.Code)
+}
+
+func TestClient_CreateZone(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/create": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	records, err := client.CreateZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/create", (*calls)[0].Path)
+	assert.Equal(t, []interface{}{map[string]interface{}{"dname": "example.com"}}, (*calls)[0].Input["domains"])
+
+	_, err = client.CreateZone(context.Background(), "example.net")
+	assert.ErrorContains(t, err, "no zone/create result")
+}
+
+func TestClient_IsDelegated(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/is_delegated": ZoneIsDelegatedResponse{
+			Answer: ZoneIsDelegatedAnswer{
+				Domains: []ZoneIsDelegatedResult{
+					{DName: "example.com", Result: "success", IsDelegated: 1},
+					{DName: "example.net", Result: "success", IsDelegated: 0},
+					{DName: "example.org", Result: "error", ErrorCode: "DOMAIN_NOT_FOUND"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	delegated, err := client.IsDelegated(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.True(t, delegated)
+	assert.Equal(t, "zone/is_delegated", (*calls)[0].Path)
+
+	delegated, err = client.IsDelegated(context.Background(), "example.net")
+	require.NoError(t, err)
+	assert.False(t, delegated)
+
+	var apiErr *APIError
+	_, err = client.IsDelegated(context.Background(), "example.org")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "DOMAIN_NOT_FOUND", apiErr.Code)
+}
+
+func TestClient_SetForwarding(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/tune_forwarding": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	require.NoError(t, client.SetForwarding(context.Background(), "example.com", ForwardingParams{}))
+	require.NoError(t, client.SetForwarding(context.Background(), "example.com", ForwardingParams{Subdomain: "www"}))
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/tune_forwarding", (*calls)[0].Path)
+	assert.NotContains(t, (*calls)[0].Input, "subdomain")
+	assert.Equal(t, "www", (*calls)[1].Input["subdomain"])
+}
+
+func TestClient_RemoveForwarding(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/remove_forwarding": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "error", ErrorCode: "NO_FORWARDING"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	var apiErr *APIError
+	err := client.RemoveForwarding(context.Background(), "example.com")
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "NO_FORWARDING", apiErr.Code)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/remove_forwarding", (*calls)[0].Path)
+}
+
+func TestClient_Parking(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/tune_parking": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+		"zone/remove_parking": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	require.NoError(t, client.EnableParking(context.Background(), "example.com"))
+	require.NoError(t, client.DisableParking(context.Background(), "example.com"))
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/tune_parking", (*calls)[0].Path)
+	assert.Equal(t, "zone/remove_parking", (*calls)[1].Path)
+	assert.Equal(t, []interface{}{map[string]interface{}{"dname": "example.com"}}, (*calls)[1].Input["domains"])
+}
+
+func TestClient_GetZoneSOA(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].SOA = &SOAInfo{TTL: "1d", MinimumTTL: "3h"}
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	soa, err := client.GetZoneSOA(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, SOAParams{TTL: 86400, MinimumTTL: 10800}, soa)
+
+	_, err = client.GetZoneSOA(context.Background(), "example.net")
+	assert.Error(t, err)
+}
+
+func TestClient_UpdateZoneSOA(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/update_soa": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	err := client.UpdateZoneSOA(context.Background(), "example.com", SOAParams{TTL: 3600, MinimumTTL: 10800})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/update_soa", (*calls)[0].Path)
+	assert.Equal(t, "1h", (*calls)[0].Input["ttl"])
+	assert.Equal(t, "3h", (*calls)[0].Input["minimum_ttl"])
+
+	err = client.UpdateZoneSOA(context.Background(), "example.com", SOAParams{TTL: 300})
+	require.NoError(t, err)
+	assert.NotContains(t, (*calls)[1].Input, "minimum_ttl")
+
+	err = client.UpdateZoneSOA(context.Background(), "example.com", SOAParams{TTL: -1})
+	assert.ErrorIs(t, err, ErrInvalidTTL)
+	assert.Len(t, *calls, 2)
+}
+
 func TestClient_ListRecords(t *testing.T) {
 	response := ZoneGetResourceRecordsResponse{
 		Answer: ZoneGetResourceRecordsAnswer{
@@ -353,7 +680,121 @@ func TestClient_ListRecords(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, records, 3)
 	assert.Equal(t, "www", records[0].Name)
-	assert.Equal(t, "A", records[0].Type)
+	assert.Equal(t, RecordTypeA, records[0].Type)
+	assert.Equal(t, 10, records[2].Priority)
+}
+
+func TestClient_MXPriority(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{
+					{
+						DName:  "example.com",
+						Result: "success",
+						RRList: []ResourceRecord{
+							{Subname: "@", Rectype: "MX", Content: "mx1.example.com", Prio: 20},
+						},
+					},
+				},
+			},
+			Result: "success",
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	record, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Type: RecordTypeMX, Content: "mx1.example.com", Priority: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 20, record.Priority)
+
+	record, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Type: RecordTypeMX, Content: "10 mx2.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, record.Priority)
+
+	records, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, 20, records[0].Priority)
+	assert.True(t, records[0].Equal(DNSRecord{Name: "@", Type: RecordTypeMX, Content: "20 mx1.example.com"}))
+
+	require.NoError(t, client.DeleteRR(ctx, "example.com", records[0]))
+
+	require.Len(t, *calls, 4)
+	assert.Equal(t, "zone/add_mx", (*calls)[0].Path)
+	assert.Equal(t, "mx1.example.com", (*calls)[0].Input["mail_server"])
+	assert.Equal(t, float64(20), (*calls)[0].Input["priority"])
+	assert.Equal(t, "mx2.example.com", (*calls)[1].Input["mail_server"])
+	assert.Equal(t, float64(10), (*calls)[1].Input["priority"])
+	assert.Equal(t, "zone/remove_record", (*calls)[3].Path)
+	assert.Equal(t, "mx1.example.com", (*calls)[3].Input["content"])
+	assert.Equal(t, float64(20), (*calls)[3].Input["priority"])
+}
+
+func TestClient_ListRecords_TTLAndIDs(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{
+					{
+						DName:  "example.com",
+						Result: "success",
+						RRList: []ResourceRecord{
+							{Subname: "www", Rectype: "A", Content: "192.0.2.1"},
+							{Subname: "@", Rectype: "MX", Content: "mail.example.com", Prio: "10"},
+						},
+						SOA: &SOAInfo{TTL: "1h", MinimumTTL: "5m"},
+					},
+				},
+			},
+			Result: "success",
+		},
+		"zone/get_ns": ZoneListResponse{
+			Answer: ZoneListAnswer{
+				Domains: []DomainWithRecords{
+					{
+						DName: "example.com",
+						NSList: []NSRecord{
+							{Subdomain: "WWW", Type: "A", Content: "192.0.2.1", TTL: 300, DNSID: "101"},
+							{Subdomain: "@", Type: "MX", Content: "10 mail.example.com.", DNSID: "102"},
+						},
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	ctx := context.Background()
+	params := ListDNSRecordsParams{ZoneName: "example.com"}
+
+	records, err := setupTestClient(t, server).ListRecords(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, 3600, records[0].TTL)
+	assert.Zero(t, records[0].TTLDuration)
+	assert.Equal(t, time.Hour, records[0].GetTTL())
+	assert.True(t, records[0].TTLInherited)
+	assert.Equal(t, "", records[0].ID)
+	assert.Equal(t, 10, records[1].Priority)
+	assert.Zero(t, countCalls(*calls, "zone/get_ns"))
+
+	records, err = setupTestClient(t, server, WithRecordIDs()).ListRecords(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "101", records[0].ID)
+	assert.Equal(t, 300, records[0].TTL)
+	assert.False(t, records[0].TTLInherited)
+	assert.Equal(t, "102", records[1].ID)
+	assert.Equal(t, 3600, records[1].TTL)
+	assert.True(t, records[1].TTLInherited)
+	assert.Equal(t, 1, countCalls(*calls, "zone/get_ns"))
 }
 
 func TestClient_ListRecords_WithFilters(t *testing.T) {
@@ -406,10 +847,58 @@ func TestClient_ListRecords_WithFilters(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, records, 2)
 	for _, record := range records {
-		assert.Equal(t, "A", record.Type)
+		assert.Equal(t, RecordTypeA, record.Type)
 	}
 }
 
+func TestClient_ListRecords_Duplicates(t *testing.T) {
+	response := ZoneGetResourceRecordsResponse{
+		Answer: ZoneGetResourceRecordsAnswer{
+			Domains: []DomainWithResourceRecords{
+				{
+					DName:  "example.com",
+					Result: "success",
+					RRList: []ResourceRecord{
+						{
+							Subname: "www",
+							Rectype: "A",
+							Content: "192.0.2.1",
+							Prio:    "0",
+							State:   "A",
+						},
+						{
+							Subname: "www",
+							Rectype: "A",
+							Content: "192.0.2.1",
+							Prio:    "0",
+							State:   "A",
+						},
+					},
+				},
+			},
+		},
+		Result: "success",
+	}
+
+	server := setupTestServer(t, response, http.StatusOK)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	records, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	require.Len(t, records, 1, "duplicates should be dropped by default")
+
+	records, err = client.ListRecords(context.Background(), ListDNSRecordsParams{
+		ZoneName:          "example.com",
+		IncludeDuplicates: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.False(t, records[0].Duplicate)
+	assert.True(t, records[1].Duplicate)
+}
+
 func TestClient_GetRRByName(t *testing.T) {
 	response := ZoneGetResourceRecordsResponse{
 		Answer: ZoneGetResourceRecordsAnswer{
@@ -450,6 +939,38 @@ func TestClient_GetRRByName(t *testing.T) {
 	assert.Equal(t, "192.0.2.1", record.Content)
 }
 
+func TestClient_GetRRByName_CaseInsensitive(t *testing.T) {
+	response := ZoneGetResourceRecordsResponse{
+		Answer: ZoneGetResourceRecordsAnswer{
+			Domains: []DomainWithResourceRecords{
+				{
+					DName:  "example.com",
+					Result: "success",
+					RRList: []ResourceRecord{
+						{
+							Subname: "www",
+							Rectype: "A",
+							Content: "192.0.2.1",
+							Prio:    "0",
+							State:   "A",
+						},
+					},
+				},
+			},
+		},
+		Result: "success",
+	}
+
+	server := setupTestServer(t, response, http.StatusOK)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	record, err := client.GetRRByName(context.Background(), "example.com", "WWW.")
+	require.NoError(t, err)
+	assert.Equal(t, "www", record.Name)
+}
+
 func TestClient_GetRRByName_NotFound(t *testing.T) {
 	response := ZoneGetResourceRecordsResponse{
 		Answer: ZoneGetResourceRecordsAnswer{
@@ -484,10 +1005,57 @@ func TestClient_GetRRByName_NotFound(t *testing.T) {
 	assert.True(t, errors.As(err, &notFoundErr), "error should be RecordNotFoundError")
 }
 
+func TestClient_GetRRByID(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{
+					{
+						DName:  "example.com",
+						Result: "success",
+						RRList: []ResourceRecord{
+							{Subname: "www", Rectype: "A", Content: "192.0.2.1"},
+							{Subname: "www", Rectype: "A", Content: "192.0.2.2"},
+						},
+					},
+				},
+			},
+			Result: "success",
+		},
+		"zone/get_ns": ZoneListResponse{
+			Answer: ZoneListAnswer{
+				Domains: []DomainWithRecords{
+					{
+						DName: "example.com",
+						NSList: []NSRecord{
+							{Subdomain: "www", Type: "A", Content: "192.0.2.1", DNSID: "101"},
+							{Subdomain: "www", Type: "A", Content: "192.0.2.2", DNSID: "102"},
+						},
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	record, err := client.GetRRByID(ctx, "example.com", "102")
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", record.Content)
+	assert.Equal(t, "102", record.ID)
+	assert.Equal(t, 1, countCalls(*calls, "zone/get_ns"))
+
+	_, err = client.GetRRByID(ctx, "example.com", "999")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+	assert.EqualError(t, err, "record not found: dns_id 999")
+}
+
 func TestClient_DeleteRR(t *testing.T) {
 	tests := []struct {
 		name       string
-		recordType string
+		recordType RecordType
 		content    string
 		response   AddNSResponse
 		wantErr    bool
@@ -613,6 +1181,22 @@ func TestClient_DeleteRR(t *testing.T) {
 	}
 }
 
+func TestClient_DeleteRRByID(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	require.NoError(t, client.DeleteRRByID(context.Background(), "example.com", "12345"))
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/remove_record", (*calls)[0].Path)
+	assert.Equal(t, "12345", (*calls)[0].Input["record_number"])
+	assert.NotContains(t, (*calls)[0].Input, "content")
+	domains := (*calls)[0].Input["domains"].([]interface{})
+	assert.Equal(t, "example.com", domains[0].(map[string]interface{})["dname"])
+}
+
 func TestClient_DeleteRR_UnsupportedType(t *testing.T) {
 	client := NewClient("username", "password")
 
@@ -686,6 +1270,71 @@ func TestClient_UpdateRR(t *testing.T) {
 	assert.Equal(t, 2, callCount, "UpdateRR should make 2 API calls")
 }
 
+func TestClient_UpdateRRFrom(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	oldRR := DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"}
+	newRR := DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.2", TTL: 300}
+	updated, err := client.UpdateRRFrom(context.Background(), "example.com", oldRR, newRR)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", updated.Content)
+	assert.Equal(t, 300, updated.TTL)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/remove_record", (*calls)[0].Path)
+	assert.Equal(t, "192.0.2.1", (*calls)[0].Input["content"])
+	assert.Equal(t, "zone/add_alias", (*calls)[1].Path)
+	assert.Equal(t, "192.0.2.2", (*calls)[1].Input["ipaddr"])
+}
+
+func TestClient_UpdateRRWithResult(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_alias": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success", DNSID: "12345"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.UpdateRRWithResult(context.Background(), "example.com", DNSRecord{
+		Name:    "www",
+		Type:    "a",
+		Content: "192.0.2.3",
+		TTL:     3600,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.3", TTL: 3600}, result.Before)
+	assert.Equal(t, "12345", result.After.ID)
+	assert.Equal(t, "192.0.2.3", result.After.Content)
+	assert.Equal(t, []string{"zone/remove_record", "zone/add_alias"}, result.Calls)
+	assert.Len(t, *calls, 2)
+}
+
+func TestClient_UpdateRRWithResult_AddFails(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_alias": map[string]interface{}{"result": "error", "error_text": "quota exceeded"},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.UpdateRRWithResult(context.Background(), "example.com", DNSRecord{
+		Name:    "www",
+		Type:    RecordTypeA,
+		Content: "192.0.2.3",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "192.0.2.3", result.Before.Content, "the removed record is reported for undo")
+	assert.Empty(t, result.After.Content)
+	assert.Equal(t, []string{"zone/remove_record", "zone/add_alias"}, result.Calls)
+}
+
 func TestClient_apiRequest_HTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -818,3 +1467,130 @@ func TestClient_ListRecordsByZoneID_ZoneNotFound(t *testing.T) {
 	var notFoundErr *ZoneNotFoundError
 	assert.True(t, errors.As(err, &notFoundErr), "error should be ZoneNotFoundError")
 }
+
+func TestClient_apiRequest_DefaultDeadlines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithReadDeadline(20*time.Millisecond),
+		WithWriteDeadline(time.Second),
+	)
+
+	// Reads use the short read deadline
+	_, err := client.apiRequest(context.Background(), "zone/get_resource_records", &ZoneGetResourceRecordsRequest{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Writes use the write deadline
+	_, err = client.apiRequest(context.Background(), "zone/add_alias", &AddAliasRequest{})
+	assert.NoError(t, err)
+
+	// A deadline set by the caller takes precedence
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.apiRequest(ctx, "zone/get_resource_records", &ZoneGetResourceRecordsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestIsReadPath(t *testing.T) {
+	assert.True(t, isReadPath("zone/get_resource_records"))
+	assert.True(t, isReadPath("service/get_list"))
+	assert.True(t, isReadPath("nop"))
+	assert.True(t, isReadPath("zone/is_delegated"))
+	assert.False(t, isReadPath("zone/add_alias"))
+	assert.False(t, isReadPath("zone/remove_record"))
+}
+
+func TestClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		_, _ = w.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("username", "password", WithBaseURL(server.URL), WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestClient_MaxConcurrentRequests_ContextDone(t *testing.T) {
+	client := NewClient("username", "password", WithMaxConcurrentRequests(1))
+	client.semaphore <- struct{}{} // occupy the only slot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.apiRequest(ctx, "service/get_list", &ServiceListRequest{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewClient_TransportTimeouts(t *testing.T) {
+	client := NewClient("username", "password",
+		WithDialTimeout(3*time.Second),
+		WithTLSHandshakeTimeout(4*time.Second),
+		WithResponseHeaderTimeout(5*time.Second),
+	)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.DialContext)
+	assert.Equal(t, 4*time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(t, 5*time.Second, transport.ResponseHeaderTimeout)
+	assert.NotSame(t, http.DefaultTransport, client.httpClient.Transport, "default transport must not be modified")
+	assert.Equal(t, DefaultTimeout, client.httpClient.Timeout)
+}
+
+func TestNewClient_TransportTimeoutsCopyHTTPClient(t *testing.T) {
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+
+	client := NewClient("username", "password",
+		WithHTTPClient(httpClient),
+		WithResponseHeaderTimeout(5*time.Second),
+	)
+
+	assert.NotSame(t, httpClient, client.httpClient)
+	assert.Same(t, transport, httpClient.Transport, "caller's client must not be modified")
+	assert.Zero(t, transport.ResponseHeaderTimeout, "caller's transport must not be modified")
+	assert.Equal(t, 5*time.Second, client.httpClient.Transport.(*http.Transport).ResponseHeaderTimeout)
+}
+
+func TestClient_ResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithResponseHeaderTimeout(20*time.Millisecond),
+	)
+
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+}
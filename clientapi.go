@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ClientAPI is the set of public Client methods. Accept it instead of *Client
+// to substitute mocks or fakes in tests; *Client satisfies it.
+type ClientAPI interface {
+	// Zones
+	ListZones(ctx context.Context) ([]Zone, error)
+	ListZonesByName(ctx context.Context, name string) ([]Zone, error)
+	CreateZone(ctx context.Context, domain string) ([]DNSRecord, error)
+	ClearZone(ctx context.Context, zone string) error
+	IsDelegated(ctx context.Context, zone string) (bool, error)
+	GetZoneSOA(ctx context.Context, zone string) (SOAParams, error)
+	UpdateZoneSOA(ctx context.Context, zone string, params SOAParams) error
+	SetForwarding(ctx context.Context, zone string, params ForwardingParams) error
+	RemoveForwarding(ctx context.Context, zone string) error
+	EnableParking(ctx context.Context, zone string) error
+	DisableParking(ctx context.Context, zone string) error
+	ZoneSerial(ctx context.Context, zone string) (uint32, error)
+	HasZoneChanged(ctx context.Context, zone string, lastSerial uint32) (bool, uint32, error)
+	CheckDelegation(ctx context.Context, zone string, opts DelegationOptions) (DelegationReport, error)
+	ExportZoneFile(ctx context.Context, zone string) (string, error)
+	ImportZoneFile(ctx context.Context, zone string, r io.Reader, opts ...BulkOption) (ZoneImportReport, error)
+	CloneZone(ctx context.Context, srcZone, dstZone string, opts ...BulkOption) (CloneReport, error)
+	LintZone(ctx context.Context, zone string, opts ...LintOption) (LintReport, error)
+	LintAll(ctx context.Context, opts ...LintOption) ([]LintReport, error)
+
+	// Records
+	ListRecords(ctx context.Context, params ListDNSRecordsParams) ([]DNSRecord, error)
+	ListRecordsByZoneID(ctx context.Context, id string, params ListDNSRecordsParams) ([]DNSRecord, error)
+	GetRRByName(ctx context.Context, zone, name string) (DNSRecord, error)
+	GetRRByID(ctx context.Context, zone, dnsID string) (DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params CreateDNSRecordParams) (DNSRecord, error)
+	UpdateRR(ctx context.Context, zone string, rr DNSRecord) (DNSRecord, error)
+	UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR DNSRecord) (DNSRecord, error)
+	UpdateRRWithResult(ctx context.Context, zone string, rr DNSRecord) (UpdateResult, error)
+	DeleteRR(ctx context.Context, zone string, rr DNSRecord) error
+	DeleteRRByID(ctx context.Context, zone, dnsID string) error
+	EnsureRR(ctx context.Context, zone string, params CreateDNSRecordParams) (EnsureResult, error)
+	AddRRMulti(ctx context.Context, zones []string, params CreateDNSRecordParams) ([]ZoneResult, error)
+	DeleteRRMulti(ctx context.Context, zones []string, rr DNSRecord) ([]ZoneResult, error)
+	GetRRSet(ctx context.Context, zone, name string, rtype RecordType) (RRSet, error)
+	PutRRSet(ctx context.Context, zone string, set RRSet, opts ...BulkOption) (BatchResults, error)
+	DeleteRRSet(ctx context.Context, zone, name string, rtype RecordType, opts ...BulkOption) (BatchResults, error)
+	AddPTRRecords(ctx context.Context, records []PTRRecord, opts ...BulkOption) (BatchResults, error)
+	WaitForRecord(ctx context.Context, zone, name string, rtype RecordType, expectedContent string, opts WaitOptions) (WaitResult, error)
+
+	// Bulk changes
+	AddRRs(ctx context.Context, zone string, params []CreateDNSRecordParams, opts ...BulkOption) (BatchResults, error)
+	DeleteRRs(ctx context.Context, zone string, records []DNSRecord, opts ...BulkOption) (BatchResults, error)
+	ImportRecordsCSV(ctx context.Context, zone string, r io.Reader, opts ...BulkOption) (BatchResults, error)
+	SyncZone(ctx context.Context, zone string, desired []DNSRecord, opts ...BulkOption) (BatchResults, error)
+	BatchUpdate(ctx context.Context, zone string, actions []RecordAction) (BatchResults, error)
+	ApplyPlan(ctx context.Context, zone string, plan Plan, confirm func(Plan) bool, opts ...BulkOption) (BatchResults, error)
+	ApplyTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string, opts ...BulkOption) (BatchResults, error)
+	PlanTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string) (Plan, error)
+	SyncTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string, opts ...BulkOption) (BatchResults, error)
+	ApplyMailPreset(ctx context.Context, zone string, preset MailPreset, opts ...BulkOption) (BatchResults, error)
+	SetZoneTTL(ctx context.Context, zone string, ttl int, filter RecordFilter, opts ...BulkOption) (TTLUpdateReport, error)
+	MoveRR(ctx context.Context, zone, oldName, newName string, rtype RecordType, opts ...BulkOption) (MoveReport, error)
+	SetPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error)
+	ReplacePool(ctx context.Context, zone, name string, oldIPs, newIPs []string, opts ...BulkOption) (PoolReport, error)
+	AddToPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error)
+	RemoveFromPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error)
+	EnsureCAAPolicy(ctx context.Context, zone string, policy CAAPolicy) (BatchResults, error)
+	SetCAAPolicy(ctx context.Context, zone string, policy CAAPolicy) (BatchResults, error)
+	UpsertEmailAuth(ctx context.Context, zone string, policy EmailAuthPolicy) ([]EnsureResult, error)
+	SetSPF(ctx context.Context, zone string, spf SPF) (EnsureResult, error)
+	GetSPF(ctx context.Context, zone string) (SPF, error)
+	PublishDKIM(ctx context.Context, zone string, dkim DKIM) (EnsureResult, error)
+	VerifyDKIM(ctx context.Context, zone string, dkim DKIM) error
+	SetDMARC(ctx context.Context, zone string, dmarc DMARC) (EnsureResult, error)
+	GetDMARC(ctx context.Context, zone string) (DMARC, error)
+	SetBIMI(ctx context.Context, zone string, bimi BIMI) (EnsureResult, error)
+	GetBIMI(ctx context.Context, zone, selector string) (BIMI, error)
+	AuditMailAuth(ctx context.Context, zone string) (MailAuthReport, error)
+	AuditMailAuthAll(ctx context.Context) ([]MailAuthReport, error)
+
+	// Domains and billing
+	ListDomains(ctx context.Context) ([]Domain, error)
+	GetDomain(ctx context.Context, domain string) (Domain, error)
+	GetNameservers(ctx context.Context, domain string) ([]string, error)
+	RegisterDomain(ctx context.Context, order DomainOrder) (Order, error)
+	RenewDomain(ctx context.Context, domain string, period int) (Order, error)
+	RegisterDomainAndPay(ctx context.Context, order DomainOrder, opts ...FlowOption) (FlowResult, error)
+	RenewDomainAndPay(ctx context.Context, domain string, period int, opts ...FlowOption) (FlowResult, error)
+	ListExpiringDomains(ctx context.Context, within time.Duration) ([]Domain, error)
+	EstimateRenewals(ctx context.Context, within time.Duration, opts ...EstimateOption) (RenewalEstimate, error)
+	ListUnpaidBills(ctx context.Context) ([]Bill, error)
+	PayFromBalance(ctx context.Context, billID string) (Bill, error)
+
+	// Account and client state
+	CheckAccess(ctx context.Context) error
+	GetBalance(ctx context.Context) (Balance, error)
+	SetCredentials(username, password string)
+	InvalidateRecordsCache(zone string)
+	Profile() string
+	Stats() Stats
+}
+
+var _ ClientAPI = (*Client)(nil)
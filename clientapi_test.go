@@ -0,0 +1,35 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientAPI_CoversClientMethods(t *testing.T) {
+	api := reflect.TypeOf((*ClientAPI)(nil)).Elem()
+	client := reflect.TypeOf((*Client)(nil))
+
+	for i := 0; i < client.NumMethod(); i++ {
+		name := client.Method(i).Name
+		_, ok := api.MethodByName(name)
+		assert.True(t, ok, "ClientAPI is missing Client.%s", name)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// apiClient is the subset of regru.Client methods used by the commands.
+type apiClient interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+	UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR regru.DNSRecord) (regru.DNSRecord, error)
+	ExportZoneFile(ctx context.Context, zone string) (string, error)
+	ImportZoneFile(ctx context.Context, zone string, r io.Reader, opts ...regru.BulkOption) (regru.ZoneImportReport, error)
+	CheckAccess(ctx context.Context) error
+	GetBalance(ctx context.Context) (regru.Balance, error)
+	Profile() string
+}
+
+// app runs commands against a client.
+type app struct {
+	client   apiClient
+	username string
+	format   string
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// command is a subcommand handler receiving the arguments after its name.
+type command func(a *app, ctx context.Context, args []string) error
+
+// commands maps "<group> <name>" and single-word command names to handlers.
+var commands = map[string]command{
+	"zones list":     (*app).zonesList,
+	"records list":   (*app).recordsList,
+	"records add":    (*app).recordsAdd,
+	"records rm":     (*app).recordsRemove,
+	"records update": (*app).recordsUpdate,
+	"zone export":    (*app).zoneExport,
+	"zone import":    (*app).zoneImport,
+	"whoami":         (*app).whoami,
+}
+
+// dispatch runs the command named by the leading arguments.
+func (a *app) dispatch(ctx context.Context, args []string) error {
+	if cmd, ok := commands[args[0]]; ok {
+		return cmd(a, ctx, args[1:])
+	}
+	if len(args) > 1 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			return cmd(a, ctx, args[2:])
+		}
+	}
+	fmt.Fprintf(a.stderr, "unknown command %q\n", strings.Join(args[:min(len(args), 2)], " "))
+	return errUsage
+}
+
+// parseArgs parses the command flags and checks the number of positional arguments.
+func (a *app) parseArgs(flags *flag.FlagSet, args []string, positional string) ([]string, error) {
+	want := len(strings.Fields(positional))
+	flags.SetOutput(a.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: regru %s [flags] %s\n", flags.Name(), positional)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != want {
+		flags.Usage()
+		return nil, errUsage
+	}
+	return flags.Args(), nil
+}
+
+func (a *app) zonesList(ctx context.Context, args []string) error {
+	if _, err := a.parseArgs(flag.NewFlagSet("zones list", flag.ContinueOnError), args, ""); err != nil {
+		return err
+	}
+
+	zones, err := a.client.ListZones(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(zones, zonesTable(zones))
+}
+
+func (a *app) recordsList(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("records list", flag.ContinueOnError)
+	name := flags.String("name", "", "only records with the `name`")
+	recordType := flags.String("type", "", "only records of the `type`")
+	pos, err := a.parseArgs(flags, args, "<zone>")
+	if err != nil {
+		return err
+	}
+
+	records, err := a.client.ListRecords(ctx, regru.ListDNSRecordsParams{
+		ZoneName: pos[0],
+		Name:     *name,
+		Type:     regru.RecordType(strings.ToUpper(*recordType)),
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(records, recordsTable(records))
+}
+
+func (a *app) recordsAdd(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("records add", flag.ContinueOnError)
+	ttl := flags.Int("ttl", 0, "record TTL in `seconds` (zone default if 0)")
+	pos, err := a.parseArgs(flags, args, "<zone> <name> <type> <content>")
+	if err != nil {
+		return err
+	}
+
+	record, err := a.client.AddRR(ctx, pos[0], regru.CreateDNSRecordParams{
+		Name:    pos[1],
+		Type:    regru.RecordType(strings.ToUpper(pos[2])),
+		Content: pos[3],
+		TTL:     *ttl,
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(record, recordsTable([]regru.DNSRecord{record}))
+}
+
+func (a *app) recordsRemove(ctx context.Context, args []string) error {
+	pos, err := a.parseArgs(flag.NewFlagSet("records rm", flag.ContinueOnError), args, "<zone> <name> <type> <content>")
+	if err != nil {
+		return err
+	}
+
+	record := regru.DNSRecord{Name: pos[1], Type: regru.RecordType(strings.ToUpper(pos[2])), Content: pos[3]}
+	if err := a.client.DeleteRR(ctx, pos[0], record); err != nil {
+		return err
+	}
+	return a.print(record, recordsTable([]regru.DNSRecord{record}))
+}
+
+func (a *app) recordsUpdate(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("records update", flag.ContinueOnError)
+	ttl := flags.Int("ttl", 0, "record TTL in `seconds` (zone default if 0)")
+	pos, err := a.parseArgs(flags, args, "<zone> <name> <type> <old-content> <new-content>")
+	if err != nil {
+		return err
+	}
+
+	recordType := regru.RecordType(strings.ToUpper(pos[2]))
+	oldRR := regru.DNSRecord{Name: pos[1], Type: recordType, Content: pos[3]}
+	newRR := regru.DNSRecord{Name: pos[1], Type: recordType, Content: pos[4], TTL: *ttl}
+	record, err := a.client.UpdateRRFrom(ctx, pos[0], oldRR, newRR)
+	if err != nil {
+		return err
+	}
+	return a.print(record, recordsTable([]regru.DNSRecord{record}))
+}
+
+func (a *app) zoneExport(ctx context.Context, args []string) error {
+	pos, err := a.parseArgs(flag.NewFlagSet("zone export", flag.ContinueOnError), args, "<zone>")
+	if err != nil {
+		return err
+	}
+
+	zoneFile, err := a.client.ExportZoneFile(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]string{"zone": pos[0], "zone_file": zoneFile}, rawOutput(zoneFile))
+}
+
+func (a *app) zoneImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("zone import", flag.ContinueOnError)
+	replace := flags.Bool("replace", false, "delete records missing from the file")
+	dryRun := flags.Bool("dry-run", false, "report the changes without applying them")
+	pos, err := a.parseArgs(flags, args, "<zone> <file>")
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if pos[1] != "-" {
+		f, err := os.Open(pos[1])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var opts []regru.BulkOption
+	if *replace {
+		opts = append(opts, regru.WithReplace())
+	}
+	if *dryRun {
+		opts = append(opts, regru.WithDryRun())
+	}
+
+	report, err := a.client.ImportZoneFile(ctx, pos[0], r, opts...)
+	if err != nil {
+		return err
+	}
+	for _, w := range report.Skipped {
+		fmt.Fprintln(a.stderr, "skipped:", w.String())
+	}
+	if err := a.print(report.Results, resultsTable(report.Results)); err != nil {
+		return err
+	}
+	if failed := report.Results.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d of %d changes failed", len(failed), len(report.Results))
+	}
+	return nil
+}
+
+func (a *app) whoami(ctx context.Context, args []string) error {
+	if _, err := a.parseArgs(flag.NewFlagSet("whoami", flag.ContinueOnError), args, ""); err != nil {
+		return err
+	}
+
+	if err := a.client.CheckAccess(ctx); err != nil {
+		return err
+	}
+	balance, err := a.client.GetBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	info := account{Username: a.username, Profile: a.client.Profile(), Balance: balance}
+	return a.print(info, accountTable(info))
+}
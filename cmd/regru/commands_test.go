@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is an in-memory apiClient implementation.
+type fakeClient struct {
+	records  []regru.DNSRecord
+	added    []regru.CreateDNSRecordParams
+	deleted  []regru.DNSRecord
+	imported string
+	opts     int
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return []regru.Zone{{Name: "example.com", Status: regru.ZoneStatusActive}}, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	f.added = append(f.added, params)
+	return regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func (f *fakeClient) UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR regru.DNSRecord) (regru.DNSRecord, error) {
+	f.deleted = append(f.deleted, oldRR)
+	return newRR, nil
+}
+
+func (f *fakeClient) ExportZoneFile(ctx context.Context, zone string) (string, error) {
+	return "$ORIGIN " + zone + ".\n", nil
+}
+
+func (f *fakeClient) ImportZoneFile(ctx context.Context, zone string, r io.Reader, opts ...regru.BulkOption) (regru.ZoneImportReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return regru.ZoneImportReport{}, err
+	}
+	f.imported = string(data)
+	f.opts = len(opts)
+	return regru.ZoneImportReport{Results: regru.BatchResults{
+		{Item: regru.DNSRecord{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1"}, Operation: regru.BatchOperationCreate, Success: true},
+	}}, nil
+}
+
+func (f *fakeClient) CheckAccess(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeClient) GetBalance(ctx context.Context) (regru.Balance, error) {
+	return regru.Balance{Prepay: 100.5, Currency: "RUB"}, nil
+}
+
+func (f *fakeClient) Profile() string {
+	return "prod"
+}
+
+func runCommand(t *testing.T, client *fakeClient, format string, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	a := &app{client: client, username: "user@example.com", format: format, stdout: &stdout, stderr: &stderr}
+	err := a.dispatch(context.Background(), args)
+	return stdout.String(), err
+}
+
+func TestZonesList(t *testing.T) {
+	out, err := runCommand(t, &fakeClient{}, formatTable, "zones", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "NAME         STATUS  EXPIRES\nexample.com  active  \n", out)
+}
+
+func TestRecordsList_JSON(t *testing.T) {
+	client := &fakeClient{records: []regru.DNSRecord{{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1", TTL: 300}}}
+
+	out, err := runCommand(t, client, formatJSON, "records", "list", "-type", "a", "example.com")
+	require.NoError(t, err)
+
+	var records []regru.DNSRecord
+	require.NoError(t, json.Unmarshal([]byte(out), &records))
+	assert.Equal(t, client.records, records)
+}
+
+func TestRecordsAdd(t *testing.T) {
+	client := &fakeClient{}
+
+	out, err := runCommand(t, client, formatTable, "records", "add", "-ttl", "300", "example.com", "www", "a", "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, []regru.CreateDNSRecordParams{{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1", TTL: 300}}, client.added)
+	assert.Contains(t, out, "www   A     300  192.0.2.1")
+}
+
+func TestRecordsRemove(t *testing.T) {
+	client := &fakeClient{}
+
+	_, err := runCommand(t, client, formatTable, "records", "rm", "example.com", "www", "A", "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, []regru.DNSRecord{{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1"}}, client.deleted)
+}
+
+func TestRecordsUpdate(t *testing.T) {
+	client := &fakeClient{}
+
+	out, err := runCommand(t, client, formatTable, "records", "update", "example.com", "www", "A", "192.0.2.1", "192.0.2.2")
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", client.deleted[0].Content)
+	assert.Contains(t, out, "192.0.2.2")
+}
+
+func TestZoneExport(t *testing.T) {
+	out, err := runCommand(t, &fakeClient{}, formatTable, "zone", "export", "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "$ORIGIN example.com.\n", out)
+}
+
+func TestZoneImport(t *testing.T) {
+	path := t.TempDir() + "/example.com.zone"
+	require.NoError(t, os.WriteFile(path, []byte("www 300 IN A 192.0.2.1\n"), 0o600))
+	client := &fakeClient{}
+
+	out, err := runCommand(t, client, formatTable, "zone", "import", "-replace", "-dry-run", "example.com", path)
+	require.NoError(t, err)
+	assert.Equal(t, "www 300 IN A 192.0.2.1\n", client.imported)
+	assert.Equal(t, 2, client.opts)
+	assert.Contains(t, out, "create     www   A     192.0.2.1  ok")
+}
+
+func TestWhoami(t *testing.T) {
+	out, err := runCommand(t, &fakeClient{}, formatJSON, "whoami")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username": "user@example.com", "profile": "prod", "balance": {"prepay": 100.5, "blocked": 0, "credit": 0, "currency": "RUB"}}`, out)
+}
+
+func TestDispatch_Usage(t *testing.T) {
+	_, err := runCommand(t, &fakeClient{}, formatTable, "records", "frobnicate")
+	assert.ErrorIs(t, err, errUsage)
+
+	_, err = runCommand(t, &fakeClient{}, formatTable, "records", "add", "example.com")
+	assert.ErrorIs(t, err, errUsage)
+}
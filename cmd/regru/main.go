@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command regru runs ad-hoc operations on reg.ru DNS zones.
+//
+// Usage:
+//
+//	regru [flags] <command> [arguments]
+//
+// Commands:
+//
+//	zones list
+//	records list [-name name] [-type type] <zone>
+//	records add [-ttl seconds] <zone> <name> <type> <content>
+//	records rm <zone> <name> <type> <content>
+//	records update [-ttl seconds] <zone> <name> <type> <old-content> <new-content>
+//	zone export <zone>
+//	zone import [-replace] [-dry-run] <zone> <file>
+//	whoami
+//
+// Credentials are taken from the REGRU_USERNAME and REGRU_PASSWORD environment
+// variables or, if they are not set, from a profile of the configuration file
+// (see regru.LoadConfig). Results are printed as a table, or as JSON with -o json.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+const (
+	// usernameEnv and passwordEnv hold credentials that take precedence over the configuration file.
+	usernameEnv = "REGRU_USERNAME"
+	passwordEnv = "REGRU_PASSWORD"
+)
+
+const usage = `Usage: regru [flags] <command> [arguments]
+
+Commands:
+  zones list
+  records list [-name name] [-type type] <zone>
+  records add [-ttl seconds] <zone> <name> <type> <content>
+  records rm <zone> <name> <type> <content>
+  records update [-ttl seconds] <zone> <name> <type> <old-content> <new-content>
+  zone export <zone>
+  zone import [-replace] [-dry-run] <zone> <file>
+  whoami
+
+Flags:
+`
+
+// errUsage reports invalid command-line arguments; the usage has already been printed.
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "regru:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags, creates the client and runs the command.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("regru", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	profile := flags.String("profile", "", "configuration profile `name`")
+	configPath := flags.String("config", "", "configuration file `path`")
+	output := flags.String("o", formatTable, "output `format`: table or json")
+	timeout := flags.Duration("timeout", 5*time.Minute, "maximum duration of the command")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *output != formatTable && *output != formatJSON {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *output)
+		return errUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	client, username, err := newClient(*configPath, *profile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	a := &app{client: client, username: username, format: *output, stdout: stdout, stderr: stderr}
+	return a.dispatch(ctx, flags.Args())
+}
+
+// newClient creates a client from the environment or the configuration profile
+// and returns it with the account username.
+func newClient(configPath, profile string) (*regru.Client, string, error) {
+	if username := os.Getenv(usernameEnv); username != "" {
+		return regru.NewClient(username, os.Getenv(passwordEnv)), username, nil
+	}
+
+	cfg, err := regru.LoadConfig(configPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("no credentials: set %s and %s or create a configuration file: %w", usernameEnv, passwordEnv, err)
+	}
+	p, err := cfg.Profile(profile)
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := p.NewClient()
+	if err != nil {
+		return nil, "", err
+	}
+	return client, p.Username, nil
+}
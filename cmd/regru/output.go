@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// Output formats selected with the -o flag.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// renderer writes the table form of a command result.
+type renderer func(w io.Writer) error
+
+// account is the result of the whoami command.
+type account struct {
+	Username string        `json:"username"`
+	Profile  string        `json:"profile,omitempty"`
+	Balance  regru.Balance `json:"balance"`
+}
+
+// print writes the result as JSON or in its table form.
+func (a *app) print(v interface{}, table renderer) error {
+	if a.format == formatJSON {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	return table(a.stdout)
+}
+
+// newTable returns a renderer aligning the header and rows in columns.
+func newTable(header []string, rows [][]string) renderer {
+	return func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// rawOutput returns a renderer writing the text as it is.
+func rawOutput(text string) renderer {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, text)
+		return err
+	}
+}
+
+// zonesTable renders zones.
+func zonesTable(zones []regru.Zone) renderer {
+	rows := make([][]string, 0, len(zones))
+	for _, z := range zones {
+		expires := ""
+		if !z.ExpirationDate.IsZero() {
+			expires = z.ExpirationDate.Format("2006-01-02")
+		}
+		rows = append(rows, []string{z.Name, z.Status, expires})
+	}
+	return newTable([]string{"NAME", "STATUS", "EXPIRES"}, rows)
+}
+
+// recordsTable renders records.
+func recordsTable(records []regru.DNSRecord) renderer {
+	rows := make([][]string, 0, len(records))
+	for _, rr := range records {
+		rows = append(rows, []string{rr.Name, string(rr.Type), formatTTL(rr.TTL), rr.Content})
+	}
+	return newTable([]string{"NAME", "TYPE", "TTL", "CONTENT"}, rows)
+}
+
+// resultsTable renders the outcomes of a bulk operation.
+func resultsTable(results regru.BatchResults) renderer {
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := "ok"
+		switch {
+		case r.Err != nil:
+			status = "error: " + r.Err.Error()
+		case !r.Success:
+			status = "dry run"
+		}
+		rows = append(rows, []string{string(r.Operation), r.Item.Name, string(r.Item.Type), r.Item.Content, status})
+	}
+	return newTable([]string{"OPERATION", "NAME", "TYPE", "CONTENT", "STATUS"}, rows)
+}
+
+// accountTable renders the whoami result.
+func accountTable(info account) renderer {
+	rows := [][]string{
+		{"Username", info.Username},
+		{"Profile", info.Profile},
+		{"Balance", fmt.Sprintf("%.2f %s", info.Balance.Prepay, info.Balance.Currency)},
+	}
+	return newTable([]string{"FIELD", "VALUE"}, rows)
+}
+
+// formatTTL returns the TTL in seconds, or an empty string if it is unknown.
+func formatTTL(ttl int) string {
+	if ttl == 0 {
+		return ""
+	}
+	return strconv.Itoa(ttl)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultProfileName is used when neither a profile name nor a default profile is set.
+	DefaultProfileName = "default"
+	// ConfigPathEnv overrides the configuration file path.
+	ConfigPathEnv = "REGRU_CONFIG"
+	// ProfileEnv selects the profile used by NewClientFromProfile when no name is given.
+	ProfileEnv = "REGRU_PROFILE"
+)
+
+// configFileNames are looked up in the regru user configuration directory, in order.
+var configFileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// Config is the contents of a configuration file.
+//
+// Example (YAML):
+//
+//	default_profile: prod
+//	profiles:
+//	  prod:
+//	    username: user@example.com
+//	    password: secret
+//	    timeout: 60s
+//	  staging:
+//	    username: staging@example.com
+//	    password: secret
+//	    base_url: https://api.test.example/api/regru2
+type Config struct {
+	DefaultProfile string             `yaml:"default_profile" toml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles" toml:"profiles"`
+}
+
+// Profile holds the credentials and client defaults of one account.
+type Profile struct {
+	// Name is the profile name; it is set by Config.Profile.
+	Name     string `yaml:"-" toml:"-"`
+	Username string `yaml:"username" toml:"username"`
+	Password string `yaml:"password" toml:"password"`
+	BaseURL  string `yaml:"base_url" toml:"base_url"`
+	// Timeout is the HTTP request timeout as a Go duration ("30s").
+	Timeout string `yaml:"timeout" toml:"timeout"`
+	// MaxRetries is the number of retries of failed requests.
+	MaxRetries int `yaml:"max_retries" toml:"max_retries"`
+	// MaxConcurrentRequests limits the number of requests in flight.
+	MaxConcurrentRequests int `yaml:"max_concurrent_requests" toml:"max_concurrent_requests"`
+}
+
+// DefaultConfigPath returns the path of the configuration file: the value of
+// REGRU_CONFIG if set, otherwise the first existing config.yaml, config.yml or
+// config.toml in the regru directory under the user configuration directory
+// (~/.config/regru on Linux).
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, "regru", name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("no configuration file found in %s: %w", filepath.Join(dir, "regru"), os.ErrNotExist)
+}
+
+// LoadConfig reads a YAML or TOML configuration file, chosen by the file
+// extension. If path is empty, DefaultConfigPath is used.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("failed to parse config %s: unknown key %s", path, undecoded[0])
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", ext)
+	}
+
+	return &cfg, nil
+}
+
+// Profile returns the named profile. An empty name selects the value of
+// REGRU_PROFILE, then the configured default profile, then "default".
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = DefaultProfileName
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, &ProfileNotFoundError{Name: name}
+	}
+	profile.Name = name
+	return profile, nil
+}
+
+// NewClient creates a client for the named profile (see Profile for how an
+// empty name is resolved). Use it to work with several accounts from one
+// process while keeping the account explicit at every call site.
+func (c *Config) NewClient(name string, opts ...ClientOption) (*Client, error) {
+	profile, err := c.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewClient(opts...)
+}
+
+// NewClients creates a client for every profile, keyed by profile name.
+func (c *Config) NewClients(opts ...ClientOption) (map[string]*Client, error) {
+	clients := make(map[string]*Client, len(c.Profiles))
+	for name := range c.Profiles {
+		client, err := c.NewClient(name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		clients[name] = client
+	}
+	return clients, nil
+}
+
+// ClientOptions converts the profile defaults into client options.
+func (p Profile) ClientOptions() ([]ClientOption, error) {
+	var opts []ClientOption
+	if p.Name != "" {
+		opts = append(opts, WithProfileName(p.Name))
+	}
+	if p.BaseURL != "" {
+		opts = append(opts, WithBaseURL(p.BaseURL))
+	}
+	if p.Timeout != "" {
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", p.Timeout, err)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+	if p.MaxRetries > 0 {
+		opts = append(opts, WithMaxRetries(p.MaxRetries))
+	}
+	if p.MaxConcurrentRequests > 0 {
+		opts = append(opts, WithMaxConcurrentRequests(p.MaxConcurrentRequests))
+	}
+	return opts, nil
+}
+
+// NewClient creates a client with the profile credentials and defaults.
+// Additional options are applied after the profile defaults.
+func (p Profile) NewClient(opts ...ClientOption) (*Client, error) {
+	profileOpts, err := p.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(p.Username, p.Password, append(profileOpts, opts...)...), nil
+}
+
+// NewClientFromProfile loads the default configuration file and creates a
+// client for the named profile (see Config.Profile for how an empty name is resolved).
+func NewClientFromProfile(name string, opts ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfig("")
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.NewClient(name, opts...)
+}
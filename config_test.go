@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAMLConfig = `default_profile: prod
+profiles:
+  prod:
+    username: prod-user
+    password: prod-pass
+    timeout: 45s
+    max_retries: 2
+  staging:
+    username: staging-user
+    password: staging-pass
+    base_url: https://staging.example/api/regru2
+`
+
+const testTOMLConfig = `default_profile = "prod"
+
+[profiles.prod]
+username = "prod-user"
+password = "prod-pass"
+timeout = "45s"
+max_retries = 2
+
+[profiles.staging]
+username = "staging-user"
+password = "staging-pass"
+base_url = "https://staging.example/api/regru2"
+`
+
+// writeConfig writes a configuration file into a temporary directory and returns its path.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	for name, content := range map[string]string{
+		"config.yaml": testYAMLConfig,
+		"config.toml": testTOMLConfig,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, "")
+
+			cfg, err := LoadConfig(writeConfig(t, name, content))
+			require.NoError(t, err)
+			assert.Equal(t, "prod", cfg.DefaultProfile)
+			require.Len(t, cfg.Profiles, 2)
+
+			profile, err := cfg.Profile("")
+			require.NoError(t, err)
+			assert.Equal(t, Profile{Name: "prod", Username: "prod-user", Password: "prod-pass", Timeout: "45s", MaxRetries: 2}, profile)
+
+			staging, err := cfg.Profile("staging")
+			require.NoError(t, err)
+			assert.Equal(t, "https://staging.example/api/regru2", staging.BaseURL)
+
+			_, err = cfg.Profile("missing")
+			assert.ErrorIs(t, err, ErrProfileNotFound)
+		})
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, "config.yaml", "profiles:\n  prod:\n    user: x\n"))
+	assert.Error(t, err, "unknown keys should be rejected")
+
+	_, err = LoadConfig(writeConfig(t, "config.toml", "[profiles.prod]\nuser = \"x\"\n"))
+	assert.Error(t, err, "unknown keys should be rejected")
+
+	_, err = LoadConfig(writeConfig(t, "config.json", "{}"))
+	assert.Error(t, err)
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConfig_Profile_Env(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "config.yaml", testYAMLConfig))
+	require.NoError(t, err)
+
+	t.Setenv(ProfileEnv, "staging")
+	profile, err := cfg.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, "staging-user", profile.Username)
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	t.Setenv(ConfigPathEnv, writeConfig(t, "config.yaml", testYAMLConfig))
+	t.Setenv(ProfileEnv, "")
+
+	client, err := NewClientFromProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-user", client.credentials.username)
+	assert.Equal(t, DefaultBaseURL, client.baseURL)
+	assert.Equal(t, 45*time.Second, client.httpClient.Timeout)
+	assert.Equal(t, 2, client.maxRetries)
+
+	// Explicit options override profile defaults
+	client, err = NewClientFromProfile("staging", WithBaseURL("https://override.example"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://override.example", client.baseURL)
+
+	_, err = NewClientFromProfile("missing")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestConfig_NewClients(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, "config.yaml", testYAMLConfig))
+	require.NoError(t, err)
+
+	clients, err := cfg.NewClients()
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	assert.Equal(t, "prod", clients["prod"].Profile())
+	assert.Equal(t, "staging-user", clients["staging"].credentials.username)
+	assert.Equal(t, "staging", clients["staging"].Profile())
+}
+
+func TestProfile_ClientOptions_InvalidTimeout(t *testing.T) {
+	_, err := Profile{Timeout: "soon"}.ClientOptions()
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mixanemca/regru-go/fixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance_ResourceRecords(t *testing.T) {
+	fixtures.AssertMapsAllFields(t, fixtures.ZoneGetResourceRecords, &ZoneGetResourceRecordsResponse{})
+
+	server := fixtures.Server(t, map[string]string{"zone/get_resource_records": fixtures.ZoneGetResourceRecords})
+	client := setupTestClient(t, server)
+
+	records, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	// Records inherit the zone TTL from the SOA
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeAAAA, Content: "2001:db8::1", TTL: 86400, TTLInherited: true}, records[2])
+	assert.Equal(t, 10, records[3].Priority)
+
+	soa, err := client.GetZoneSOA(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, SOAParams{TTL: 86400, MinimumTTL: 43200}, soa)
+}
+
+func TestConformance_ResourceRecordsQuirks(t *testing.T) {
+	server := fixtures.Server(t, map[string]string{"zone/get_resource_records": fixtures.ZoneGetResourceRecordsQuirks})
+	client := setupTestClient(t, server)
+
+	records, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "пример.рф"})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, 20, records[0].Priority)
+	assert.True(t, records[2].Equal(DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.10"}))
+}
+
+func TestConformance_ServiceList(t *testing.T) {
+	server := fixtures.Server(t, map[string]string{"service/get_list": fixtures.ServiceGetList})
+	client := setupTestClient(t, server)
+
+	zones, err := client.ListZones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com", zones[0].Name)
+	assert.Equal(t, "12345678", zones[0].ID)
+	assert.Equal(t, ZoneStatusActive, zones[0].Status)
+
+	server = fixtures.Server(t, map[string]string{"service/get_list": fixtures.ServiceGetListAlternative})
+	client = setupTestClient(t, server)
+
+	zones, err = client.ListZones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.org", zones[0].Name)
+	assert.Equal(t, "23456789", zones[0].ID)
+	assert.Equal(t, ZoneStatusSuspended, zones[0].Status)
+}
+
+func TestConformance_Balance(t *testing.T) {
+	server := fixtures.Server(t, map[string]string{"user/get_balance": fixtures.UserGetBalance})
+	client := setupTestClient(t, server)
+
+	balance, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Balance{Prepay: 1520.35, Currency: "RUB"}, balance)
+}
+
+func TestConformance_AddRecord(t *testing.T) {
+	server := fixtures.Server(t, map[string]string{"zone/add_alias": fixtures.ZoneAddRecord})
+	client := setupTestClient(t, server)
+
+	record, err := client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"})
+	require.NoError(t, err)
+	assert.Equal(t, "1234567", record.ID)
+
+	server = fixtures.Server(t, map[string]string{"zone/add_alias": fixtures.ZoneAddRecordDomainError})
+	client = setupTestClient(t, server)
+
+	_, err = client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "DOMAIN_NOT_FOUND", apiErr.Code)
+}
+
+func TestConformance_ErrorEnvelopes(t *testing.T) {
+	server := fixtures.Server(t, map[string]string{"user/get_balance": fixtures.ErrorInvalidAuth})
+	client := setupTestClient(t, server)
+
+	_, err := client.GetBalance(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "PASSWORD_AUTH_FAILED", apiErr.Code)
+
+	server = fixtures.Server(t, map[string]string{"user/get_balance": fixtures.ErrorIPRestricted})
+	client = setupTestClient(t, server)
+
+	_, err = client.GetBalance(context.Background())
+	var ipErr *IPRestrictedError
+	assert.True(t, errors.As(err, &ipErr))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"sync"
+)
+
+// CredentialsProvider supplies API credentials. It is consulted before every
+// request, so rotated credentials are picked up without recreating the client.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// CredentialsProviderFunc adapts a function to the CredentialsProvider interface.
+type CredentialsProviderFunc func(ctx context.Context) (username, password string, err error)
+
+// Credentials calls f(ctx).
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+// WithCredentialsProvider makes the client obtain credentials from the provider
+// before every request instead of using the username and password passed to NewClient.
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials.provider = provider
+	}
+}
+
+// credentialStore holds the static credentials of a client and an optional provider.
+type credentialStore struct {
+	mu       sync.RWMutex
+	username string
+	password string
+	provider CredentialsProvider
+}
+
+// get returns the credentials to use for a request.
+func (s *credentialStore) get(ctx context.Context) (string, string, error) {
+	s.mu.RLock()
+	username, password, provider := s.username, s.password, s.provider
+	s.mu.RUnlock()
+
+	if provider != nil {
+		return provider.Credentials(ctx)
+	}
+	return username, password, nil
+}
+
+// SetCredentials replaces the static credentials of the client. It is safe to
+// call while requests are in flight: each request uses the credentials that
+// were current when it started. A provider set with WithCredentialsProvider
+// takes precedence over static credentials.
+func (c *Client) SetCredentials(username, password string) {
+	c.credentials.mu.Lock()
+	defer c.credentials.mu.Unlock()
+	c.credentials.username = username
+	c.credentials.password = password
+}
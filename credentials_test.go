@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SetCredentials(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, nil)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	client.SetCredentials("rotated-user", "rotated-pass")
+
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "rotated-user", (*calls)[0].Input["username"])
+	assert.Equal(t, "rotated-pass", (*calls)[0].Input["password"])
+}
+
+func TestClient_SetCredentials_Concurrent(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, nil)
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.SetCredentials("user", "pass")
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_CredentialsProvider(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, nil)
+	defer server.Close()
+
+	version := 0
+	client := NewClient("static-user", "static-pass",
+		WithBaseURL(server.URL),
+		WithCredentialsProvider(CredentialsProviderFunc(func(ctx context.Context) (string, string, error) {
+			version++
+			if version > 2 {
+				return "", "", errors.New("vault unavailable")
+			}
+			return "user", "pass-" + string(rune('0'+version)), nil
+		})),
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+		require.NoError(t, err)
+	}
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "pass-1", (*calls)[0].Input["password"])
+	assert.Equal(t, "pass-2", (*calls)[1].Input["password"])
+
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	assert.ErrorContains(t, err, "vault unavailable")
+	assert.Len(t, *calls, 2, "no request is sent without credentials")
+}
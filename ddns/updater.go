@@ -0,0 +1,251 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ddns keeps A and AAAA records of a reg.ru zone pointed at the
+// public addresses of the host, for home-lab and edge deployments without a
+// static IP. Addresses are found by pluggable detectors and applied with
+// regru.Client.EnsureRR.
+package ddns
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+const (
+	// DefaultInterval is the delay between address checks.
+	DefaultInterval = 5 * time.Minute
+	// DefaultIPv4URL is the service used by the default IPv4 detector.
+	DefaultIPv4URL = "https://api.ipify.org"
+	// DefaultIPv6URL is the service used by WithIPv6.
+	DefaultIPv6URL = "https://api6.ipify.org"
+)
+
+// Client is the subset of regru.Client methods used by the updater.
+type Client interface {
+	EnsureRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.EnsureResult, error)
+}
+
+// Detector finds a public address of the host.
+type Detector interface {
+	Detect(ctx context.Context) (net.IP, error)
+}
+
+// DetectorFunc adapts a function to the Detector interface.
+type DetectorFunc func(ctx context.Context) (net.IP, error)
+
+// Detect calls f(ctx).
+func (f DetectorFunc) Detect(ctx context.Context) (net.IP, error) {
+	return f(ctx)
+}
+
+// HTTPDetector returns a detector that fetches the URL and parses the response
+// body as an IP address, as served by ipify, icanhazip and similar services.
+func HTTPDetector(url string) Detector {
+	return DetectorFunc(func(ctx context.Context) (net.IP, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, &regru.HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+		}
+
+		ip := net.ParseIP(strings.TrimSpace(string(body)))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address in response from %s", url)
+		}
+		return ip, nil
+	})
+}
+
+// Updater keeps the records of one name in sync with the detected addresses.
+type Updater struct {
+	client   Client
+	zone     string
+	name     string
+	ipv4     Detector
+	ipv6     Detector
+	interval time.Duration
+	ttl      int
+	onChange func(regru.EnsureResult)
+	onError  func(error)
+
+	// last holds the address last applied per record type
+	last map[regru.RecordType]string
+}
+
+// Option represents an option for configuring the updater.
+type Option func(*Updater)
+
+// WithIPv4Detector sets the detector used for the A record. A nil detector
+// disables IPv4 updates.
+func WithIPv4Detector(d Detector) Option {
+	return func(u *Updater) {
+		u.ipv4 = d
+	}
+}
+
+// WithIPv6Detector sets the detector used for the AAAA record. IPv6 updates
+// are disabled by default.
+func WithIPv6Detector(d Detector) Option {
+	return func(u *Updater) {
+		u.ipv6 = d
+	}
+}
+
+// WithIPv6 enables AAAA updates using the default IPv6 detector.
+func WithIPv6() Option {
+	return WithIPv6Detector(HTTPDetector(DefaultIPv6URL))
+}
+
+// WithInterval sets the delay between address checks in Run. Intervals that
+// are not positive are ignored and DefaultInterval is used.
+func WithInterval(interval time.Duration) Option {
+	return func(u *Updater) {
+		if interval > 0 {
+			u.interval = interval
+		}
+	}
+}
+
+// WithTTL sets the TTL in seconds of the managed records.
+func WithTTL(ttl int) Option {
+	return func(u *Updater) {
+		u.ttl = ttl
+	}
+}
+
+// WithOnChange sets a callback invoked whenever a record was created or replaced.
+func WithOnChange(fn func(regru.EnsureResult)) Option {
+	return func(u *Updater) {
+		u.onChange = fn
+	}
+}
+
+// WithErrorHandler sets a callback invoked with errors that occur in Run.
+func WithErrorHandler(fn func(error)) Option {
+	return func(u *Updater) {
+		u.onError = fn
+	}
+}
+
+// NewUpdater creates an updater for the record name in zone. By default only
+// the A record is managed, using the public IPv4 address reported by DefaultIPv4URL.
+func NewUpdater(client Client, zone, name string, opts ...Option) *Updater {
+	u := &Updater{
+		client:   client,
+		zone:     zone,
+		name:     name,
+		ipv4:     HTTPDetector(DefaultIPv4URL),
+		interval: DefaultInterval,
+		last:     make(map[regru.RecordType]string),
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// Update detects the current addresses and ensures the records point at them.
+// Addresses that did not change since the last successful update are not
+// sent to the API again. It returns the results of the EnsureRR calls made.
+func (u *Updater) Update(ctx context.Context) ([]regru.EnsureResult, error) {
+	var results []regru.EnsureResult
+
+	for _, target := range []struct {
+		recordType regru.RecordType
+		detector   Detector
+	}{
+		{regru.RecordTypeA, u.ipv4},
+		{regru.RecordTypeAAAA, u.ipv6},
+	} {
+		if target.detector == nil {
+			continue
+		}
+
+		ip, err := target.detector.Detect(ctx)
+		if err != nil {
+			return results, fmt.Errorf("failed to detect %s address: %w", target.recordType, err)
+		}
+		if (ip.To4() != nil) != (target.recordType == regru.RecordTypeA) {
+			return results, fmt.Errorf("detector returned %s for %s record", ip, target.recordType)
+		}
+
+		content := ip.String()
+		if u.last[target.recordType] == content {
+			continue
+		}
+
+		result, err := u.client.EnsureRR(ctx, u.zone, regru.CreateDNSRecordParams{
+			Name:    u.name,
+			Type:    target.recordType,
+			Content: content,
+			TTL:     u.ttl,
+		})
+		if err != nil {
+			return results, err
+		}
+		u.last[target.recordType] = content
+		results = append(results, result)
+
+		if result.Action != regru.EnsureActionNone && u.onChange != nil {
+			u.onChange(result)
+		}
+	}
+
+	return results, nil
+}
+
+// Run calls Update immediately and then on every interval until the context
+// is done. Errors are passed to the error handler and do not stop the loop.
+func (u *Updater) Run(ctx context.Context) error {
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.Update(ctx); err != nil && u.onError != nil && ctx.Err() == nil {
+			u.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ddns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records EnsureRR calls and reports a change when the content differs
+// from the previous call for the same type.
+type fakeClient struct {
+	calls   []regru.CreateDNSRecordParams
+	current map[regru.RecordType]string
+}
+
+func (f *fakeClient) EnsureRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.EnsureResult, error) {
+	f.calls = append(f.calls, params)
+	record := regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content}
+	if f.current[params.Type] == params.Content {
+		return regru.EnsureResult{Action: regru.EnsureActionNone, Record: record}, nil
+	}
+	f.current[params.Type] = params.Content
+	return regru.EnsureResult{Action: regru.EnsureActionReplaced, Record: record}, nil
+}
+
+func staticDetector(ip string) Detector {
+	return DetectorFunc(func(ctx context.Context) (net.IP, error) {
+		return net.ParseIP(ip), nil
+	})
+}
+
+func TestUpdater_Update(t *testing.T) {
+	client := &fakeClient{current: map[regru.RecordType]string{regru.RecordTypeA: "192.0.2.1"}}
+	ipv4 := "192.0.2.1"
+
+	var changes []regru.EnsureResult
+	updater := NewUpdater(client, "example.com", "home",
+		WithIPv4Detector(DetectorFunc(func(ctx context.Context) (net.IP, error) {
+			return net.ParseIP(ipv4), nil
+		})),
+		WithIPv6Detector(staticDetector("2001:db8::1")),
+		WithTTL(60),
+		WithOnChange(func(result regru.EnsureResult) { changes = append(changes, result) }),
+	)
+
+	results, err := updater.Update(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, regru.EnsureActionNone, results[0].Action)
+	assert.Equal(t, regru.EnsureActionReplaced, results[1].Action)
+	require.Len(t, changes, 1)
+	assert.Equal(t, regru.RecordTypeAAAA, changes[0].Record.Type)
+	assert.Equal(t, 60, client.calls[0].TTL)
+
+	// Unchanged addresses are not sent again
+	results, err = updater.Update(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Len(t, client.calls, 2)
+
+	ipv4 = "192.0.2.2"
+	results, err = updater.Update(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "192.0.2.2", client.calls[2].Content)
+	assert.Len(t, changes, 2)
+}
+
+func TestUpdater_Update_Errors(t *testing.T) {
+	client := &fakeClient{current: map[regru.RecordType]string{}}
+
+	failing := NewUpdater(client, "example.com", "home", WithIPv4Detector(DetectorFunc(func(ctx context.Context) (net.IP, error) {
+		return nil, errors.New("offline")
+	})))
+	_, err := failing.Update(context.Background())
+	assert.ErrorContains(t, err, "offline")
+
+	wrongFamily := NewUpdater(client, "example.com", "home", WithIPv4Detector(staticDetector("2001:db8::1")))
+	_, err = wrongFamily.Update(context.Background())
+	assert.Error(t, err)
+	assert.Empty(t, client.calls)
+}
+
+func TestHTTPDetector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("198.51.100.7\n"))
+	}))
+	defer server.Close()
+
+	ip, err := HTTPDetector(server.URL).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.7", ip.String())
+}
+
+func TestHTTPDetector_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("<html>"))
+	}))
+	defer server.Close()
+
+	_, err := HTTPDetector(server.URL + "/down").Detect(context.Background())
+	var httpErr *regru.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+
+	_, err = HTTPDetector(server.URL).Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestWithInterval(t *testing.T) {
+	u := NewUpdater(&fakeClient{}, "example.com", "home", WithInterval(time.Minute))
+	assert.Equal(t, time.Minute, u.interval)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		u = NewUpdater(&fakeClient{}, "example.com", "home", WithInterval(interval))
+		assert.Equal(t, DefaultInterval, u.interval, interval)
+	}
+}
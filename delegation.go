@@ -0,0 +1,275 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// DelegationOptions configures CheckDelegation.
+type DelegationOptions struct {
+	// ParentNameservers to query as "host" or "host:port". If empty, the
+	// nameservers of the closest parent zone are looked up via the system resolver.
+	ParentNameservers []string
+	// Addresses maps nameserver hostnames to "host:port" addresses used to
+	// query them instead of resolving the names.
+	Addresses map[string]string
+}
+
+// NameserverCheck is the result of querying a delegated nameserver for the zone.
+type NameserverCheck struct {
+	Host string `json:"host"`
+	// Authoritative is true if the server answered the SOA query for the zone authoritatively.
+	Authoritative bool   `json:"authoritative"`
+	Error         string `json:"error,omitempty"`
+}
+
+// DelegationFinding is a problem reported by CheckDelegation.
+type DelegationFinding struct {
+	// Check is the area of the finding: "parent", "registrar", "zone" or "lame".
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// DelegationReport is the delegation state of a zone.
+type DelegationReport struct {
+	Zone string `json:"zone"`
+	// Parent is the parent zone whose servers were queried.
+	Parent string `json:"parent,omitempty"`
+	// ParentNS is the NS set delegated by the parent zone servers.
+	ParentNS []string `json:"parent_ns,omitempty"`
+	// RegistrarNS is the NS set registered at reg.ru.
+	RegistrarNS []string `json:"registrar_ns,omitempty"`
+	// ZoneNS is the NS set published at the zone apex.
+	ZoneNS      []string            `json:"zone_ns,omitempty"`
+	Nameservers []NameserverCheck   `json:"nameservers,omitempty"`
+	Findings    []DelegationFinding `json:"findings,omitempty"`
+}
+
+// MaxSeverity returns the highest severity among the findings, or "" if there are none.
+func (r DelegationReport) MaxSeverity() Severity {
+	var max Severity
+	for _, f := range r.Findings {
+		if severityRank(f.Severity) > severityRank(max) {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// CheckDelegation queries the parent zone servers for the NS set of the zone
+// and compares it with the nameservers registered at reg.ru and the apex NS
+// records of the zone. Every delegated nameserver is asked for the zone SOA;
+// servers that do not answer authoritatively are reported as lame.
+// Problems are returned as findings; an error is returned only if the zone
+// records or the parent nameservers cannot be obtained.
+func (c *Client) CheckDelegation(ctx context.Context, zone string, opts DelegationOptions) (DelegationReport, error) {
+	report := DelegationReport{Zone: zone}
+	add := func(check string, severity Severity, format string, args ...interface{}) {
+		report.Findings = append(report.Findings, DelegationFinding{
+			Check:    check,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	origin := canonicalHost(zone)
+	fqdn := dns.Fqdn(origin)
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return report, err
+	}
+	for _, rr := range records {
+		if r := Canonicalize(rr); r.Type == RecordTypeNS && r.Name == "@" {
+			report.ZoneNS = append(report.ZoneNS, canonicalHost(r.Content))
+		}
+	}
+	report.ZoneNS = uniqueSorted(report.ZoneNS)
+
+	if nss, err := c.GetNameservers(ctx, zone); err != nil {
+		add("registrar", SeverityWarning, "cannot get the registered nameservers: %v", err)
+	} else {
+		report.RegistrarNS = uniqueSorted(nss)
+	}
+
+	parent, parentServers, err := parentNameservers(ctx, origin, opts.ParentNameservers)
+	if err != nil {
+		return report, err
+	}
+	report.Parent = parent
+
+	// Ask every parent server for the delegation
+	var sets [][]string
+	for _, server := range parentServers {
+		delegated, err := queryDelegation(ctx, server, fqdn)
+		if err != nil {
+			add("parent", SeverityWarning, "parent server %s did not answer: %v", server, err)
+			continue
+		}
+		sets = append(sets, delegated)
+		report.ParentNS = uniqueSorted(append(report.ParentNS, delegated...))
+	}
+	for _, set := range sets {
+		if !sameHosts(set, report.ParentNS) {
+			add("parent", SeverityWarning, "parent servers return different NS sets, the registry update may still be propagating")
+			break
+		}
+	}
+	if len(report.ParentNS) == 0 {
+		add("parent", SeverityCritical, "the parent zone %s does not delegate %s", parent, origin)
+		return report, nil
+	}
+
+	if report.RegistrarNS != nil && !sameHosts(report.RegistrarNS, report.ParentNS) {
+		add("registrar", SeverityCritical, "the parent zone delegates to %s, but %s are registered at reg.ru",
+			strings.Join(report.ParentNS, ", "), strings.Join(report.RegistrarNS, ", "))
+	}
+	if len(report.ZoneNS) > 0 && !sameHosts(report.ZoneNS, report.ParentNS) {
+		add("zone", SeverityWarning, "the zone lists NS %s, but the parent zone delegates to %s",
+			strings.Join(report.ZoneNS, ", "), strings.Join(report.ParentNS, ", "))
+	}
+
+	// Check that every delegated server answers for the zone
+	lame := 0
+	for _, host := range report.ParentNS {
+		check := NameserverCheck{Host: host}
+		addr, ok := opts.Addresses[host]
+		if !ok {
+			addr = net.JoinHostPort(host, "53")
+		}
+		check.Authoritative, err = queryAuthoritative(ctx, addr, fqdn)
+		if err != nil {
+			check.Error = err.Error()
+		}
+		if !check.Authoritative {
+			lame++
+		}
+		report.Nameservers = append(report.Nameservers, check)
+	}
+	switch {
+	case lame == len(report.ParentNS):
+		add("lame", SeverityCritical, "none of the delegated nameservers answer authoritatively for %s", origin)
+	case lame > 0:
+		for _, check := range report.Nameservers {
+			if !check.Authoritative {
+				add("lame", SeverityWarning, "nameserver %s does not answer authoritatively for %s (partial delegation)", check.Host, origin)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// parentNameservers returns the parent zone and the addresses of its nameservers.
+func parentNameservers(ctx context.Context, origin string, configured []string) (string, []string, error) {
+	_, parent, _ := strings.Cut(origin, ".")
+	if len(configured) > 0 {
+		servers, err := waitNameservers(ctx, parent, configured)
+		return parent, servers, err
+	}
+
+	// Walk up to the closest enclosing zone with nameservers
+	for candidate := parent; candidate != ""; _, candidate, _ = strings.Cut(candidate, ".") {
+		if servers, err := waitNameservers(ctx, candidate, nil); err == nil && len(servers) > 0 {
+			return candidate, servers, nil
+		}
+	}
+	return parent, nil, fmt.Errorf("no nameservers found for the parent zones of %s", origin)
+}
+
+// queryDelegation asks a parent server for the NS records of the zone and
+// returns the delegated hosts from the answer or the referral.
+func queryDelegation(ctx context.Context, server, fqdn string) ([]string, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(fqdn, dns.TypeNS)
+	m.RecursionDesired = false
+
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, m, server)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("%s", dns.RcodeToString[resp.Rcode])
+	}
+
+	var hosts []string
+	for _, rr := range append(append([]dns.RR(nil), resp.Answer...), resp.Ns...) {
+		if ns, ok := rr.(*dns.NS); ok && strings.EqualFold(ns.Hdr.Name, fqdn) {
+			hosts = append(hosts, canonicalHost(ns.Ns))
+		}
+	}
+	return uniqueSorted(hosts), nil
+}
+
+// queryAuthoritative reports whether the server answers the SOA query for the zone authoritatively.
+func queryAuthoritative(ctx context.Context, server, fqdn string) (bool, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(fqdn, dns.TypeSOA)
+	m.RecursionDesired = false
+
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, m, server)
+	if err != nil {
+		return false, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return false, fmt.Errorf("%s", dns.RcodeToString[resp.Rcode])
+	}
+	if !resp.Authoritative {
+		return false, nil
+	}
+	for _, rr := range resp.Answer {
+		if _, ok := rr.(*dns.SOA); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// uniqueSorted returns the hostnames sorted without duplicates.
+func uniqueSorted(hosts []string) []string {
+	if len(hosts) == 0 {
+		return hosts
+	}
+	sorted := append([]string(nil), hosts...)
+	sort.Strings(sorted)
+	result := sorted[:1]
+	for _, h := range sorted[1:] {
+		if h != result[len(result)-1] {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// sameHosts reports whether two hostname sets are equal, ignoring case and order.
+func sameHosts(a, b []string) bool {
+	normalize := func(hosts []string) []string {
+		result := make([]string, 0, len(hosts))
+		for _, h := range hosts {
+			result = append(result, canonicalHost(h))
+		}
+		return uniqueSorted(result)
+	}
+	return strings.Join(normalize(a), " ") == strings.Join(normalize(b), " ")
+}
@@ -0,0 +1,192 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startDNSServer starts a local UDP DNS server with the handler and returns its address.
+func startDNSServer(t *testing.T, handler dns.HandlerFunc) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{PacketConn: conn, Handler: handler}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+// startParentServer starts a server returning a referral for example.com to the hosts.
+func startParentServer(t *testing.T, hosts ...string) string {
+	return startDNSServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		for _, host := range hosts {
+			rr, _ := dns.NewRR("example.com. 3600 IN NS " + host + ".")
+			m.Ns = append(m.Ns, rr)
+		}
+		_ = w.WriteMsg(m)
+	})
+}
+
+// startChildServer starts a server answering the example.com SOA query, authoritatively or not.
+func startChildServer(t *testing.T, authoritative bool) string {
+	return startDNSServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Authoritative = authoritative
+		if authoritative && req.Question[0].Qtype == dns.TypeSOA {
+			rr, _ := dns.NewRR("example.com. 3600 IN SOA ns1.hosting.net. admin.example.com. 1 3600 600 86400 300")
+			m.Answer = append(m.Answer, rr)
+		}
+		_ = w.WriteMsg(m)
+	})
+}
+
+func TestClient_CheckDelegation(t *testing.T) {
+	parent := startParentServer(t, "ns1.hosting.net", "ns2.hosting.net")
+	addresses := map[string]string{
+		"ns1.hosting.net": startChildServer(t, true),
+		"ns2.hosting.net": startChildServer(t, true),
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		server, calls := setupRoutedTestServer(t, map[string]interface{}{
+			"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+				Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+					DName: "example.com",
+					RRList: []ResourceRecord{
+						{Subname: "@", Rectype: "NS", Content: "ns1.hosting.net."},
+						{Subname: "@", Rectype: "NS", Content: "NS2.hosting.net"},
+						{Subname: "www", Rectype: "A", Content: "192.0.2.1"},
+					},
+				}}},
+			},
+			"domain/get_nss": DomainGetNSSResponse{
+				Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+					DName:  "example.com",
+					Result: "success",
+					NSS:    []NameserverInfo{{NS: "ns2.hosting.net"}, {NS: "ns1.hosting.net"}},
+				}}},
+			},
+		})
+		defer server.Close()
+
+		client := setupTestClient(t, server)
+		report, err := client.CheckDelegation(context.Background(), "example.com", DelegationOptions{
+			ParentNameservers: []string{parent},
+			Addresses:         addresses,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "com", report.Parent)
+		assert.Equal(t, []string{"ns1.hosting.net", "ns2.hosting.net"}, report.ParentNS)
+		assert.Equal(t, []string{"ns1.hosting.net", "ns2.hosting.net"}, report.RegistrarNS)
+		assert.Equal(t, []string{"ns1.hosting.net", "ns2.hosting.net"}, report.ZoneNS)
+		require.Len(t, report.Nameservers, 2)
+		assert.True(t, report.Nameservers[0].Authoritative)
+		assert.True(t, report.Nameservers[1].Authoritative)
+		assert.Empty(t, report.Findings)
+		assert.Equal(t, Severity(""), report.MaxSeverity())
+		assert.Len(t, *calls, 2)
+	})
+
+	t.Run("lame and mismatched", func(t *testing.T) {
+		server, _ := setupRoutedTestServer(t, map[string]interface{}{
+			"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+				Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+					DName:  "example.com",
+					RRList: []ResourceRecord{{Subname: "@", Rectype: "NS", Content: "ns1.hosting.net"}},
+				}}},
+			},
+			"domain/get_nss": DomainGetNSSResponse{
+				Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+					DName:  "example.com",
+					Result: "success",
+					NSS:    []NameserverInfo{{NS: "ns1.hosting.net"}, {NS: "ns3.hosting.net"}},
+				}}},
+			},
+		})
+		defer server.Close()
+
+		client := setupTestClient(t, server)
+		report, err := client.CheckDelegation(context.Background(), "example.com", DelegationOptions{
+			ParentNameservers: []string{parent},
+			Addresses: map[string]string{
+				"ns1.hosting.net": addresses["ns1.hosting.net"],
+				"ns2.hosting.net": startChildServer(t, false),
+			},
+		})
+		require.NoError(t, err)
+
+		checks := make(map[string]Severity)
+		for _, f := range report.Findings {
+			checks[f.Check] = f.Severity
+		}
+		assert.Equal(t, map[string]Severity{
+			"registrar": SeverityCritical,
+			"zone":      SeverityWarning,
+			"lame":      SeverityWarning,
+		}, checks)
+		assert.Equal(t, SeverityCritical, report.MaxSeverity())
+		require.Len(t, report.Nameservers, 2)
+		assert.False(t, report.Nameservers[1].Authoritative)
+	})
+
+	t.Run("not delegated", func(t *testing.T) {
+		server, _ := setupRoutedTestServer(t, map[string]interface{}{
+			"domain/get_nss": DomainGetNSSResponse{
+				Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+					DName:  "example.com",
+					Result: "success",
+					NSS:    []NameserverInfo{{NS: "ns1.hosting.net"}},
+				}}},
+			},
+		})
+		defer server.Close()
+
+		client := setupTestClient(t, server)
+		report, err := client.CheckDelegation(context.Background(), "example.com", DelegationOptions{
+			ParentNameservers: []string{startParentServer(t)},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "parent", report.Findings[0].Check)
+		assert.Equal(t, SeverityCritical, report.Findings[0].Severity)
+		assert.Empty(t, report.Nameservers)
+	})
+}
+
+func TestSameHosts(t *testing.T) {
+	assert.True(t, sameHosts([]string{"NS1.example.net.", "ns2.example.net"}, []string{"ns2.example.net", "ns1.example.net"}))
+	assert.False(t, sameHosts([]string{"ns1.example.net"}, []string{"ns1.example.net", "ns2.example.net"}))
+}
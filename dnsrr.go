@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// FromDNSRR converts a DNS resource record into a DNSRecord with the name
+// relative to zone. Only record types supported by the client are converted;
+// others return an UnsupportedRecordTypeError.
+func FromDNSRR(rr dns.RR, zone string) (DNSRecord, error) {
+	record := DNSRecord{
+		Name: relativeName(strings.ToLower(dns.Fqdn(rr.Header().Name)), zone),
+		TTL:  int(rr.Header().Ttl),
+	}
+
+	switch v := rr.(type) {
+	case *dns.A:
+		record.Type = RecordTypeA
+		record.Content = v.A.String()
+	case *dns.AAAA:
+		record.Type = RecordTypeAAAA
+		record.Content = v.AAAA.String()
+	case *dns.CNAME:
+		record.Type = RecordTypeCNAME
+		record.Content = v.Target
+	case *dns.NS:
+		record.Type = RecordTypeNS
+		record.Content = v.Ns
+	case *dns.PTR:
+		record.Type = RecordTypePTR
+		record.Content = v.Ptr
+	case *dns.MX:
+		record.Type = RecordTypeMX
+		record.Content = fmt.Sprintf("%d %s", v.Preference, v.Mx)
+	case *dns.SRV:
+		record.Type = RecordTypeSRV
+		record.Content = fmt.Sprintf("%d %d %d %s", v.Priority, v.Weight, v.Port, v.Target)
+	case *dns.TXT:
+		record.Type = RecordTypeTXT
+		record.Content = strings.Join(v.Txt, "")
+	case *dns.SPF:
+		record.Type = RecordTypeSPF
+		record.Content = strings.Join(v.Txt, "")
+	case *dns.CAA:
+		record.Type = RecordTypeCAA
+		record.Content = CAAContent(int(v.Flag), v.Tag, v.Value)
+	case *dns.LOC:
+		record.Type = RecordTypeLOC
+		record.Content = locRData(v)
+	case *dns.SSHFP:
+		record.Type = RecordTypeSSHFP
+		record.Content = SSHFPContent(int(v.Algorithm), int(v.Type), v.FingerPrint)
+	default:
+		return DNSRecord{}, &UnsupportedRecordTypeError{RecordType: dns.TypeToString[rr.Header().Rrtype]}
+	}
+
+	return record, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromDNSRR(t *testing.T) {
+	tests := []struct {
+		rr   string
+		want DNSRecord
+	}{
+		{"example.com. 300 IN A 192.0.2.1", DNSRecord{Name: "@", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300}},
+		{"WWW.example.com. 60 IN CNAME example.com.", DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "example.com.", TTL: 60}},
+		{"example.com. 300 IN MX 10 mail.example.com.", DNSRecord{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com.", TTL: 300}},
+		{"_sip._tcp.example.com. 300 IN SRV 10 5 5060 sip.example.com.", DNSRecord{Name: "_sip._tcp", Type: RecordTypeSRV, Content: "10 5 5060 sip.example.com.", TTL: 300}},
+		{`txt.example.com. 300 IN TXT "part1" "part2"`, DNSRecord{Name: "txt", Type: RecordTypeTXT, Content: "part1part2", TTL: 300}},
+		{`example.com. 300 IN CAA 0 issue "letsencrypt.org"`, DNSRecord{Name: "@", Type: RecordTypeCAA, Content: `0 issue "letsencrypt.org"`, TTL: 300}},
+		{`example.com. 300 IN SPF "v=spf1 " "-all"`, DNSRecord{Name: "@", Type: RecordTypeSPF, Content: "v=spf1 -all", TTL: 300}},
+		{"office.example.com. 300 IN LOC 55 45 21 N 37 37 4 E 150m", DNSRecord{Name: "office", Type: RecordTypeLOC, Content: "55 45 21.000 N 37 37 4.000 E 150m 1m 10000m 10m", TTL: 300}},
+		{"host.example.com. 300 IN SSHFP 4 2 66402C9468C58941DD19FFD650BF2B42F9226F83D3BD06AD515D0E5104A77020", DNSRecord{Name: "host", Type: RecordTypeSSHFP, Content: "4 2 66402c9468c58941dd19ffd650bf2b42f9226f83d3bd06ad515d0e5104a77020", TTL: 300}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rr, func(t *testing.T) {
+			rr, err := dns.NewRR(tt.rr)
+			require.NoError(t, err)
+
+			got, err := FromDNSRR(rr, "example.com.")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromDNSRR_Unsupported(t *testing.T) {
+	rr, err := dns.NewRR("example.com. 300 IN HINFO \"x86\" \"Linux\"")
+	require.NoError(t, err)
+
+	_, err = FromDNSRR(rr, "example.com")
+	assert.ErrorIs(t, err, ErrUnsupportedRecordType)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// apiDateLayout is the date format used by reg.ru API.
+const apiDateLayout = "2006-01-02"
+
+// DomainsService groups the domain registration methods of the API.
+type DomainsService struct {
+	client *Client
+}
+
+// List returns the domains registered in the account.
+func (s *DomainsService) List(ctx context.Context) ([]Domain, error) {
+	apiReq := ServiceListRequest{
+		PageSize: 1000,
+		ServType: "domain",
+	}
+
+	resp, err := Call[ServiceListResponse](ctx, s.client, "service/get_list", &apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainsFromServices(resp.Answer.Services), nil
+}
+
+// ListByFolder returns the domains in a folder of the account.
+func (s *DomainsService) ListByFolder(ctx context.Context, folder string) ([]Domain, error) {
+	apiReq := FolderGetServicesRequest{FolderName: folder}
+
+	resp, err := Call[ServiceListResponse](ctx, s.client, "folder/get_services", &apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainsFromServices(resp.Answer.Services), nil
+}
+
+// domainsFromServices converts the domain services of a list into domains.
+func domainsFromServices(services []Service) []Domain {
+	var domains []Domain
+	for _, service := range services {
+		if service.GetServiceType() != "domain" {
+			continue
+		}
+		domains = append(domains, domainFromService(service))
+	}
+	return domains
+}
+
+// domainFromService converts a service into a Domain.
+func domainFromService(service Service) Domain {
+	return Domain{
+		Name:           service.GetDomain(),
+		ServiceID:      service.GetServiceID(),
+		State:          service.State,
+		CreationDate:   parseAPIDate(service.CreationDate),
+		ExpirationDate: parseAPIDate(service.ExpirationDate),
+	}
+}
+
+// GetPrices returns the registration and renewal prices of all TLDs.
+func (s *DomainsService) GetPrices(ctx context.Context) (PriceList, error) {
+	apiReq := DomainGetPricesRequest{ShowRenewData: 1}
+
+	resp, err := Call[DomainGetPricesResponse](ctx, s.client, "domain/get_prices", &apiReq)
+	if err != nil {
+		return PriceList{}, err
+	}
+
+	prices := PriceList{
+		Currency: resp.Answer.Currency,
+		Prices:   make(map[string]DomainPrice, len(resp.Answer.Prices)),
+	}
+	for tld, price := range resp.Answer.Prices {
+		tld = strings.ToLower(strings.Trim(tld, "."))
+		prices.Prices[tld] = DomainPrice{
+			TLD:      tld,
+			Register: parseAmount(price.RegPrice),
+			Renew:    parseAmount(price.RenewPrice),
+		}
+	}
+	return prices, nil
+}
+
+// GetNameservers returns the nameservers delegated for the domain at the registry,
+// as lowercase hostnames without a trailing dot.
+func (s *DomainsService) GetNameservers(ctx context.Context, domain string) ([]string, error) {
+	apiReq := DomainGetNSSRequest{
+		Domains: []ZoneGetResourceRecordsDomain{{DName: s.client.asciiName(domain)}},
+	}
+
+	resp, err := Call[DomainGetNSSResponse](ctx, s.client, "domain/get_nss", &apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Answer.Domains) == 0 {
+		return nil, &ZoneNotFoundError{ZoneID: domain}
+	}
+	result := resp.Answer.Domains[0]
+	if result.Result != "" && result.Result != "success" {
+		return nil, newAPIError(result.ErrorCode, result.ErrorText)
+	}
+
+	nameservers := make([]string, 0, len(result.NSS))
+	for _, ns := range result.NSS {
+		if ns.NS != "" {
+			nameservers = append(nameservers, canonicalHost(ns.NS))
+		}
+	}
+	return nameservers, nil
+}
+
+// Get returns the registration details of a domain in the account.
+func (s *DomainsService) Get(ctx context.Context, domain string) (Domain, error) {
+	apiReq := ServiceGetInfoRequest{
+		Services: []ServiceRef{{DName: s.client.asciiName(domain)}},
+	}
+
+	resp, err := Call[ServiceListResponse](ctx, s.client, "service/get_info", &apiReq)
+	if err != nil {
+		return Domain{}, err
+	}
+
+	if len(resp.Answer.Services) == 0 {
+		return Domain{}, &ZoneNotFoundError{ZoneID: domain}
+	}
+	result := domainFromService(resp.Answer.Services[0])
+	if result.Name == "" {
+		result.Name = domain
+	}
+	return result, nil
+}
+
+// Register orders the registration of a domain. When the balance is
+// insufficient, an unpaid bill is issued instead of failing.
+func (s *DomainsService) Register(ctx context.Context, order DomainOrder) (Order, error) {
+	apiReq := DomainCreateRequest{
+		DomainName:  s.client.asciiName(order.Name),
+		Period:      order.Period,
+		Contacts:    order.Contacts,
+		OKIfNoMoney: 1,
+	}
+	if apiReq.Period <= 0 {
+		apiReq.Period = 1
+	}
+	if len(order.Nameservers) > 0 {
+		apiReq.NSS = make(map[string]string, len(order.Nameservers))
+		for i, ns := range order.Nameservers {
+			apiReq.NSS[fmt.Sprintf("ns%d", i)] = ns
+		}
+	}
+
+	resp, err := Call[OrderResponse](ctx, s.client, "domain/create", &apiReq)
+	if err != nil {
+		return Order{}, err
+	}
+	return orderFromAnswer(resp.Answer, order.Name), nil
+}
+
+// Renew orders the renewal of a domain for the period in years (1 if zero).
+// When the balance is insufficient, an unpaid bill is issued instead of failing.
+func (s *DomainsService) Renew(ctx context.Context, domain string, period int) (Order, error) {
+	if period <= 0 {
+		period = 1
+	}
+	apiReq := ServiceRenewRequest{
+		DName:       s.client.asciiName(domain),
+		Period:      period,
+		OKIfNoMoney: 1,
+	}
+
+	resp, err := Call[OrderResponse](ctx, s.client, "service/renew", &apiReq)
+	if err != nil {
+		return Order{}, err
+	}
+	return orderFromAnswer(resp.Answer, domain), nil
+}
+
+// orderFromAnswer converts an order answer into an Order.
+func orderFromAnswer(answer OrderAnswer, domain string) Order {
+	if answer.DName != "" {
+		domain = answer.DName
+	}
+	return Order{
+		Domain:    domain,
+		ServiceID: answer.GetServiceID(),
+		BillID:    answer.GetBillID(),
+		Payment:   parseAmount(answer.Payment),
+		Currency:  answer.Currency,
+		Status:    answer.Status,
+	}
+}
+
+// parseAPIDate parses a date returned by the API, returning the zero time for
+// empty or malformed values.
+func parseAPIDate(value string) time.Time {
+	t, err := time.Parse(apiDateLayout, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"slices"
+)
+
+// EnsureAction describes what EnsureRR did.
+type EnsureAction string
+
+// EnsureRR actions
+const (
+	// EnsureActionNone means an identical record already existed.
+	EnsureActionNone EnsureAction = "none"
+	// EnsureActionCreated means the record was created.
+	EnsureActionCreated EnsureAction = "created"
+	// EnsureActionReplaced means conflicting records were removed and the record was created or kept.
+	EnsureActionReplaced EnsureAction = "replaced"
+)
+
+// EnsureResult describes the outcome of EnsureRR.
+type EnsureResult struct {
+	// Action is the action taken.
+	Action EnsureAction
+	// Record is the existing or created record.
+	Record DNSRecord
+	// Replaced contains the conflicting records that were removed.
+	Replaced []DNSRecord
+}
+
+// EnsureRR makes sure the record described by params exists. If a record with
+// the same name, type and content is present it is kept, unless params sets a
+// TTL that differs from the known TTL of that record (see
+// DNSRecord.TTLInherited and WithRecordIDs); otherwise the record is created.
+// In both cases all other records with the same name and type are removed.
+// Conflicting records are removed before the record is created, as with
+// UpdateRR, because a name can hold only one CNAME record and reg.ru rejects a
+// second one. Records of other types with the same name are left alone.
+func (c *Client) EnsureRR(ctx context.Context, zone string, params CreateDNSRecordParams) (EnsureResult, error) {
+	return c.ensureRR(ctx, zone, params, nil)
+}
+
+// ensureRR implements EnsureRR. If conflicts is not nil, only records with the
+// same name and type for which it returns true are replaced; others are kept.
+func (c *Client) ensureRR(ctx context.Context, zone string, params CreateDNSRecordParams, conflicts func(rr DNSRecord) bool) (EnsureResult, error) {
+	params, err := applyRecordData(params)
+	if err != nil {
+		return EnsureResult{}, err
+	}
+	rtype, err := checkRecordType(params.Type)
+	if err != nil {
+		return EnsureResult{}, err
+	}
+	params.Type = rtype
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return EnsureResult{}, err
+	}
+
+	desired := DNSRecord{
+		Name:        params.Name,
+		Type:        params.Type,
+		Content:     params.Content,
+		TTL:         params.TTL,
+		TTLDuration: params.TTLDuration,
+		Priority:    params.Priority,
+	}
+	filter := FilterByName(params.Name)
+	var (
+		current *DNSRecord
+		replace []DNSRecord
+	)
+	for _, record := range records {
+		if !filter.match(record) || record.Type != params.Type {
+			continue
+		}
+		if record.Equal(desired) && current == nil && !ttlDiffers(record, desired) {
+			current = &record
+			continue
+		}
+		if record.Equal(desired) || conflicts == nil || conflicts(record) {
+			replace = append(replace, record)
+		}
+	}
+	if current != nil {
+		// Copies of the kept record are left alone, since deleting them
+		// would delete the kept record too
+		replace = slices.DeleteFunc(replace, current.Equal)
+	}
+
+	result := EnsureResult{Action: EnsureActionCreated}
+	if current != nil {
+		result = EnsureResult{Action: EnsureActionNone, Record: *current}
+	}
+	for _, record := range replace {
+		if err := c.DeleteRR(ctx, zone, record); err != nil {
+			return result, err
+		}
+		result.Action = EnsureActionReplaced
+		result.Replaced = append(result.Replaced, record)
+	}
+	if current != nil {
+		return result, nil
+	}
+
+	result.Record, err = c.AddRR(ctx, zone, params)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_EnsureRR(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    CreateDNSRecordParams
+		action    EnsureAction
+		replaced  int
+		wantCalls []string
+	}{
+		{
+			name:      "identical record exists",
+			params:    CreateDNSRecordParams{Name: "WWW", Type: "a", Content: "192.0.2.2"},
+			action:    EnsureActionNone,
+			wantCalls: []string{"zone/get_resource_records"},
+		},
+		{
+			name:      "identical MX record with separate priority exists",
+			params:    CreateDNSRecordParams{Name: "@", Type: RecordTypeMX, Content: "mail.example.com", Priority: 10},
+			action:    EnsureActionNone,
+			wantCalls: []string{"zone/get_resource_records"},
+		},
+		{
+			name:      "TTL is ignored when the current TTL is unknown",
+			params:    CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.2", TTL: 300},
+			action:    EnsureActionNone,
+			wantCalls: []string{"zone/get_resource_records"},
+		},
+		{
+			name:      "missing record is created",
+			params:    CreateDNSRecordParams{Name: "api", Type: RecordTypeA, Content: "192.0.2.9"},
+			action:    EnsureActionCreated,
+			wantCalls: []string{"zone/get_resource_records", "zone/add_alias"},
+		},
+		{
+			name:      "conflicting record is replaced",
+			params:    CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.9"},
+			action:    EnsureActionReplaced,
+			replaced:  1,
+			wantCalls: []string{"zone/get_resource_records", "zone/remove_record", "zone/add_alias"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := setupRoutedTestServer(t, map[string]interface{}{
+				"zone/get_resource_records": testZoneRecords(),
+			})
+			defer server.Close()
+
+			client := setupTestClient(t, server)
+
+			result, err := client.EnsureRR(context.Background(), "example.com", tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.action, result.Action)
+			assert.Len(t, result.Replaced, tt.replaced)
+
+			var paths []string
+			for _, call := range *calls {
+				paths = append(paths, call.Path)
+			}
+			assert.Equal(t, tt.wantCalls, paths)
+		})
+	}
+}
+
+func TestClient_EnsureRR_ExistingRecordOrder(t *testing.T) {
+	identical := ResourceRecord{Subname: "www", Rectype: "A", Content: "192.0.2.2"}
+	conflicting := ResourceRecord{Subname: "www", Rectype: "A", Content: "192.0.2.3"}
+
+	for _, order := range [][]ResourceRecord{{identical, conflicting}, {conflicting, identical}} {
+		records := testZoneRecords()
+		records.Answer.Domains[0].RRList = order
+		server, calls := setupRoutedTestServer(t, map[string]interface{}{
+			"zone/get_resource_records": records,
+		})
+
+		client := setupTestClient(t, server)
+
+		result, err := client.EnsureRR(context.Background(), "example.com",
+			CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"})
+		require.NoError(t, err)
+		assert.Equal(t, EnsureActionReplaced, result.Action)
+		assert.Equal(t, "192.0.2.2", result.Record.Content)
+		require.Len(t, result.Replaced, 1)
+		assert.Equal(t, "192.0.2.3", result.Replaced[0].Content)
+
+		var paths []string
+		for _, call := range *calls {
+			paths = append(paths, call.Path)
+		}
+		assert.Equal(t, []string{"zone/get_resource_records", "zone/remove_record"}, paths)
+		server.Close()
+	}
+}
+
+func TestClient_EnsureRR_TTLChanged(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].SOA = &SOAInfo{TTL: "1h"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+		"zone/get_ns": ZoneListResponse{
+			Answer: ZoneListAnswer{
+				Domains: []DomainWithRecords{
+					{
+						DName: "example.com",
+						NSList: []NSRecord{
+							{Subdomain: "www", Type: "A", Content: "192.0.2.2", TTL: 3600, DNSID: "101"},
+						},
+					},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	params := CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.2", TTL: 300}
+
+	// The zone TTL is not the TTL of the record, so it is not compared
+	result, err := setupTestClient(t, server).EnsureRR(context.Background(), "example.com", params)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionNone, result.Action)
+	assert.Len(t, *calls, 1)
+
+	client := setupTestClient(t, server, WithRecordIDs())
+	result, err = client.EnsureRR(context.Background(), "example.com", params)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionReplaced, result.Action)
+	require.Len(t, result.Replaced, 1)
+	assert.Equal(t, 3600, result.Replaced[0].TTL)
+	assert.Equal(t, 1, countCalls(*calls, "zone/remove_record"))
+	assert.Equal(t, 1, countCalls(*calls, "zone/add_alias"))
+
+	params.TTL = 3600
+	result, err = client.EnsureRR(context.Background(), "example.com", params)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionNone, result.Action)
+}
+
+func TestClient_EnsureRR_UnsupportedType(t *testing.T) {
+	client := NewClient("user", "pass")
+
+	_, err := client.EnsureRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: "HINFO"})
+	assert.ErrorIs(t, err, ErrUnsupportedRecordType)
+}
@@ -31,10 +31,45 @@ var (
 
 	// ErrZoneNotFound is returned when a zone is not found.
 	ErrZoneNotFound = errors.New("zone not found")
+
+	// ErrInvalidTTL is returned when a TTL value cannot be parsed.
+	ErrInvalidTTL = errors.New("invalid TTL")
+
+	// ErrInvalidZoneFile is returned when a zone file cannot be parsed.
+	ErrInvalidZoneFile = errors.New("invalid zone file")
+
+	// ErrProfileNotFound is returned when a configuration profile does not exist.
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// ErrWriteDenied is returned when the write policy blocks an operation.
+	ErrWriteDenied = errors.New("write denied by policy")
+
+	// ErrPlanRejected is returned when a plan is not confirmed for execution.
+	ErrPlanRejected = errors.New("plan rejected")
+
+	// ErrMissingTemplateVariable is returned when a template variable has no value.
+	ErrMissingTemplateVariable = errors.New("missing template variable")
+
+	// ErrInvalidEmailAuth is returned when an SPF, DKIM, DMARC or BIMI record is invalid.
+	ErrInvalidEmailAuth = errors.New("invalid email authentication record")
+
+	// ErrOwnedByOther is returned when records belong to another owner.
+	ErrOwnedByOther = errors.New("records owned by another owner")
+
+	// ErrIPRestricted is returned when the API rejects calls from the client IP address.
+	ErrIPRestricted = errors.New("API access denied from this IP address")
+
+	// ErrFlowFailed is returned when a registration or renewal flow does not complete.
+	ErrFlowFailed = errors.New("order flow failed")
+
+	// ErrInvalidRecord is returned when a record fails client-side validation.
+	ErrInvalidRecord = errors.New("invalid record")
 )
 
 // APIError represents an error returned by the reg.ru API.
 type APIError struct {
+	// Code is the API error code, such as "INVALID_AUTH", if the API returned one.
+	Code    string
 	Message string
 }
 
@@ -68,9 +103,14 @@ func (e *UnsupportedRecordTypeError) Is(target error) bool {
 // RecordNotFoundError represents an error when a record is not found.
 type RecordNotFoundError struct {
 	RecordName string
+	// RecordID is set instead of RecordName when a record was looked up by its dns_id.
+	RecordID string
 }
 
 func (e *RecordNotFoundError) Error() string {
+	if e.RecordName == "" && e.RecordID != "" {
+		return fmt.Sprintf("record not found: dns_id %s", e.RecordID)
+	}
 	return fmt.Sprintf("record not found: %s", e.RecordName)
 }
 
@@ -90,3 +130,158 @@ func (e *ZoneNotFoundError) Error() string {
 func (e *ZoneNotFoundError) Is(target error) bool {
 	return target == ErrZoneNotFound
 }
+
+// InvalidTTLError represents an error when a TTL value cannot be parsed.
+type InvalidTTLError struct {
+	Value string
+}
+
+func (e *InvalidTTLError) Error() string {
+	return fmt.Sprintf("invalid TTL: %q", e.Value)
+}
+
+func (e *InvalidTTLError) Is(target error) bool {
+	return target == ErrInvalidTTL
+}
+
+// ZoneFileError represents a syntax error in a zone file.
+type ZoneFileError struct {
+	Line    int
+	Message string
+}
+
+func (e *ZoneFileError) Error() string {
+	return fmt.Sprintf("invalid zone file: line %d: %s", e.Line, e.Message)
+}
+
+func (e *ZoneFileError) Is(target error) bool {
+	return target == ErrInvalidZoneFile
+}
+
+// ProfileNotFoundError represents an error when a configuration profile does not exist.
+type ProfileNotFoundError struct {
+	Name string
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("profile not found: %s", e.Name)
+}
+
+func (e *ProfileNotFoundError) Is(target error) bool {
+	return target == ErrProfileNotFound
+}
+
+// WriteDeniedError represents a write operation blocked by the write policy.
+type WriteDeniedError struct {
+	Method string
+	Err    error
+}
+
+func (e *WriteDeniedError) Error() string {
+	return fmt.Sprintf("write denied by policy: %s: %v", e.Method, e.Err)
+}
+
+func (e *WriteDeniedError) Is(target error) bool {
+	return target == ErrWriteDenied
+}
+
+func (e *WriteDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// TemplateVariableError represents a template variable without a value.
+type TemplateVariableError struct {
+	Template string
+	Variable string
+}
+
+func (e *TemplateVariableError) Error() string {
+	return fmt.Sprintf("template %s: missing variable %q", e.Template, e.Variable)
+}
+
+func (e *TemplateVariableError) Is(target error) bool {
+	return target == ErrMissingTemplateVariable
+}
+
+// EmailAuthError represents an invalid SPF, DKIM, DMARC or BIMI record.
+type EmailAuthError struct {
+	// Kind is "SPF", "DKIM", "DMARC" or "BIMI".
+	Kind    string
+	Message string
+}
+
+func (e *EmailAuthError) Error() string {
+	return fmt.Sprintf("invalid %s record: %s", e.Kind, e.Message)
+}
+
+func (e *EmailAuthError) Is(target error) bool {
+	return target == ErrInvalidEmailAuth
+}
+
+// OwnershipError represents records whose name and type are owned by another owner.
+type OwnershipError struct {
+	Name  string
+	Type  RecordType
+	Owner string
+}
+
+func (e *OwnershipError) Error() string {
+	return fmt.Sprintf("%s %s records are owned by %q", e.Name, e.Type, e.Owner)
+}
+
+func (e *OwnershipError) Is(target error) bool {
+	return target == ErrOwnedByOther
+}
+
+// IPRestrictedError represents an API call rejected because the client IP
+// address is not in the API access allowlist of the account.
+type IPRestrictedError struct {
+	Err *APIError
+}
+
+func (e *IPRestrictedError) Error() string {
+	return fmt.Sprintf("%v (add this IP address to the API allowlist in the reg.ru account settings)", e.Err)
+}
+
+func (e *IPRestrictedError) Is(target error) bool {
+	return target == ErrIPRestricted
+}
+
+func (e *IPRestrictedError) Unwrap() error {
+	return e.Err
+}
+
+// FlowError represents a registration or renewal flow that failed at a step.
+type FlowError struct {
+	Step FlowStep
+	Err  error
+}
+
+func (e *FlowError) Error() string {
+	return fmt.Sprintf("order flow failed at %s step: %v", e.Step, e.Err)
+}
+
+func (e *FlowError) Is(target error) bool {
+	return target == ErrFlowFailed
+}
+
+func (e *FlowError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError represents a record that failed client-side validation.
+type ValidationError struct {
+	Name string
+	Type RecordType
+	// Field is the invalid field: "name", "content" or "ttl".
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s record %q: %s", e.Type, e.Name, e.Message)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRecord
+}
@@ -74,3 +74,43 @@ func TestZoneNotFoundError(t *testing.T) {
 	require.True(t, errors.As(err, &notFoundErr), "errors.As() should work with ZoneNotFoundError")
 	assert.Equal(t, "12345", notFoundErr.ZoneID)
 }
+
+func TestInvalidTTLError(t *testing.T) {
+	err := &InvalidTTLError{Value: "1y"}
+	assert.NotEmpty(t, err.Error(), "InvalidTTLError.Error() should not return empty string")
+	assert.True(t, errors.Is(err, ErrInvalidTTL), "InvalidTTLError should be checkable with errors.Is()")
+
+	var ttlErr *InvalidTTLError
+	require.True(t, errors.As(err, &ttlErr), "errors.As() should work with InvalidTTLError")
+	assert.Equal(t, "1y", ttlErr.Value)
+}
+
+func TestZoneFileError(t *testing.T) {
+	err := &ZoneFileError{Line: 3, Message: "missing record type"}
+	assert.NotEmpty(t, err.Error(), "ZoneFileError.Error() should not return empty string")
+	assert.True(t, errors.Is(err, ErrInvalidZoneFile), "ZoneFileError should be checkable with errors.Is()")
+
+	var zoneFileErr *ZoneFileError
+	require.True(t, errors.As(err, &zoneFileErr), "errors.As() should work with ZoneFileError")
+	assert.Equal(t, 3, zoneFileErr.Line)
+}
+
+func TestProfileNotFoundError(t *testing.T) {
+	err := &ProfileNotFoundError{Name: "staging"}
+	assert.NotEmpty(t, err.Error(), "ProfileNotFoundError.Error() should not return empty string")
+	assert.True(t, errors.Is(err, ErrProfileNotFound), "ProfileNotFoundError should be checkable with errors.Is()")
+
+	var profileErr *ProfileNotFoundError
+	require.True(t, errors.As(err, &profileErr), "errors.As() should work with ProfileNotFoundError")
+	assert.Equal(t, "staging", profileErr.Name)
+}
+
+func TestIPRestrictedError(t *testing.T) {
+	err := &IPRestrictedError{Err: &APIError{Code: "ACCESS_DENIED_FROM_IP", Message: "denied"}}
+	assert.NotEmpty(t, err.Error(), "IPRestrictedError.Error() should not return empty string")
+	assert.True(t, errors.Is(err, ErrIPRestricted), "IPRestrictedError should be checkable with errors.Is()")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr), "IPRestrictedError should unwrap to APIError")
+	assert.Equal(t, "ACCESS_DENIED_FROM_IP", apiErr.Code)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package exporter publishes reg.ru account health as Prometheus metrics:
+// domain expiration dates, the account balance, unpaid bills and the number
+// of records per zone. The data is refreshed on an interval, so scrapes do
+// not hit the reg.ru API.
+//
+// Metrics are written in the Prometheus text exposition format:
+//
+//	regru_domain_expiry_timestamp_seconds{domain}  expiration date of a domain
+//	regru_account_balance{currency}                available prepaid balance
+//	regru_unpaid_bills_total                       number of unpaid bills
+//	regru_unpaid_bills_amount{currency}            sum of unpaid bills
+//	regru_zone_record_count{zone}                  records in a DNS zone
+//	regru_refresh_success                          1 if the last refresh succeeded
+//	regru_refresh_timestamp_seconds                time of the last refresh
+//	regru_refresh_errors_total                     failed refreshes
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// DefaultInterval is the delay between refreshes in Run.
+const DefaultInterval = 15 * time.Minute
+
+// Client is the subset of regru.Client methods used by the exporter.
+type Client interface {
+	ListDomains(ctx context.Context) ([]regru.Domain, error)
+	GetBalance(ctx context.Context) (regru.Balance, error)
+	ListUnpaidBills(ctx context.Context) ([]regru.Bill, error)
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+}
+
+// Exporter collects account metrics and serves them over HTTP.
+type Exporter struct {
+	client   Client
+	interval time.Duration
+	onError  func(error)
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	snapshot snapshot
+}
+
+// snapshot holds the values of the last refresh. Groups that fail to refresh
+// keep their previous values.
+type snapshot struct {
+	expiry      map[string]time.Time
+	balance     *regru.Balance
+	bills       *int
+	billAmounts map[string]float64
+	records     map[string]int
+	success     bool
+	refreshedAt time.Time
+	errors      int
+}
+
+// Option represents an option for configuring the exporter.
+type Option func(*Exporter)
+
+// WithInterval sets the delay between refreshes in Run.
+func WithInterval(interval time.Duration) Option {
+	return func(e *Exporter) {
+		e.interval = interval
+	}
+}
+
+// WithErrorHandler sets a callback invoked with refresh errors.
+func WithErrorHandler(fn func(error)) Option {
+	return func(e *Exporter) {
+		e.onError = fn
+	}
+}
+
+// NewExporter creates an exporter backed by the client. Metrics are empty
+// until the first refresh.
+func NewExporter(client Client, opts ...Option) *Exporter {
+	e := &Exporter{
+		client:   client,
+		interval: DefaultInterval,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Refresh fetches the account data once. Every metric group is fetched
+// independently: groups that fail keep their previous values and the errors
+// are joined.
+func (e *Exporter) Refresh(ctx context.Context) error {
+	expiry, expiryErr := e.expiry(ctx)
+	balance, balanceErr := e.client.GetBalance(ctx)
+	bills, billsErr := e.client.ListUnpaidBills(ctx)
+	records, recordsErr := e.records(ctx)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s := &e.snapshot
+	var errs []error
+	if expiryErr != nil {
+		errs = append(errs, fmt.Errorf("list domains: %w", expiryErr))
+	} else {
+		s.expiry = expiry
+	}
+	if balanceErr != nil {
+		errs = append(errs, fmt.Errorf("get balance: %w", balanceErr))
+	} else {
+		s.balance = &balance
+	}
+	if billsErr != nil {
+		errs = append(errs, fmt.Errorf("list unpaid bills: %w", billsErr))
+	} else {
+		count := len(bills)
+		s.bills = &count
+		s.billAmounts = make(map[string]float64)
+		for _, bill := range bills {
+			s.billAmounts[bill.Currency] += bill.Total
+		}
+	}
+	if recordsErr != nil {
+		errs = append(errs, recordsErr)
+	} else {
+		s.records = records
+	}
+
+	s.success = len(errs) == 0
+	s.refreshedAt = e.now()
+	if !s.success {
+		s.errors++
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && e.onError != nil {
+		e.onError(err)
+	}
+	return err
+}
+
+// expiry returns the expiration dates of the registered domains.
+func (e *Exporter) expiry(ctx context.Context) (map[string]time.Time, error) {
+	domains, err := e.client.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expiry := make(map[string]time.Time, len(domains))
+	for _, domain := range domains {
+		if !domain.ExpirationDate.IsZero() {
+			expiry[domain.Name] = domain.ExpirationDate
+		}
+	}
+	return expiry, nil
+}
+
+// records returns the number of records in every zone.
+func (e *Exporter) records(ctx context.Context) (map[string]int, error) {
+	zones, err := e.client.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list zones: %w", err)
+	}
+	counts := make(map[string]int, len(zones))
+	for _, zone := range zones {
+		records, err := e.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zone.Name})
+		if err != nil {
+			return nil, fmt.Errorf("list records of %s: %w", zone.Name, err)
+		}
+		counts[zone.Name] = len(records)
+	}
+	return counts, nil
+}
+
+// Run refreshes the metrics on every interval until the context is done.
+// Errors are passed to the error handler and do not stop the loop.
+func (e *Exporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = e.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler, writing the metrics of the last refresh.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = e.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics of the last refresh in the Prometheus text format.
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	s := e.snapshot
+	m := &metricWriter{w: w}
+
+	m.header("regru_domain_expiry_timestamp_seconds", "gauge", "Expiration date of the domain as a Unix timestamp.")
+	for _, name := range sortedKeys(s.expiry) {
+		m.sample("regru_domain_expiry_timestamp_seconds", float64(s.expiry[name].Unix()), "domain", name)
+	}
+
+	if s.balance != nil {
+		m.header("regru_account_balance", "gauge", "Available prepaid balance of the account.")
+		m.sample("regru_account_balance", s.balance.Prepay, "currency", s.balance.Currency)
+	}
+
+	if s.bills != nil {
+		m.header("regru_unpaid_bills_total", "gauge", "Number of unpaid bills.")
+		m.sample("regru_unpaid_bills_total", float64(*s.bills))
+		m.header("regru_unpaid_bills_amount", "gauge", "Sum of the unpaid bills.")
+		for _, currency := range sortedKeys(s.billAmounts) {
+			m.sample("regru_unpaid_bills_amount", s.billAmounts[currency], "currency", currency)
+		}
+	}
+
+	m.header("regru_zone_record_count", "gauge", "Number of records in the DNS zone.")
+	for _, zone := range sortedKeys(s.records) {
+		m.sample("regru_zone_record_count", float64(s.records[zone]), "zone", zone)
+	}
+
+	if !s.refreshedAt.IsZero() {
+		success := 0.0
+		if s.success {
+			success = 1
+		}
+		m.header("regru_refresh_success", "gauge", "Whether the last refresh succeeded.")
+		m.sample("regru_refresh_success", success)
+		m.header("regru_refresh_timestamp_seconds", "gauge", "Time of the last refresh as a Unix timestamp.")
+		m.sample("regru_refresh_timestamp_seconds", float64(s.refreshedAt.Unix()))
+	}
+	m.header("regru_refresh_errors_total", "counter", "Number of failed refreshes.")
+	m.sample("regru_refresh_errors_total", float64(s.errors))
+
+	return m.err
+}
+
+// metricWriter writes the Prometheus text format, keeping the first error.
+type metricWriter struct {
+	w   io.Writer
+	err error
+}
+
+// header writes the HELP and TYPE lines of a metric.
+func (m *metricWriter) header(name, kind, help string) {
+	m.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes a sample with label name/value pairs.
+func (m *metricWriter) sample(name string, value float64, labels ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(labelEscaper.Replace(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	m.printf("%s %s\n", b.String(), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (m *metricWriter) printf(format string, args ...interface{}) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintf(m.w, format, args...)
+	}
+}
+
+// labelEscaper escapes label values as required by the text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// sortedKeys returns the keys of the map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
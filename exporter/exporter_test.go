@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regru.Client must satisfy the exporter Client interface
+var _ Client = (*regru.Client)(nil)
+
+type fakeClient struct {
+	domains    []regru.Domain
+	balance    regru.Balance
+	bills      []regru.Bill
+	zones      []regru.Zone
+	records    map[string][]regru.DNSRecord
+	balanceErr error
+}
+
+func (f *fakeClient) ListDomains(ctx context.Context) ([]regru.Domain, error) {
+	return f.domains, nil
+}
+
+func (f *fakeClient) GetBalance(ctx context.Context) (regru.Balance, error) {
+	return f.balance, f.balanceErr
+}
+
+func (f *fakeClient) ListUnpaidBills(ctx context.Context) ([]regru.Bill, error) {
+	return f.bills, nil
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func TestExporter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeClient{
+		domains: []regru.Domain{
+			{Name: "example.org", ExpirationDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "example.com", ExpirationDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{Name: "unknown.net"},
+		},
+		balance: regru.Balance{Prepay: 1500.5, Currency: "RUR"},
+		bills: []regru.Bill{
+			{ID: "1", Currency: "RUR", Total: 100},
+			{ID: "2", Currency: "RUR", Total: 250},
+		},
+		zones: []regru.Zone{{Name: "example.com"}, {Name: `we"ird.com`}},
+		records: map[string][]regru.DNSRecord{
+			"example.com": {{Name: "@", Type: regru.RecordTypeA}, {Name: "www", Type: regru.RecordTypeA}},
+		},
+	}
+
+	e := NewExporter(client)
+	e.now = func() time.Time { return now }
+
+	// Nothing but the error counter before the first refresh
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.NotContains(t, rec.Body.String(), "regru_account_balance")
+	assert.Contains(t, rec.Body.String(), "regru_refresh_errors_total 0\n")
+
+	require.NoError(t, e.Refresh(context.Background()))
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "version=0.0.4")
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE regru_domain_expiry_timestamp_seconds gauge",
+		`regru_domain_expiry_timestamp_seconds{domain="example.com"} 1.7407872e+09`,
+		`regru_domain_expiry_timestamp_seconds{domain="example.org"} 1.748736e+09`,
+		`regru_account_balance{currency="RUR"} 1500.5`,
+		"regru_unpaid_bills_total 2",
+		`regru_unpaid_bills_amount{currency="RUR"} 350`,
+		`regru_zone_record_count{zone="example.com"} 2`,
+		`regru_zone_record_count{zone="we\"ird.com"} 0`,
+		"regru_refresh_success 1",
+		"regru_refresh_timestamp_seconds 1.7357328e+09",
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.NotContains(t, body, "unknown.net")
+	assert.Less(t, strings.Index(body, `domain="example.com"`), strings.Index(body, `domain="example.org"`))
+
+	// A failed group keeps its previous values
+	var handled error
+	e.onError = func(err error) { handled = err }
+	client.balanceErr = errors.New("unavailable")
+	client.balance = regru.Balance{}
+
+	err := e.Refresh(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "get balance")
+	assert.Equal(t, err, handled)
+
+	var b strings.Builder
+	require.NoError(t, e.WriteMetrics(&b))
+	assert.Contains(t, b.String(), `regru_account_balance{currency="RUR"} 1500.5`+"\n")
+	assert.Contains(t, b.String(), "regru_refresh_success 0\n")
+	assert.Contains(t, b.String(), "regru_refresh_errors_total 1\n")
+}
@@ -0,0 +1,284 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externaldns implements the Kubernetes external-dns webhook provider
+// API backed by a reg.ru client, so external-dns can manage reg.ru zones:
+//
+//	GET  /                 negotiation, returns the domain filter
+//	GET  /records          current records as endpoints
+//	POST /records          apply changes
+//	POST /adjustendpoints  normalize desired endpoints
+//	GET  /healthz          liveness check
+//
+// Ownership TXT records written by the external-dns TXT registry are returned
+// quoted, as external-dns created them, so ownership is recognized on every sync.
+package externaldns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// MediaType is the content type of webhook requests and responses.
+const MediaType = "application/external.dns.webhook+json;version=1"
+
+// maxBodySize limits the size of request bodies. A change set holds every
+// record external-dns changes in one sync, so the limit is generous.
+const maxBodySize = 4 << 20
+
+// Client is the subset of regru.Client methods used by the server.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// Server is an http.Handler implementing the external-dns webhook provider API.
+type Server struct {
+	client  Client
+	domains []string
+	mux     *http.ServeMux
+}
+
+// Option represents an option for configuring the server.
+type Option func(*Server)
+
+// WithDomainFilter limits the server to the zones of the account matching the
+// domains, which are also reported to external-dns during negotiation.
+func WithDomainFilter(domains ...string) Option {
+	return func(s *Server) {
+		for _, domain := range domains {
+			s.domains = append(s.domains, canonicalName(domain))
+		}
+	}
+}
+
+// NewServer creates a new external-dns webhook server backed by the client.
+func NewServer(client Client, opts ...Option) *Server {
+	s := &Server{
+		client: client,
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /{$}", s.handleNegotiate)
+	s.mux.HandleFunc("GET /records", s.handleRecords)
+	s.mux.HandleFunc("POST /records", s.handleApplyChanges)
+	s.mux.HandleFunc("POST /adjustendpoints", s.handleAdjustEndpoints)
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleNegotiate(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, domainFilter{Include: s.domains})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) handleRecords(w http.ResponseWriter, r *http.Request) {
+	zones, err := s.zones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	result := []*endpoint{}
+	for _, zone := range zones {
+		records, err := s.client.ListRecords(r.Context(), regru.ListDNSRecordsParams{ZoneName: zone})
+		if err != nil {
+			writeClientError(w, err)
+			return
+		}
+		result = append(result, toEndpoints(zone, records)...)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleAdjustEndpoints(w http.ResponseWriter, r *http.Request) {
+	var endpoints []*endpoint
+	if !decodeBody(w, r, &endpoints) {
+		return
+	}
+
+	result := make([]*endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		result = append(result, adjustEndpoint(ep))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleApplyChanges(w http.ResponseWriter, r *http.Request) {
+	var ch changes
+	if !decodeBody(w, r, &ch) {
+		return
+	}
+
+	zones, err := s.zones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	deletes, err := toRecords(zones, append(append([]*endpoint(nil), ch.Delete...), ch.UpdateOld...))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	creates, err := toRecords(zones, append(append([]*endpoint(nil), ch.Create...), ch.UpdateNew...))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// Records present on both sides of an update are left in place
+	deletes, creates = withoutCommon(deletes, creates)
+
+	if err := s.apply(r.Context(), deletes, creates); err != nil {
+		writeClientError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeBody decodes the JSON request body into v, writing an error response
+// if the body is invalid or larger than maxBodySize.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v)
+	if err == nil {
+		return true
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return false
+	}
+	http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+	return false
+}
+
+// apply deletes and then creates records, zone by zone. Deleted records are
+// looked up in the zone, and records that no longer exist are ignored.
+func (s *Server) apply(ctx context.Context, deletes, creates []zoneRecord) error {
+	current := make(map[string][]regru.DNSRecord)
+	for _, zr := range deletes {
+		records, ok := current[zr.zone]
+		if !ok {
+			var err error
+			records, err = s.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zr.zone})
+			if err != nil {
+				return err
+			}
+			current[zr.zone] = records
+		}
+		for _, rr := range records {
+			if rr.Equal(zr.record) {
+				if err := s.client.DeleteRR(ctx, zr.zone, rr); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	for _, zr := range creates {
+		params := regru.CreateDNSRecordParams{
+			Name:    zr.record.Name,
+			Type:    zr.record.Type,
+			Content: zr.record.Content,
+			TTL:     zr.record.TTL,
+		}
+		if _, err := s.client.AddRR(ctx, zr.zone, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zones returns the account zones matching the domain filter.
+func (s *Server) zones(ctx context.Context) ([]string, error) {
+	zones, err := s.client.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, z := range zones {
+		if s.matchesFilter(canonicalName(z.Name)) {
+			result = append(result, z.Name)
+		}
+	}
+	return result, nil
+}
+
+// matchesFilter reports whether the zone is inside one of the filter domains.
+// Every zone matches an empty filter.
+func (s *Server) matchesFilter(zone string) bool {
+	if len(s.domains) == 0 {
+		return true
+	}
+	for _, domain := range s.domains {
+		if zone == domain || strings.HasSuffix(zone, "."+domain) || strings.HasSuffix(domain, "."+zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes a webhook JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", MediaType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeClientError maps client errors to HTTP status codes.
+func writeClientError(w http.ResponseWriter, err error) {
+	var (
+		apiErr  *regru.APIError
+		httpErr *regru.HTTPError
+	)
+	switch {
+	case errors.Is(err, regru.ErrZoneNotFound), errors.Is(err, regru.ErrRecordNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, regru.ErrUnsupportedRecordType), errors.As(err, &apiErr):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.As(err, &httpErr):
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
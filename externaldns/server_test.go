@@ -0,0 +1,182 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is an in-memory Client implementation.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		zones: []regru.Zone{{Name: "example.com"}, {Name: "example.org"}},
+		records: map[string][]regru.DNSRecord{
+			"example.com": {
+				{Name: "@", Type: regru.RecordTypeA, Content: "192.0.2.1", TTL: 3600},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1", TTL: 300},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.2", TTL: 300},
+				{Name: "a-www", Type: regru.RecordTypeTXT, Content: "heritage=external-dns,external-dns/owner=default", TTL: 300},
+				{Name: "@", Type: regru.RecordTypeMX, Content: "10 mail.example.com.", TTL: 3600},
+			},
+			"example.org": {
+				{Name: "@", Type: regru.RecordTypeA, Content: "198.51.100.1"},
+			},
+		},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	f.added = append(f.added, params)
+	return regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content}, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Accept", MediaType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Negotiate(t *testing.T) {
+	server := NewServer(newFakeClient(), WithDomainFilter("Example.com."))
+
+	rec := doRequest(t, server, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, MediaType, rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"include": ["example.com"]}`, rec.Body.String())
+}
+
+func TestServer_Health(t *testing.T) {
+	rec := doRequest(t, NewServer(newFakeClient()), http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_Records(t *testing.T) {
+	server := NewServer(newFakeClient(), WithDomainFilter("example.com"))
+
+	rec := doRequest(t, server, http.MethodGet, "/records", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var endpoints []endpoint
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&endpoints))
+	require.Len(t, endpoints, 4)
+
+	assert.Equal(t, endpoint{DNSName: "example.com", RecordType: "A", RecordTTL: 3600, Targets: []string{"192.0.2.1"}}, endpoints[0])
+	assert.Equal(t, endpoint{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"192.0.2.1", "192.0.2.2"}}, endpoints[1])
+	// Ownership records are quoted as the TXT registry writes them
+	assert.Equal(t, []string{`"heritage=external-dns,external-dns/owner=default"`}, endpoints[2].Targets)
+	assert.Equal(t, []string{"10 mail.example.com"}, endpoints[3].Targets)
+}
+
+func TestServer_ApplyChanges(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client)
+
+	body := `{
+		"Create": [
+			{"dnsName": "app.example.org", "recordType": "CNAME", "targets": ["lb.example.net."], "recordTTL": 60},
+			{"dnsName": "cname-app.example.org", "recordType": "TXT", "targets": ["\"heritage=external-dns,external-dns/owner=default\""]}
+		],
+		"UpdateOld": [{"dnsName": "www.example.com", "recordType": "A", "targets": ["192.0.2.1", "192.0.2.2"], "recordTTL": 300}],
+		"UpdateNew": [{"dnsName": "www.example.com", "recordType": "A", "targets": ["192.0.2.1", "192.0.2.3"], "recordTTL": 300}],
+		"Delete": [{"dnsName": "example.com", "recordType": "MX", "targets": ["10 mail.example.com"]}]
+	}`
+
+	rec := doRequest(t, server, http.MethodPost, "/records", body)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	require.Len(t, client.deleted, 2)
+	assert.Equal(t, regru.RecordTypeMX, client.deleted[0].Type)
+	assert.Equal(t, "192.0.2.2", client.deleted[1].Content)
+
+	assert.Equal(t, []regru.CreateDNSRecordParams{
+		{Name: "app", Type: regru.RecordTypeCNAME, Content: "lb.example.net", TTL: 60},
+		{Name: "cname-app", Type: regru.RecordTypeTXT, Content: "heritage=external-dns,external-dns/owner=default"},
+		{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.3", TTL: 300},
+	}, client.added)
+}
+
+func TestServer_ApplyChanges_UnknownZone(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client, WithDomainFilter("example.com"))
+
+	body := `{"Create": [{"dnsName": "app.example.org", "recordType": "A", "targets": ["192.0.2.10"]}]}`
+
+	rec := doRequest(t, server, http.MethodPost, "/records", body)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Empty(t, client.added)
+}
+
+func TestServer_AdjustEndpoints(t *testing.T) {
+	server := NewServer(newFakeClient())
+
+	body := `[{"dnsName": "App.Example.com.", "recordType": "cname", "targets": ["LB.example.net."]}]`
+
+	rec := doRequest(t, server, http.MethodPost, "/adjustendpoints", body)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var endpoints []endpoint
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&endpoints))
+	assert.Equal(t, []endpoint{{DNSName: "app.example.com", RecordType: "CNAME", Targets: []string{"lb.example.net"}}}, endpoints)
+}
+
+func TestServer_BodyTooLarge(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client)
+
+	target := `"` + strings.Repeat("a", maxBodySize) + `"`
+	for _, req := range []struct{ path, body string }{
+		{"/records", `{"Create": [{"dnsName": "app.example.com", "recordType": "TXT", "targets": [` + target + `]}]}`},
+		{"/adjustendpoints", `[{"dnsName": "app.example.com", "recordType": "TXT", "targets": [` + target + `]}]`},
+	} {
+		rec := doRequest(t, server, http.MethodPost, req.path, req.body)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, req.path)
+	}
+	assert.Empty(t, client.added)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// ownershipPrefix starts the content of TXT records written by the external-dns TXT registry.
+const ownershipPrefix = "heritage=external-dns"
+
+// endpoint represents an external-dns endpoint: one name and type with its targets.
+type endpoint struct {
+	DNSName          string                     `json:"dnsName,omitempty"`
+	Targets          []string                   `json:"targets,omitempty"`
+	RecordType       string                     `json:"recordType,omitempty"`
+	SetIdentifier    string                     `json:"setIdentifier,omitempty"`
+	RecordTTL        int64                      `json:"recordTTL,omitempty"`
+	Labels           map[string]string          `json:"labels,omitempty"`
+	ProviderSpecific []providerSpecificProperty `json:"providerSpecific,omitempty"`
+}
+
+// providerSpecificProperty represents a provider specific endpoint property.
+type providerSpecificProperty struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// changes represents the body of an apply changes request.
+type changes struct {
+	Create    []*endpoint `json:"create,omitempty"`
+	UpdateOld []*endpoint `json:"updateOld,omitempty"`
+	UpdateNew []*endpoint `json:"updateNew,omitempty"`
+	Delete    []*endpoint `json:"delete,omitempty"`
+}
+
+// domainFilter represents the domain filter returned during negotiation.
+type domainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// zoneRecord is a record together with the zone it belongs to.
+type zoneRecord struct {
+	zone   string
+	record regru.DNSRecord
+}
+
+// toEndpoints groups zone records by name and type into endpoints.
+func toEndpoints(zone string, records []regru.DNSRecord) []*endpoint {
+	index := make(map[string]*endpoint)
+	var result []*endpoint
+
+	for _, rr := range records {
+		c := regru.Canonicalize(rr)
+		name := absoluteName(c.Name, zone)
+		key := name + " " + string(c.Type)
+
+		ep, ok := index[key]
+		if !ok {
+			ep = &endpoint{DNSName: name, RecordType: string(c.Type), RecordTTL: int64(rr.TTL)}
+			index[key] = ep
+			result = append(result, ep)
+		}
+		ep.Targets = append(ep.Targets, toTarget(c))
+	}
+	return result
+}
+
+// toTarget returns the endpoint target of a canonical record. Ownership TXT
+// records are quoted as the TXT registry writes them.
+func toTarget(c regru.DNSRecord) string {
+	if c.Type == regru.RecordTypeTXT && strings.HasPrefix(c.Content, ownershipPrefix) {
+		return `"` + c.Content + `"`
+	}
+	return c.Content
+}
+
+// toRecords converts endpoints into records of the zones they belong to.
+func toRecords(zones []string, endpoints []*endpoint) ([]zoneRecord, error) {
+	var result []zoneRecord
+	for _, ep := range endpoints {
+		if ep == nil {
+			continue
+		}
+		name := canonicalName(ep.DNSName)
+		zone := findZone(zones, name)
+		if zone == "" {
+			return nil, fmt.Errorf("no zone found for %s", ep.DNSName)
+		}
+
+		for _, target := range ep.Targets {
+			record := regru.Canonicalize(regru.DNSRecord{
+				Name:    relativeName(name, zone),
+				Type:    regru.RecordType(ep.RecordType),
+				Content: target,
+				TTL:     int(ep.RecordTTL),
+			})
+			result = append(result, zoneRecord{zone: zone, record: record})
+		}
+	}
+	return result, nil
+}
+
+// withoutCommon drops records that are both deleted and created with the same TTL.
+func withoutCommon(deletes, creates []zoneRecord) ([]zoneRecord, []zoneRecord) {
+	created := make([]bool, len(creates))
+	var remaining []zoneRecord
+	for _, d := range deletes {
+		kept := false
+		for i, c := range creates {
+			if !created[i] && c.zone == d.zone && c.record.Equal(d.record) && sameTTL(c.record.TTL, d.record.TTL) {
+				created[i] = true
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			remaining = append(remaining, d)
+		}
+	}
+
+	var toCreate []zoneRecord
+	for i, c := range creates {
+		if !created[i] {
+			toCreate = append(toCreate, c)
+		}
+	}
+	return remaining, toCreate
+}
+
+// sameTTL reports whether two TTLs are equal, treating an unset TTL as equal to any.
+func sameTTL(a, b int) bool {
+	return a == 0 || b == 0 || a == b
+}
+
+// adjustEndpoint returns the endpoint with the name and targets in the form
+// returned by the records endpoint, so external-dns plans no spurious updates.
+func adjustEndpoint(ep *endpoint) *endpoint {
+	adjusted := *ep
+	adjusted.DNSName = canonicalName(ep.DNSName)
+	adjusted.RecordType = strings.ToUpper(ep.RecordType)
+	adjusted.Targets = make([]string, 0, len(ep.Targets))
+	for _, target := range ep.Targets {
+		c := regru.Canonicalize(regru.DNSRecord{Type: regru.RecordType(adjusted.RecordType), Content: target})
+		if c.Type == regru.RecordTypeTXT {
+			// TXT targets are kept as given: ownership records are compared quoted
+			adjusted.Targets = append(adjusted.Targets, target)
+			continue
+		}
+		adjusted.Targets = append(adjusted.Targets, c.Content)
+	}
+	return &adjusted
+}
+
+// findZone returns the longest zone containing the name, or "" if there is none.
+func findZone(zones []string, name string) string {
+	best := ""
+	for _, zone := range zones {
+		z := canonicalName(zone)
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(canonicalName(best)) {
+			best = zone
+		}
+	}
+	return best
+}
+
+// canonicalName returns the name in lower case without a trailing dot.
+func canonicalName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// absoluteName converts a name relative to the zone into an absolute name without a trailing dot.
+func absoluteName(name, zone string) string {
+	zone = canonicalName(zone)
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// relativeName converts an absolute name into a name relative to the zone.
+func relativeName(name, zone string) string {
+	zone = canonicalName(zone)
+	if name == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
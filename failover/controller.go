@@ -0,0 +1,279 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package failover switches a DNS record between a primary and a standby
+// target based on health checks. When the primary fails a configurable number
+// of consecutive checks the record is pointed at the standby; once the primary
+// passes enough consecutive checks again, the record is switched back.
+package failover
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+const (
+	// DefaultInterval is the delay between health checks.
+	DefaultInterval = 30 * time.Second
+	// DefaultFailureThreshold is the number of consecutive failed checks
+	// before switching to the standby.
+	DefaultFailureThreshold = 3
+	// DefaultRecoveryThreshold is the number of consecutive successful checks
+	// before switching back to the primary.
+	DefaultRecoveryThreshold = 5
+)
+
+// Client is the subset of regru.Client methods used by the controller.
+type Client interface {
+	EnsureRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.EnsureResult, error)
+}
+
+// HealthChecker checks whether a target (the record content, such as an IP
+// address or hostname) is healthy. A nil error means healthy.
+type HealthChecker interface {
+	Check(ctx context.Context, target string) error
+}
+
+// HealthCheckerFunc adapts a function to the HealthChecker interface.
+type HealthCheckerFunc func(ctx context.Context, target string) error
+
+// Check calls f(ctx, target).
+func (f HealthCheckerFunc) Check(ctx context.Context, target string) error {
+	return f(ctx, target)
+}
+
+// TCPHealthChecker returns a checker that considers a target healthy if a TCP
+// connection to the port can be established within the timeout.
+func TCPHealthChecker(port string, timeout time.Duration) HealthChecker {
+	return HealthCheckerFunc(func(ctx context.Context, target string) error {
+		dialer := net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(target, port))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
+
+// EventType identifies a controller event.
+type EventType string
+
+// Controller events
+const (
+	// EventFailover is emitted after the record was switched to the standby.
+	EventFailover EventType = "failover"
+	// EventRecovery is emitted after the record was switched back to the primary.
+	EventRecovery EventType = "recovery"
+	// EventCheckFailed is emitted for every failed health check of the primary.
+	EventCheckFailed EventType = "check_failed"
+	// EventError is emitted when the record could not be updated.
+	EventError EventType = "error"
+)
+
+// Event describes a state change or problem observed by the controller.
+type Event struct {
+	Type EventType
+	// From and To are the previous and new record content for switch events.
+	From string
+	To   string
+	// Err is the health check or API error, if any.
+	Err  error
+	Time time.Time
+}
+
+// Controller keeps a record pointed at a healthy target.
+type Controller struct {
+	client            Client
+	zone              string
+	name              string
+	recordType        regru.RecordType
+	primary           string
+	standby           string
+	checker           HealthChecker
+	interval          time.Duration
+	failureThreshold  int
+	recoveryThreshold int
+	ttl               int
+	onEvent           func(Event)
+
+	mu        sync.Mutex
+	active    string
+	applied   bool
+	failures  int
+	successes int
+}
+
+// Option represents an option for configuring the controller.
+type Option func(*Controller)
+
+// WithInterval sets the delay between health checks in Run.
+func WithInterval(interval time.Duration) Option {
+	return func(c *Controller) {
+		c.interval = interval
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failed checks before failing over.
+func WithFailureThreshold(n int) Option {
+	return func(c *Controller) {
+		c.failureThreshold = n
+	}
+}
+
+// WithRecoveryThreshold sets the number of consecutive successful checks
+// before switching back to the primary.
+func WithRecoveryThreshold(n int) Option {
+	return func(c *Controller) {
+		c.recoveryThreshold = n
+	}
+}
+
+// WithTTL sets the TTL in seconds of the managed record.
+func WithTTL(ttl int) Option {
+	return func(c *Controller) {
+		c.ttl = ttl
+	}
+}
+
+// WithEventHandler sets a callback invoked for every event.
+func WithEventHandler(fn func(Event)) Option {
+	return func(c *Controller) {
+		c.onEvent = fn
+	}
+}
+
+// NewController creates a controller for the record name of type recordType
+// in zone that points at primary while it is healthy and at standby otherwise.
+func NewController(client Client, zone, name string, recordType regru.RecordType, primary, standby string, checker HealthChecker, opts ...Option) *Controller {
+	c := &Controller{
+		client:            client,
+		zone:              zone,
+		name:              name,
+		recordType:        recordType,
+		primary:           primary,
+		standby:           standby,
+		checker:           checker,
+		interval:          DefaultInterval,
+		failureThreshold:  DefaultFailureThreshold,
+		recoveryThreshold: DefaultRecoveryThreshold,
+		active:            primary,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Active returns the target the record currently points at.
+func (c *Controller) Active() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.active
+}
+
+// Step checks the primary once and switches the record if a threshold was reached.
+// The first call also makes sure the record points at the active target.
+func (c *Controller) Step(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.checker.Check(ctx, c.primary)
+	if err != nil {
+		c.emit(Event{Type: EventCheckFailed, Err: err})
+	}
+
+	target := c.active
+	switch {
+	case c.active == c.primary && err != nil:
+		c.failures++
+		if c.failures >= c.failureThreshold {
+			target = c.standby
+		}
+	case c.active == c.primary:
+		c.failures = 0
+	case err == nil:
+		c.successes++
+		if c.successes >= c.recoveryThreshold {
+			target = c.primary
+		}
+	default:
+		c.successes = 0
+	}
+
+	if target == c.active && c.applied {
+		return nil
+	}
+
+	if _, err := c.client.EnsureRR(ctx, c.zone, regru.CreateDNSRecordParams{
+		Name:    c.name,
+		Type:    c.recordType,
+		Content: target,
+		TTL:     c.ttl,
+	}); err != nil {
+		c.emit(Event{Type: EventError, From: c.active, To: target, Err: err})
+		return err
+	}
+
+	previous := c.active
+	c.active = target
+	c.applied = true
+	if previous == target {
+		return nil
+	}
+
+	c.failures = 0
+	c.successes = 0
+	switch target {
+	case c.standby:
+		c.emit(Event{Type: EventFailover, From: previous, To: target})
+	default:
+		c.emit(Event{Type: EventRecovery, From: previous, To: target})
+	}
+
+	return nil
+}
+
+// Run calls Step on every interval until the context is done.
+// Errors are reported as events and do not stop the loop.
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		_ = c.Step(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// emit passes the event to the event handler, if any.
+func (c *Controller) emit(event Event) {
+	if c.onEvent == nil {
+		return
+	}
+	event.Time = time.Now()
+	c.onEvent(event)
+}
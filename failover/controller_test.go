@@ -0,0 +1,117 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failover
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	contents []string
+	err      error
+}
+
+func (f *fakeClient) EnsureRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.EnsureResult, error) {
+	if f.err != nil {
+		return regru.EnsureResult{}, f.err
+	}
+	f.contents = append(f.contents, params.Content)
+	return regru.EnsureResult{Action: regru.EnsureActionReplaced}, nil
+}
+
+func TestController_FailoverAndRecovery(t *testing.T) {
+	client := &fakeClient{}
+	healthy := true
+	checker := HealthCheckerFunc(func(ctx context.Context, target string) error {
+		assert.Equal(t, "192.0.2.1", target)
+		if healthy {
+			return nil
+		}
+		return errors.New("down")
+	})
+
+	var events []EventType
+	controller := NewController(client, "example.com", "www", regru.RecordTypeA, "192.0.2.1", "192.0.2.2", checker,
+		WithFailureThreshold(2),
+		WithRecoveryThreshold(2),
+		WithEventHandler(func(e Event) { events = append(events, e.Type) }),
+	)
+
+	// First step applies the primary
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, []string{"192.0.2.1"}, client.contents)
+
+	healthy = false
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, "192.0.2.1", controller.Active(), "single failure must not switch")
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, "192.0.2.2", controller.Active())
+
+	// Hysteresis: a flapping primary does not switch back
+	healthy = true
+	require.NoError(t, controller.Step(context.Background()))
+	healthy = false
+	require.NoError(t, controller.Step(context.Background()))
+	healthy = true
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, "192.0.2.2", controller.Active())
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, "192.0.2.1", controller.Active())
+
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2", "192.0.2.1"}, client.contents)
+	assert.Equal(t, []EventType{EventCheckFailed, EventCheckFailed, EventFailover, EventCheckFailed, EventRecovery}, events)
+}
+
+func TestController_UpdateError(t *testing.T) {
+	client := &fakeClient{err: errors.New("api down")}
+	checker := HealthCheckerFunc(func(ctx context.Context, target string) error { return nil })
+
+	var events []Event
+	controller := NewController(client, "example.com", "www", regru.RecordTypeA, "192.0.2.1", "192.0.2.2", checker,
+		WithEventHandler(func(e Event) { events = append(events, e) }),
+	)
+
+	assert.Error(t, controller.Step(context.Background()))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventError, events[0].Type)
+
+	// The record is applied again once the API recovers
+	client.err = nil
+	require.NoError(t, controller.Step(context.Background()))
+	assert.Equal(t, []string{"192.0.2.1"}, client.contents)
+}
+
+func TestTCPHealthChecker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	checker := TCPHealthChecker(port, time.Second)
+	assert.NoError(t, checker.Check(context.Background(), "127.0.0.1"))
+
+	require.NoError(t, listener.Close())
+	assert.Error(t, checker.Check(context.Background(), "127.0.0.1"))
+}
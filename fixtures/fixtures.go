@@ -0,0 +1,248 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures provides a corpus of reg.ru API responses and helpers to
+// validate response parsers against it. The samples reproduce the quirks of
+// the real API: error envelopes, per-domain errors inside successful answers,
+// alternative field names (servtype/service_type, dname/domain) and numbers
+// returned as strings.
+//
+//	server := fixtures.Server(t, map[string]string{
+//		"zone/get_resource_records": fixtures.ZoneGetResourceRecords,
+//	})
+//	client := regru.NewClient("user", "password", regru.WithBaseURL(server.URL))
+package fixtures
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Fixture names.
+const (
+	// ZoneGetResourceRecords is a zone/get_resource_records answer with common record types and SOA TTLs.
+	ZoneGetResourceRecords = "zone_get_resource_records"
+	// ZoneGetResourceRecordsQuirks has an IDN zone, string priorities, a split TXT value and mixed-case names.
+	ZoneGetResourceRecordsQuirks = "zone_get_resource_records_quirks"
+	// ZoneAddRecord is a successful zone/add_* answer with the dns_id of the record.
+	ZoneAddRecord = "zone_add_record"
+	// ZoneAddRecordDomainError is a successful envelope carrying a per-domain error.
+	ZoneAddRecordDomainError = "zone_add_record_domain_error"
+	// ServiceGetList is a service/get_list answer using the servtype and dname field names.
+	ServiceGetList = "service_get_list"
+	// ServiceGetListAlternative uses the service_type and domain field names and a numeric service_id.
+	ServiceGetListAlternative = "service_get_list_alternative"
+	// UserGetBalance is a user/get_balance answer with amounts as strings.
+	UserGetBalance = "user_get_balance"
+	// ErrorInvalidAuth is the error envelope for invalid credentials.
+	ErrorInvalidAuth = "error_invalid_auth"
+	// ErrorIPRestricted is the error envelope for calls from an address outside the API allowlist.
+	ErrorIPRestricted = "error_ip_restricted"
+)
+
+// envelopeFields are the top-level response keys common to every answer.
+var envelopeFields = map[string]bool{
+	"charset":      true,
+	"messagestore": true,
+	"result":       true,
+	"error_code":   true,
+	"error_text":   true,
+	"error_params": true,
+}
+
+//go:embed responses/*.json
+var responses embed.FS
+
+// Names returns the names of all fixtures, sorted.
+func Names() []string {
+	entries, _ := responses.ReadDir("responses")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the response body of the fixture.
+func Load(name string) ([]byte, error) {
+	data, err := responses.ReadFile(path.Join("responses", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown fixture %q", name)
+	}
+	return data, nil
+}
+
+// MustLoad returns the response body of the fixture and panics if there is none.
+func MustLoad(name string) []byte {
+	data, err := Load(name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// Server returns a test server answering the API methods in routes (for
+// example "zone/get_resource_records") with the named fixtures. Other methods
+// get an empty successful answer. The server is closed when the test ends.
+func Server(t testing.TB, routes map[string]string) *httptest.Server {
+	t.Helper()
+
+	bodies := make(map[string][]byte, len(routes))
+	for method, name := range routes {
+		data, err := Load(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies[method] = data
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			body = []byte(`{"result": "success"}`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// AssertDecodes decodes the fixture into v, a pointer to a response type, and
+// reports a test error if it does not decode.
+func AssertDecodes(t testing.TB, name string, v interface{}) bool {
+	t.Helper()
+
+	data, err := Load(name)
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		t.Errorf("fixture %s: %v", name, err)
+		return false
+	}
+	return true
+}
+
+// AssertMapsAllFields decodes the fixture into v like AssertDecodes and
+// reports a test error for every field of the fixture that v does not keep.
+func AssertMapsAllFields(t testing.TB, name string, v interface{}) bool {
+	t.Helper()
+
+	if !AssertDecodes(t, name, v) {
+		return false
+	}
+	unmapped, err := UnmappedFields(MustLoad(name), v)
+	if err != nil {
+		t.Errorf("fixture %s: %v", name, err)
+		return false
+	}
+	for _, field := range unmapped {
+		t.Errorf("fixture %s: field %s is not mapped by %T", name, field, v)
+	}
+	return len(unmapped) == 0
+}
+
+// UnmappedFields returns the paths ("answer.domains[0].rrs[1].prio") of the
+// fields of data that are lost when data is decoded into v and encoded again.
+// Envelope fields such as charset and result are ignored, as are fields with
+// empty values, which omitempty tags drop.
+func UnmappedFields(data []byte, v interface{}) ([]string, error) {
+	var original interface{}
+	if err := json.Unmarshal(data, &original); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var roundTrip interface{}
+	if err := json.Unmarshal(encoded, &roundTrip); err != nil {
+		return nil, err
+	}
+
+	var unmapped []string
+	if object, ok := original.(map[string]interface{}); ok {
+		for key := range envelopeFields {
+			delete(object, key)
+		}
+	}
+	collectUnmapped(original, roundTrip, "", &unmapped)
+	sort.Strings(unmapped)
+	return unmapped, nil
+}
+
+// collectUnmapped appends the paths of values in original missing from roundTrip.
+func collectUnmapped(original, roundTrip interface{}, prefix string, unmapped *[]string) {
+	switch o := original.(type) {
+	case map[string]interface{}:
+		r, _ := roundTrip.(map[string]interface{})
+		for key, value := range o {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			kept, ok := r[key]
+			if !ok {
+				if !isEmpty(value) {
+					*unmapped = append(*unmapped, name)
+				}
+				continue
+			}
+			collectUnmapped(value, kept, name, unmapped)
+		}
+	case []interface{}:
+		r, _ := roundTrip.([]interface{})
+		for i, value := range o {
+			name := fmt.Sprintf("%s[%d]", prefix, i)
+			if i >= len(r) {
+				*unmapped = append(*unmapped, name)
+				continue
+			}
+			collectUnmapped(value, r[i], name, unmapped)
+		}
+	}
+}
+
+// isEmpty reports whether a decoded JSON value is null, zero or empty.
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
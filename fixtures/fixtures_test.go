@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	names := Names()
+	assert.Contains(t, names, ZoneGetResourceRecords)
+	assert.Contains(t, names, ErrorIPRestricted)
+
+	// Every fixture is valid JSON
+	for _, name := range names {
+		var v interface{}
+		assert.NoError(t, json.Unmarshal(MustLoad(name), &v), name)
+	}
+}
+
+func TestLoad_Unknown(t *testing.T) {
+	_, err := Load("missing")
+	assert.Error(t, err)
+	assert.Panics(t, func() { MustLoad("missing") })
+}
+
+func TestServer(t *testing.T) {
+	server := Server(t, map[string]string{"user/get_balance": UserGetBalance})
+
+	resp, err := http.Post(server.URL+"/user/get_balance", "application/x-www-form-urlencoded", strings.NewReader(""))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.JSONEq(t, string(MustLoad(UserGetBalance)), string(body))
+
+	resp, err = http.Post(server.URL+"/user/nop", "application/x-www-form-urlencoded", strings.NewReader(""))
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.JSONEq(t, `{"result": "success"}`, string(body))
+}
+
+func TestUnmappedFields(t *testing.T) {
+	type service struct {
+		ServType string `json:"servtype,omitempty"`
+		DName    string `json:"dname,omitempty"`
+	}
+	type response struct {
+		Answer struct {
+			Services []service `json:"services"`
+		} `json:"answer"`
+	}
+
+	data := []byte(`{"answer": {"services": [{"servtype": "domain", "dname": "example.com", "state": "A", "subtype": ""}]}, "result": "success"}`)
+	unmapped, err := UnmappedFields(data, &response{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"answer.services[0].state"}, unmapped)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultFlowPollInterval is the delay between service state checks in order flows.
+const DefaultFlowPollInterval = 10 * time.Second
+
+// stateActive is the reg.ru state of an active service.
+const stateActive = "A"
+
+// FlowStep identifies a step of an order flow.
+type FlowStep string
+
+// Order flow steps
+const (
+	// FlowStepOrder creates the registration or renewal order.
+	FlowStepOrder FlowStep = "order"
+	// FlowStepPay pays the order bill from the prepaid balance.
+	FlowStepPay FlowStep = "pay"
+	// FlowStepActivate waits until the service is active.
+	FlowStepActivate FlowStep = "activate"
+)
+
+// FlowResult describes the outcome of an order flow. On failure it holds
+// everything done before the failed step, so the flow can be resumed manually.
+type FlowResult struct {
+	Order Order `json:"order"`
+	// Bill is the bill after payment; it is zero if the bill was already paid
+	// or the order was paid when created.
+	Bill Bill `json:"bill"`
+	// Domain is the last observed state of the domain service.
+	Domain Domain `json:"domain"`
+	// Completed lists the steps finished successfully, in order.
+	Completed []FlowStep `json:"completed,omitempty"`
+}
+
+// FlowOption represents an option for configuring order flows.
+type FlowOption func(*flowOptions)
+
+// flowOptions holds order flow settings.
+type flowOptions struct {
+	pollInterval time.Duration
+}
+
+// WithFlowPollInterval sets the delay between service state checks. Intervals
+// that are not positive are ignored and DefaultFlowPollInterval is used.
+func WithFlowPollInterval(interval time.Duration) FlowOption {
+	return func(o *flowOptions) {
+		if interval > 0 {
+			o.pollInterval = interval
+		}
+	}
+}
+
+// RegisterDomainAndPay orders the registration of a domain, pays the resulting
+// bill from the prepaid balance and waits until the domain is active. Bound the
+// wait with a context deadline; registration in some registries takes hours.
+// Errors are *FlowError values naming the failed step.
+func (c *Client) RegisterDomainAndPay(ctx context.Context, order DomainOrder, opts ...FlowOption) (FlowResult, error) {
+	return c.runOrderFlow(ctx, func(ctx context.Context) (Order, error) {
+		return c.Domains.Register(ctx, order)
+	}, func(d Domain) bool {
+		return d.State == stateActive
+	}, opts)
+}
+
+// RenewDomainAndPay orders the renewal of a domain for the period in years,
+// pays the resulting bill from the prepaid balance and waits until the new
+// expiration date is visible. Errors are *FlowError values naming the failed step.
+func (c *Client) RenewDomainAndPay(ctx context.Context, domain string, period int, opts ...FlowOption) (FlowResult, error) {
+	current, err := c.Domains.Get(ctx, domain)
+	if err != nil {
+		return FlowResult{}, &FlowError{Step: FlowStepOrder, Err: err}
+	}
+
+	result, err := c.runOrderFlow(ctx, func(ctx context.Context) (Order, error) {
+		return c.Domains.Renew(ctx, domain, period)
+	}, func(d Domain) bool {
+		return d.State == stateActive && d.ExpirationDate.After(current.ExpirationDate)
+	}, opts)
+	if result.Domain.Name == "" {
+		result.Domain = current
+	}
+	return result, err
+}
+
+// runOrderFlow places an order, pays its bill if it is still unpaid and polls
+// the domain until ready reports true.
+func (c *Client) runOrderFlow(ctx context.Context, place func(context.Context) (Order, error), ready func(Domain) bool, opts []FlowOption) (FlowResult, error) {
+	o := flowOptions{pollInterval: DefaultFlowPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var result FlowResult
+	order, err := place(ctx)
+	if err != nil {
+		return result, &FlowError{Step: FlowStepOrder, Err: err}
+	}
+	result.Order = order
+	result.Completed = append(result.Completed, FlowStepOrder)
+
+	// Pay the bill unless the order was paid when created
+	if order.BillID != "" {
+		bills, err := c.Billing.ListUnpaidBills(ctx)
+		if err != nil {
+			return result, &FlowError{Step: FlowStepPay, Err: err}
+		}
+		for _, bill := range bills {
+			if bill.ID != order.BillID {
+				continue
+			}
+			result.Bill, err = c.Billing.PayFromBalance(ctx, bill.ID)
+			if err != nil {
+				return result, &FlowError{Step: FlowStepPay, Err: err}
+			}
+			break
+		}
+	}
+	result.Completed = append(result.Completed, FlowStepPay)
+
+	// Wait for the service; lookup errors are expected until the service exists
+	for {
+		domain, err := c.Domains.Get(ctx, order.Domain)
+		if err == nil {
+			result.Domain = domain
+			if ready(domain) {
+				result.Completed = append(result.Completed, FlowStepActivate)
+				return result, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, &FlowError{Step: FlowStepActivate, Err: ctx.Err()}
+		case <-time.After(o.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// responseSequence is a test response that returns its values in turn,
+// repeating the last one.
+type responseSequence struct {
+	mu        sync.Mutex
+	responses []interface{}
+	served    int
+}
+
+func (s *responseSequence) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := min(s.served, len(s.responses)-1)
+	s.served++
+	return json.Marshal(s.responses[i])
+}
+
+func serviceInfo(state, expiration string) map[string]interface{} {
+	return map[string]interface{}{
+		"result": "success",
+		"answer": map[string]interface{}{
+			"services": []map[string]interface{}{
+				{"dname": "example.com", "service_id": 3003, "servtype": "domain", "state": state, "expiration_date": expiration},
+			},
+		},
+	}
+}
+
+func unpaidBills(ids ...string) map[string]interface{} {
+	bills := []map[string]interface{}{}
+	for _, id := range ids {
+		bills = append(bills, map[string]interface{}{"bill_id": id, "total": "790.00", "currency": "RUR"})
+	}
+	return map[string]interface{}{"result": "success", "answer": map[string]interface{}{"bills": bills}}
+}
+
+func callPaths(calls []apiCall) []string {
+	paths := make([]string, 0, len(calls))
+	for _, call := range calls {
+		paths = append(paths, call.Path)
+	}
+	return paths
+}
+
+func TestClient_RegisterDomainAndPay(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/create": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": 2002, "service_id": 3003, "dname": "example.com", "payment": "790.00"},
+		},
+		"bill/get_not_payed": unpaidBills("1999", "2002"),
+		"bill/change_pay_type": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{{"bill_id": 2002, "result": "success", "status": "payed", "payment": "790.00"}},
+			},
+		},
+		"service/get_info": &responseSequence{responses: []interface{}{
+			map[string]interface{}{"result": "success", "answer": map[string]interface{}{"services": []interface{}{}}},
+			serviceInfo("N", ""),
+			serviceInfo("A", "2026-05-01"),
+		}},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.RegisterDomainAndPay(context.Background(), DomainOrder{Name: "example.com"},
+		WithFlowPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Equal(t, []FlowStep{FlowStepOrder, FlowStepPay, FlowStepActivate}, result.Completed)
+	assert.Equal(t, "2002", result.Order.BillID)
+	assert.Equal(t, "payed", result.Bill.Status)
+	assert.Equal(t, "A", result.Domain.State)
+	assert.Equal(t, []string{
+		"domain/create", "bill/get_not_payed", "bill/change_pay_type",
+		"service/get_info", "service/get_info", "service/get_info",
+	}, callPaths(*calls))
+}
+
+func TestClient_RegisterDomainAndPay_AlreadyPaid(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/create": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": 2002, "service_id": 3003},
+		},
+		"bill/get_not_payed": unpaidBills("1999"),
+		"service/get_info":   serviceInfo("A", "2026-05-01"),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.RegisterDomainAndPay(context.Background(), DomainOrder{Name: "example.com"})
+	require.NoError(t, err)
+	assert.Len(t, result.Completed, 3)
+	assert.Zero(t, result.Bill)
+	assert.Equal(t, []string{"domain/create", "bill/get_not_payed", "service/get_info"}, callPaths(*calls))
+}
+
+func TestClient_RegisterDomainAndPay_PaymentFails(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/create": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": 2002, "service_id": 3003},
+		},
+		"bill/get_not_payed": unpaidBills("2002"),
+		"bill/change_pay_type": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{{"bill_id": 2002, "result": "error", "error_code": "NO_MONEY"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.RegisterDomainAndPay(context.Background(), DomainOrder{Name: "example.com"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrFlowFailed))
+
+	var flowErr *FlowError
+	require.ErrorAs(t, err, &flowErr)
+	assert.Equal(t, FlowStepPay, flowErr.Step)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "NO_MONEY", apiErr.Code)
+
+	assert.Equal(t, []FlowStep{FlowStepOrder}, result.Completed)
+	assert.Equal(t, "2002", result.Order.BillID)
+	assert.Len(t, *calls, 3)
+}
+
+func TestClient_RenewDomainAndPay(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"service/renew": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": 2003, "service_id": 3003},
+		},
+		"bill/get_not_payed": unpaidBills("2003"),
+		"bill/change_pay_type": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{{"bill_id": 2003, "result": "success", "status": "payed"}},
+			},
+		},
+		"service/get_info": &responseSequence{responses: []interface{}{
+			serviceInfo("A", "2026-05-01"),
+			serviceInfo("A", "2026-05-01"),
+			serviceInfo("A", "2027-05-01"),
+		}},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	result, err := client.RenewDomainAndPay(context.Background(), "example.com", 1, WithFlowPollInterval(time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, []FlowStep{FlowStepOrder, FlowStepPay, FlowStepActivate}, result.Completed)
+	assert.Equal(t, 2027, result.Domain.ExpirationDate.Year())
+	assert.Equal(t, "service/get_info", (*calls)[0].Path)
+	assert.Len(t, *calls, 6)
+}
+
+func TestClient_RenewDomainAndPay_Timeout(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"service/renew": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"service_id": 3003},
+		},
+		"service/get_info": serviceInfo("A", "2026-05-01"),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := client.RenewDomainAndPay(ctx, "example.com", 1, WithFlowPollInterval(5*time.Millisecond))
+	var flowErr *FlowError
+	require.ErrorAs(t, err, &flowErr)
+	assert.Equal(t, FlowStepActivate, flowErr.Step)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []FlowStep{FlowStepOrder, FlowStepPay}, result.Completed)
+	assert.Equal(t, "example.com", result.Domain.Name)
+}
+
+func TestWithFlowPollInterval(t *testing.T) {
+	o := flowOptions{pollInterval: DefaultFlowPollInterval}
+	WithFlowPollInterval(time.Second)(&o)
+	assert.Equal(t, time.Second, o.pollInterval)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		o = flowOptions{pollInterval: DefaultFlowPollInterval}
+		WithFlowPollInterval(interval)(&o)
+		assert.Equal(t, DefaultFlowPollInterval, o.pollInterval, interval)
+	}
+}
@@ -2,10 +2,22 @@ module github.com/mixanemca/regru-go
 
 go 1.24.2
 
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/libdns/libdns v1.1.1
+	github.com/miekg/dns v1.1.62
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/net v0.47.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/google/licensecheck v0.3.1 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/mod v0.29.0 // indirect
+	golang.org/x/sync v0.18.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	golang.org/x/text v0.31.0 // indirect
+	golang.org/x/tools v0.38.0 // indirect
 )
@@ -0,0 +1,353 @@
+// Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: regru.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RegRu_ListZones_FullMethodName    = "/regru.v1.RegRu/ListZones"
+	RegRu_ListRecords_FullMethodName  = "/regru.v1.RegRu/ListRecords"
+	RegRu_AddRecord_FullMethodName    = "/regru.v1.RegRu/AddRecord"
+	RegRu_UpdateRecord_FullMethodName = "/regru.v1.RegRu/UpdateRecord"
+	RegRu_DeleteRecord_FullMethodName = "/regru.v1.RegRu/DeleteRecord"
+	RegRu_ListDomains_FullMethodName  = "/regru.v1.RegRu/ListDomains"
+)
+
+// RegRuClient is the client API for RegRu service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RegRu exposes the zone, record and domain operations of a reg.ru client.
+type RegRuClient interface {
+	// ListZones streams the DNS zones of the account.
+	ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Zone], error)
+	// ListRecords streams the records of a zone, optionally filtered by name and type.
+	ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Record], error)
+	// AddRecord creates a record and returns it.
+	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*Record, error)
+	// UpdateRecord replaces old_record (or record, if unset) with record and
+	// returns the new record.
+	UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*Record, error)
+	// DeleteRecord deletes a record.
+	DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponse, error)
+	// ListDomains streams the domains registered in the account.
+	ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Domain], error)
+}
+
+type regRuClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRegRuClient(cc grpc.ClientConnInterface) RegRuClient {
+	return &regRuClient{cc}
+}
+
+func (c *regRuClient) ListZones(ctx context.Context, in *ListZonesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Zone], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RegRu_ServiceDesc.Streams[0], RegRu_ListZones_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListZonesRequest, Zone]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListZonesClient = grpc.ServerStreamingClient[Zone]
+
+func (c *regRuClient) ListRecords(ctx context.Context, in *ListRecordsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Record], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RegRu_ServiceDesc.Streams[1], RegRu_ListRecords_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRecordsRequest, Record]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListRecordsClient = grpc.ServerStreamingClient[Record]
+
+func (c *regRuClient) AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Record)
+	err := c.cc.Invoke(ctx, RegRu_AddRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *regRuClient) UpdateRecord(ctx context.Context, in *UpdateRecordRequest, opts ...grpc.CallOption) (*Record, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Record)
+	err := c.cc.Invoke(ctx, RegRu_UpdateRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *regRuClient) DeleteRecord(ctx context.Context, in *DeleteRecordRequest, opts ...grpc.CallOption) (*DeleteRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRecordResponse)
+	err := c.cc.Invoke(ctx, RegRu_DeleteRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *regRuClient) ListDomains(ctx context.Context, in *ListDomainsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Domain], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RegRu_ServiceDesc.Streams[2], RegRu_ListDomains_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDomainsRequest, Domain]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListDomainsClient = grpc.ServerStreamingClient[Domain]
+
+// RegRuServer is the server API for RegRu service.
+// All implementations must embed UnimplementedRegRuServer
+// for forward compatibility.
+//
+// RegRu exposes the zone, record and domain operations of a reg.ru client.
+type RegRuServer interface {
+	// ListZones streams the DNS zones of the account.
+	ListZones(*ListZonesRequest, grpc.ServerStreamingServer[Zone]) error
+	// ListRecords streams the records of a zone, optionally filtered by name and type.
+	ListRecords(*ListRecordsRequest, grpc.ServerStreamingServer[Record]) error
+	// AddRecord creates a record and returns it.
+	AddRecord(context.Context, *AddRecordRequest) (*Record, error)
+	// UpdateRecord replaces old_record (or record, if unset) with record and
+	// returns the new record.
+	UpdateRecord(context.Context, *UpdateRecordRequest) (*Record, error)
+	// DeleteRecord deletes a record.
+	DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponse, error)
+	// ListDomains streams the domains registered in the account.
+	ListDomains(*ListDomainsRequest, grpc.ServerStreamingServer[Domain]) error
+	mustEmbedUnimplementedRegRuServer()
+}
+
+// UnimplementedRegRuServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRegRuServer struct{}
+
+func (UnimplementedRegRuServer) ListZones(*ListZonesRequest, grpc.ServerStreamingServer[Zone]) error {
+	return status.Errorf(codes.Unimplemented, "method ListZones not implemented")
+}
+func (UnimplementedRegRuServer) ListRecords(*ListRecordsRequest, grpc.ServerStreamingServer[Record]) error {
+	return status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
+}
+func (UnimplementedRegRuServer) AddRecord(context.Context, *AddRecordRequest) (*Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRecord not implemented")
+}
+func (UnimplementedRegRuServer) UpdateRecord(context.Context, *UpdateRecordRequest) (*Record, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecord not implemented")
+}
+func (UnimplementedRegRuServer) DeleteRecord(context.Context, *DeleteRecordRequest) (*DeleteRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecord not implemented")
+}
+func (UnimplementedRegRuServer) ListDomains(*ListDomainsRequest, grpc.ServerStreamingServer[Domain]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (UnimplementedRegRuServer) mustEmbedUnimplementedRegRuServer() {}
+func (UnimplementedRegRuServer) testEmbeddedByValue()               {}
+
+// UnsafeRegRuServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RegRuServer will
+// result in compilation errors.
+type UnsafeRegRuServer interface {
+	mustEmbedUnimplementedRegRuServer()
+}
+
+func RegisterRegRuServer(s grpc.ServiceRegistrar, srv RegRuServer) {
+	// If the following call pancis, it indicates UnimplementedRegRuServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RegRu_ServiceDesc, srv)
+}
+
+func _RegRu_ListZones_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListZonesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegRuServer).ListZones(m, &grpc.GenericServerStream[ListZonesRequest, Zone]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListZonesServer = grpc.ServerStreamingServer[Zone]
+
+func _RegRu_ListRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegRuServer).ListRecords(m, &grpc.GenericServerStream[ListRecordsRequest, Record]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListRecordsServer = grpc.ServerStreamingServer[Record]
+
+func _RegRu_AddRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegRuServer).AddRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegRu_AddRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegRuServer).AddRecord(ctx, req.(*AddRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegRu_UpdateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegRuServer).UpdateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegRu_UpdateRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegRuServer).UpdateRecord(ctx, req.(*UpdateRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegRu_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegRuServer).DeleteRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RegRu_DeleteRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegRuServer).DeleteRecord(ctx, req.(*DeleteRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegRu_ListDomains_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDomainsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegRuServer).ListDomains(m, &grpc.GenericServerStream[ListDomainsRequest, Domain]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RegRu_ListDomainsServer = grpc.ServerStreamingServer[Domain]
+
+// RegRu_ServiceDesc is the grpc.ServiceDesc for RegRu service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RegRu_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "regru.v1.RegRu",
+	HandlerType: (*RegRuServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddRecord",
+			Handler:    _RegRu_AddRecord_Handler,
+		},
+		{
+			MethodName: "UpdateRecord",
+			Handler:    _RegRu_UpdateRecord_Handler,
+		},
+		{
+			MethodName: "DeleteRecord",
+			Handler:    _RegRu_DeleteRecord_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListZones",
+			Handler:       _RegRu_ListZones_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRecords",
+			Handler:       _RegRu_ListRecords_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDomains",
+			Handler:       _RegRu_ListDomains_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "regru.proto",
+}
@@ -0,0 +1,235 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpcapi provides a gRPC service wrapping the zone, record and domain
+// operations of a reg.ru client, defined in regru.proto. List methods stream
+// their results.
+//
+// The server does not authenticate callers; use gRPC interceptors or transport
+// credentials for that:
+//
+//	s := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(auth))
+//	grpcapi.RegisterRegRuServer(s, grpcapi.NewServer(client))
+//
+// The Go code is generated from regru.proto with protoc-gen-go and
+// protoc-gen-go-grpc using paths=source_relative.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Client is the subset of regru.Client methods used by the server.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR regru.DNSRecord) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+	ListDomains(ctx context.Context) ([]regru.Domain, error)
+}
+
+// Server implements RegRuServer backed by a client.
+type Server struct {
+	UnimplementedRegRuServer
+
+	client Client
+}
+
+// NewServer creates a new gRPC service implementation backed by the client.
+func NewServer(client Client) *Server {
+	return &Server{client: client}
+}
+
+// ListZones implements RegRuServer.
+func (s *Server) ListZones(_ *ListZonesRequest, stream grpc.ServerStreamingServer[Zone]) error {
+	zones, err := s.client.ListZones(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, z := range zones {
+		if err := stream.Send(&Zone{Id: z.ID, Name: z.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListRecords implements RegRuServer.
+func (s *Server) ListRecords(req *ListRecordsRequest, stream grpc.ServerStreamingServer[Record]) error {
+	if req.GetZone() == "" {
+		return status.Error(codes.InvalidArgument, "zone is required")
+	}
+
+	records, err := s.client.ListRecords(stream.Context(), regru.ListDNSRecordsParams{
+		ZoneName: req.GetZone(),
+		Name:     req.GetName(),
+		Type:     regru.RecordType(req.GetType()),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, rr := range records {
+		if err := stream.Send(toRecord(rr)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRecord implements RegRuServer.
+func (s *Server) AddRecord(ctx context.Context, req *AddRecordRequest) (*Record, error) {
+	rr, err := recordArg(req.GetZone(), req.GetRecord())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.client.AddRR(ctx, req.GetZone(), regru.CreateDNSRecordParams{
+		Name:     rr.Name,
+		Type:     rr.Type,
+		Content:  rr.Content,
+		TTL:      rr.TTL,
+		Priority: rr.Priority,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toRecord(created), nil
+}
+
+// UpdateRecord implements RegRuServer. Without old_record the record replaces
+// itself, which only changes its TTL and priority.
+func (s *Server) UpdateRecord(ctx context.Context, req *UpdateRecordRequest) (*Record, error) {
+	rr, err := recordArg(req.GetZone(), req.GetRecord())
+	if err != nil {
+		return nil, err
+	}
+	old := rr
+	if req.GetOldRecord() != nil {
+		if old, err = recordArg(req.GetZone(), req.GetOldRecord()); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.client.UpdateRRFrom(ctx, req.GetZone(), old, rr)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toRecord(updated), nil
+}
+
+// DeleteRecord implements RegRuServer.
+func (s *Server) DeleteRecord(ctx context.Context, req *DeleteRecordRequest) (*DeleteRecordResponse, error) {
+	rr, err := recordArg(req.GetZone(), req.GetRecord())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.DeleteRR(ctx, req.GetZone(), rr); err != nil {
+		return nil, toStatus(err)
+	}
+	return &DeleteRecordResponse{}, nil
+}
+
+// ListDomains implements RegRuServer.
+func (s *Server) ListDomains(_ *ListDomainsRequest, stream grpc.ServerStreamingServer[Domain]) error {
+	domains, err := s.client.ListDomains(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, d := range domains {
+		msg := &Domain{
+			Name:           d.Name,
+			ServiceId:      d.ServiceID,
+			State:          d.State,
+			CreationDate:   timestamp(d.CreationDate),
+			ExpirationDate: timestamp(d.ExpirationDate),
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordArg validates the zone and record of a write request.
+func recordArg(zone string, r *Record) (regru.DNSRecord, error) {
+	if zone == "" || r.GetName() == "" || r.GetType() == "" || r.GetContent() == "" {
+		return regru.DNSRecord{}, status.Error(codes.InvalidArgument, "zone and record name, type and content are required")
+	}
+	return regru.DNSRecord{
+		ID:       r.GetId(),
+		Name:     r.GetName(),
+		Type:     regru.RecordType(r.GetType()),
+		Content:  r.GetContent(),
+		TTL:      int(r.GetTtl()),
+		Priority: int(r.GetPriority()),
+	}, nil
+}
+
+// toRecord converts a client record into a message.
+func toRecord(rr regru.DNSRecord) *Record {
+	return &Record{
+		Id:       rr.ID,
+		Name:     rr.Name,
+		Type:     string(rr.Type),
+		Content:  rr.Content,
+		Ttl:      int32(rr.TTL),
+		Priority: int32(rr.Priority),
+	}
+}
+
+// timestamp converts a time into a message, leaving zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toStatus maps client errors to gRPC status codes.
+func toStatus(err error) error {
+	var (
+		apiErr  *regru.APIError
+		httpErr *regru.HTTPError
+	)
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, regru.ErrZoneNotFound), errors.Is(err, regru.ErrRecordNotFound):
+		code = codes.NotFound
+	case errors.Is(err, regru.ErrWriteDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, regru.ErrIPRestricted):
+		code = codes.FailedPrecondition
+	case errors.As(err, &httpErr):
+		code = codes.Unavailable
+	case errors.Is(err, regru.ErrUnsupportedRecordType), errors.As(err, &apiErr):
+		code = codes.InvalidArgument
+	}
+	return status.Error(code, err.Error())
+}
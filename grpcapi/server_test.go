@@ -0,0 +1,250 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeClient is an in-memory Client implementation.
+type fakeClient struct {
+	records map[string][]regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	// updated holds old and new record pairs.
+	updated [][2]regru.DNSRecord
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		records: map[string][]regru.DNSRecord{
+			"example.com": {
+				{Name: "@", Type: regru.RecordTypeA, Content: "192.0.2.1"},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.2"},
+			},
+		},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return []regru.Zone{{ID: "1", Name: "example.com"}, {ID: "2", Name: "example.org"}}, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	records, ok := f.records[params.ZoneName]
+	if !ok {
+		return nil, &regru.ZoneNotFoundError{ZoneID: params.ZoneName}
+	}
+	return records, nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	if params.Type == "HINFO" {
+		return regru.DNSRecord{}, &regru.UnsupportedRecordTypeError{RecordType: string(params.Type)}
+	}
+	f.added = append(f.added, params)
+	return regru.DNSRecord{ID: "42", Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}, nil
+}
+
+func (f *fakeClient) UpdateRRFrom(ctx context.Context, zone string, oldRR, newRR regru.DNSRecord) (regru.DNSRecord, error) {
+	f.updated = append(f.updated, [2]regru.DNSRecord{oldRR, newRR})
+	return newRR, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func (f *fakeClient) ListDomains(ctx context.Context) ([]regru.Domain, error) {
+	return []regru.Domain{{
+		Name:           "example.com",
+		ServiceID:      "12345",
+		State:          "A",
+		ExpirationDate: time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}, nil
+}
+
+// dial starts the service on an in-memory listener and returns a client for it.
+func dial(t *testing.T, client Client) RegRuClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterRegRuServer(server, NewServer(client))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewRegRuClient(conn)
+}
+
+// receiveAll reads a stream until it ends.
+func receiveAll[T any](t *testing.T, stream grpc.ServerStreamingClient[T]) []*T {
+	t.Helper()
+
+	var items []*T
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return items
+		}
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+}
+
+func TestServer_ListZones(t *testing.T) {
+	client := dial(t, newFakeClient())
+
+	stream, err := client.ListZones(context.Background(), &ListZonesRequest{})
+	require.NoError(t, err)
+
+	zones := receiveAll(t, stream)
+	require.Len(t, zones, 2)
+	assert.Equal(t, "example.com", zones[0].GetName())
+	assert.Equal(t, "2", zones[1].GetId())
+}
+
+func TestServer_ListRecords(t *testing.T) {
+	client := dial(t, newFakeClient())
+
+	stream, err := client.ListRecords(context.Background(), &ListRecordsRequest{Zone: "example.com"})
+	require.NoError(t, err)
+	records := receiveAll(t, stream)
+	require.Len(t, records, 2)
+	assert.Equal(t, "www", records[1].GetName())
+	assert.Equal(t, "192.0.2.2", records[1].GetContent())
+
+	stream, err = client.ListRecords(context.Background(), &ListRecordsRequest{Zone: "unknown.com"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_WriteRecords(t *testing.T) {
+	fake := newFakeClient()
+	client := dial(t, fake)
+	ctx := context.Background()
+
+	created, err := client.AddRecord(ctx, &AddRecordRequest{
+		Zone:   "example.com",
+		Record: &Record{Name: "api", Type: "A", Content: "192.0.2.3", Ttl: 300},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "42", created.GetId())
+	require.Len(t, fake.added, 1)
+	assert.Equal(t, 300, fake.added[0].TTL)
+
+	_, err = client.UpdateRecord(ctx, &UpdateRecordRequest{
+		Zone:   "example.com",
+		Record: &Record{Name: "www", Type: "A", Content: "192.0.2.4", Ttl: 60},
+	})
+	require.NoError(t, err)
+	require.Len(t, fake.updated, 1)
+	assert.Equal(t, fake.updated[0][0], fake.updated[0][1])
+
+	updated, err := client.UpdateRecord(ctx, &UpdateRecordRequest{
+		Zone:      "example.com",
+		Record:    &Record{Name: "@", Type: "MX", Content: "mx2.example.com", Priority: 20},
+		OldRecord: &Record{Name: "@", Type: "MX", Content: "mx1.example.com", Priority: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), updated.GetPriority())
+	require.Len(t, fake.updated, 2)
+	assert.Equal(t, regru.DNSRecord{Name: "@", Type: regru.RecordTypeMX, Content: "mx1.example.com", Priority: 10}, fake.updated[1][0])
+	assert.Equal(t, regru.DNSRecord{Name: "@", Type: regru.RecordTypeMX, Content: "mx2.example.com", Priority: 20}, fake.updated[1][1])
+
+	_, err = client.UpdateRecord(ctx, &UpdateRecordRequest{
+		Zone:      "example.com",
+		Record:    &Record{Name: "www", Type: "A", Content: "192.0.2.4"},
+		OldRecord: &Record{Name: "www"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.DeleteRecord(ctx, &DeleteRecordRequest{
+		Zone:   "example.com",
+		Record: &Record{Name: "www", Type: "A", Content: "192.0.2.4"},
+	})
+	require.NoError(t, err)
+	require.Len(t, fake.deleted, 1)
+
+	_, err = client.AddRecord(ctx, &AddRecordRequest{Zone: "example.com", Record: &Record{Name: "api"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.AddRecord(ctx, &AddRecordRequest{
+		Zone:   "example.com",
+		Record: &Record{Name: "api", Type: "HINFO", Content: "x"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_AddRecord_Priority(t *testing.T) {
+	fake := newFakeClient()
+	client := dial(t, fake)
+
+	_, err := client.AddRecord(context.Background(), &AddRecordRequest{
+		Zone:   "example.com",
+		Record: &Record{Name: "@", Type: "MX", Content: "mail.example.com", Priority: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, fake.added, 1)
+	assert.Equal(t, 10, fake.added[0].Priority)
+}
+
+func TestServer_ListDomains(t *testing.T) {
+	client := dial(t, newFakeClient())
+
+	stream, err := client.ListDomains(context.Background(), &ListDomainsRequest{})
+	require.NoError(t, err)
+
+	domains := receiveAll(t, stream)
+	require.Len(t, domains, 1)
+	assert.Equal(t, "12345", domains[0].GetServiceId())
+	assert.Nil(t, domains[0].GetCreationDate())
+	assert.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), domains[0].GetExpirationDate().AsTime())
+}
+
+func TestToStatus(t *testing.T) {
+	assert.Equal(t, codes.PermissionDenied, status.Code(toStatus(&regru.WriteDeniedError{Method: "zone/add_alias", Err: errors.New("freeze")})))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(toStatus(&regru.IPRestrictedError{Err: &regru.APIError{Message: "denied"}})))
+	assert.Equal(t, codes.Unavailable, status.Code(toStatus(&regru.HTTPError{StatusCode: 502})))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(toStatus(context.DeadlineExceeded)))
+	assert.Equal(t, codes.Unknown, status.Code(toStatus(errors.New("boom"))))
+}
@@ -27,7 +27,7 @@ import (
 func TestGetAddRecordPath(t *testing.T) {
 	tests := []struct {
 		name        string
-		recordType  string
+		recordType  RecordType
 		wantPath    string
 		wantErr     bool
 		wantErrType error
@@ -94,7 +94,7 @@ func TestGetAddRecordPath(t *testing.T) {
 func TestGetRemoveRecordPath(t *testing.T) {
 	tests := []struct {
 		name        string
-		recordType  string
+		recordType  RecordType
 		wantPath    string
 		wantErr     bool
 		wantErrType error
@@ -164,7 +164,7 @@ func TestCreateAddRecordRequest(t *testing.T) {
 		zone      string
 		params    CreateDNSRecordParams
 		wantErr   bool
-		wantType  string
+		wantType  RecordType
 		checkType func(APIRequest) bool
 	}{
 		{
@@ -299,7 +299,7 @@ func TestCreateRemoveRecordRequest(t *testing.T) {
 		zone      string
 		record    DNSRecord
 		wantErr   bool
-		wantType  string
+		wantType  RecordType
 		checkType func(APIRequest) bool
 	}{
 		{
@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile converts internationalized names. Unlike idna.Lookup it accepts
+// underscores, which are common in record names such as "_dmarc".
+var idnaProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.Transitional(false))
+
+// WithIDNConversion enables or disables the conversion of internationalized
+// zone and record names. When enabled, Unicode names are sent to the API in
+// punycode (ASCII) form, and punycode names in returned Zone and DNSRecord
+// values are converted back to Unicode. Conversion is enabled by default.
+func WithIDNConversion(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.noIDNConversion = !enabled
+	}
+}
+
+// asciiName returns the punycode form of a zone or record name for API calls.
+// Names that are already ASCII or cannot be converted are returned unchanged.
+func (c *Client) asciiName(name string) string {
+	if c.noIDNConversion || isASCII(name) {
+		return name
+	}
+	ascii, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return name
+	}
+	return ascii
+}
+
+// unicodeName returns the Unicode form of a punycode zone or record name returned by the API.
+func (c *Client) unicodeName(name string) string {
+	if c.noIDNConversion || !strings.Contains(strings.ToLower(name), "xn--") {
+		return name
+	}
+	unicode, err := idnaProfile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return unicode
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
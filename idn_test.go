@@ -0,0 +1,96 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_IDNNames(t *testing.T) {
+	c := NewClient("user", "pass")
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", c.asciiName("пример.рф"))
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", c.asciiName("Пример.РФ"))
+	assert.Equal(t, "_dmarc", c.asciiName("_dmarc"))
+	assert.Equal(t, "пример.рф", c.unicodeName("xn--e1afmkfd.xn--p1ai"))
+	assert.Equal(t, "www", c.unicodeName("www"))
+
+	c = NewClient("user", "pass", WithIDNConversion(false))
+	assert.Equal(t, "пример.рф", c.asciiName("пример.рф"))
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", c.unicodeName("xn--e1afmkfd.xn--p1ai"))
+}
+
+func TestClient_IDNConversion(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"service/get_list": ServiceListResponse{
+			Answer: ServiceListAnswer{
+				Services: []Service{
+					{ServiceType: "domain", Domain: "xn--e1afmkfd.xn--p1ai", ServiceID: 1},
+				},
+			},
+		},
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{
+					{
+						DName:  "xn--e1afmkfd.xn--p1ai",
+						Result: "success",
+						RRList: []ResourceRecord{
+							{Subname: "xn--80aswg", Rectype: "A", Content: "192.0.2.1"},
+						},
+					},
+				},
+			},
+			Result: "success",
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	zones, err := client.ListZones(ctx)
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "пример.рф", zones[0].Name)
+
+	zones, err = client.ListZonesByName(ctx, "xn--e1afmkfd.xn--p1ai")
+	require.NoError(t, err)
+	assert.Len(t, zones, 1)
+
+	records, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "пример.рф"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "сайт", records[0].Name)
+
+	record, err := client.AddRR(ctx, "пример.рф", CreateDNSRecordParams{Name: "сайт", Type: RecordTypeA, Content: "192.0.2.2"})
+	require.NoError(t, err)
+	assert.Equal(t, "сайт", record.Name)
+
+	require.NoError(t, client.DeleteRR(ctx, "пример.рф", records[0]))
+
+	require.Len(t, *calls, 5)
+	domains := (*calls)[2].Input["domains"].([]interface{})
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", domains[0].(map[string]interface{})["dname"])
+	assert.Equal(t, "xn--80aswg", (*calls)[3].Input["subdomain"])
+	domains = (*calls)[3].Input["domains"].([]interface{})
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", domains[0].(map[string]interface{})["dname"])
+	assert.Equal(t, "xn--80aswg", (*calls)[4].Input["subdomain"])
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImportWarning describes a record that was skipped or changed while
+// converting data exported from another DNS provider.
+type ImportWarning struct {
+	Name    string
+	Type    RecordType
+	Message string
+}
+
+// String returns a human-readable description of the warning.
+func (w ImportWarning) String() string {
+	return fmt.Sprintf("%s %s: %s", w.Name, w.Type, w.Message)
+}
+
+// ImportResult contains records converted from another DNS provider's export.
+type ImportResult struct {
+	// Records contains the converted records, with names relative to the zone.
+	Records []DNSRecord
+	// Warnings lists records that were skipped or could not be converted exactly.
+	Warnings []ImportWarning
+}
+
+// Params returns the converted records as parameters for AddRR.
+func (r *ImportResult) Params() []CreateDNSRecordParams {
+	params := make([]CreateDNSRecordParams, 0, len(r.Records))
+	for _, record := range r.Records {
+		params = append(params, paramsFromRecord(record))
+	}
+	return params
+}
+
+// ImportOption represents an option for configuring record importers.
+type ImportOption func(*importOptions)
+
+// importOptions holds settings shared by record importers.
+type importOptions struct {
+	resolve func(host string) ([]string, error)
+}
+
+// WithAliasResolver sets a function resolving a hostname to IP addresses.
+// Importers use it to flatten provider-specific alias records that cannot be
+// expressed as CNAME (for example at the zone apex) into A/AAAA records.
+func WithAliasResolver(resolve func(host string) ([]string, error)) ImportOption {
+	return func(o *importOptions) {
+		o.resolve = resolve
+	}
+}
+
+// newImportOptions applies the options over the defaults.
+func newImportOptions(opts []ImportOption) importOptions {
+	var o importOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// warn adds a warning for the record.
+func (r *ImportResult) warn(rr DNSRecord, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, ImportWarning{
+		Name:    rr.Name,
+		Type:    rr.Type,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// add appends the record, or records a warning if it cannot be managed at reg.ru.
+func (r *ImportResult) add(rr DNSRecord) {
+	rr.Type = rr.Type.Canonical()
+	switch {
+	case strings.HasSuffix(rr.Name, "."):
+		r.warn(rr, "record is outside of the zone, skipped")
+	case !rr.Type.Supported():
+		r.warn(rr, "record type is not supported, skipped")
+	case rr.Type == RecordTypeNS && canonicalName(rr.Name) == "@":
+		r.warn(rr, "apex NS records are managed by reg.ru, skipped")
+	default:
+		r.Records = append(r.Records, rr)
+	}
+}
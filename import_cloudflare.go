@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// cloudflareAutoTTL is the TTL value Cloudflare uses for "automatic".
+const cloudflareAutoTTL = 1
+
+// CloudflareRecord represents a DNS record in Cloudflare API format.
+type CloudflareRecord struct {
+	ID       string               `json:"id,omitempty"`
+	Type     string               `json:"type"`
+	Name     string               `json:"name"`
+	Content  string               `json:"content"`
+	Proxied  bool                 `json:"proxied,omitempty"`
+	TTL      int                  `json:"ttl,omitempty"`
+	Priority *int                 `json:"priority,omitempty"`
+	Data     *CloudflareSRVRecord `json:"data,omitempty"`
+}
+
+// CloudflareSRVRecord represents structured SRV data in Cloudflare API format.
+type CloudflareSRVRecord struct {
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+	Target   string `json:"target"`
+}
+
+// cloudflareListResponse represents a Cloudflare list DNS records API response.
+type cloudflareListResponse struct {
+	Result []CloudflareRecord `json:"result"`
+}
+
+// ImportCloudflareJSON converts Cloudflare DNS records in JSON format for the
+// zone. Both the list DNS records API response ({"result": [...]}) and a plain
+// array of records are accepted. Proxied records are imported as plain DNS
+// records with a warning, since reg.ru has no proxying; unsupported types are
+// skipped with a warning.
+func ImportCloudflareJSON(r io.Reader, zone string) (ImportResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	var records []CloudflareRecord
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &records)
+	} else {
+		var resp cloudflareListResponse
+		err = json.Unmarshal(trimmed, &resp)
+		records = resp.Result
+	}
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to parse Cloudflare export: %w", err)
+	}
+
+	var result ImportResult
+	for _, cf := range records {
+		rr := DNSRecord{
+			Name:    relativeName(cf.Name+".", zone),
+			Type:    RecordType(cf.Type).Canonical(),
+			Content: cf.Content,
+		}
+		if cf.TTL != cloudflareAutoTTL {
+			rr.TTL = cf.TTL
+		}
+
+		switch rr.Type {
+		case RecordTypeMX:
+			if cf.Priority != nil {
+				rr.Content = fmt.Sprintf("%d %s", *cf.Priority, cf.Content)
+			}
+		case RecordTypeSRV:
+			if cf.Data != nil {
+				rr.Content = fmt.Sprintf("%d %d %d %s", cf.Data.Priority, cf.Data.Weight, cf.Data.Port, cf.Data.Target)
+			} else if cf.Priority != nil {
+				rr.Content = fmt.Sprintf("%d %s", *cf.Priority, cf.Content)
+			}
+		}
+
+		if cf.Proxied {
+			result.warn(rr, "Cloudflare proxying is not available at reg.ru, record will point to the origin directly")
+		}
+		result.add(rr)
+	}
+
+	return result, nil
+}
+
+// ImportCloudflareBIND converts a Cloudflare BIND zone file export for the zone.
+// Records tagged as proxied ("cf_tags=cf-proxied:true") are imported as plain
+// DNS records with a warning; SOA and unsupported types are skipped with a warning.
+func ImportCloudflareBIND(r io.Reader, zone string) (ImportResult, error) {
+	records, err := ParseZoneFile(r, zone)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	var result ImportResult
+	for _, record := range records {
+		if record.TTL == cloudflareAutoTTL {
+			record.TTL = 0
+		}
+		if strings.Contains(record.Comment, "cf-proxied:true") {
+			result.warn(record.DNSRecord, "Cloudflare proxying is not available at reg.ru, record will point to the origin directly")
+		}
+		result.add(record.DNSRecord)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Route53RecordSet represents a resource record set in AWS Route53 API format.
+type Route53RecordSet struct {
+	Name            string                  `json:"Name"`
+	Type            string                  `json:"Type"`
+	TTL             int                     `json:"TTL,omitempty"`
+	ResourceRecords []Route53ResourceRecord `json:"ResourceRecords,omitempty"`
+	AliasTarget     *Route53AliasTarget     `json:"AliasTarget,omitempty"`
+	SetIdentifier   string                  `json:"SetIdentifier,omitempty"`
+}
+
+// Route53ResourceRecord represents a single value of a Route53 record set.
+type Route53ResourceRecord struct {
+	Value string `json:"Value"`
+}
+
+// Route53AliasTarget represents the target of a Route53 alias record.
+type Route53AliasTarget struct {
+	HostedZoneID string `json:"HostedZoneId"`
+	DNSName      string `json:"DNSName"`
+}
+
+// route53ListResponse represents the output of ListResourceRecordSets.
+type route53ListResponse struct {
+	ResourceRecordSets []Route53RecordSet `json:"ResourceRecordSets"`
+}
+
+// ImportRoute53JSON converts the JSON output of the Route53 ListResourceRecordSets
+// API (as printed by "aws route53 list-resource-record-sets") for the zone.
+//
+// Alias records have no equivalent at reg.ru: outside the apex they are
+// flattened to a CNAME pointing at the alias target; at the apex they are
+// resolved to A/AAAA records if WithAliasResolver is given and skipped otherwise.
+// Both cases produce warnings, as do dropped routing policies and unsupported types.
+func ImportRoute53JSON(r io.Reader, zone string, opts ...ImportOption) (ImportResult, error) {
+	o := newImportOptions(opts)
+
+	var resp route53ListResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return ImportResult{}, fmt.Errorf("failed to parse Route53 export: %w", err)
+	}
+
+	var result ImportResult
+	for _, set := range resp.ResourceRecordSets {
+		base := DNSRecord{
+			Name: relativeName(unescapeRoute53Name(set.Name), zone),
+			Type: RecordType(set.Type).Canonical(),
+			TTL:  set.TTL,
+		}
+
+		if set.SetIdentifier != "" {
+			result.warn(base, "routing policy %q is not available at reg.ru, record imported as a plain record", set.SetIdentifier)
+		}
+
+		if set.AliasTarget != nil {
+			importRoute53Alias(&result, base, set.AliasTarget, o)
+			continue
+		}
+
+		for _, value := range set.ResourceRecords {
+			rr := base
+			rr.Content = value.Value
+			if rr.Type == RecordTypeTXT || rr.Type == RecordTypeSPF {
+				rr.Content = canonicalTXT(rr.Content)
+			}
+			result.add(rr)
+		}
+	}
+
+	return result, nil
+}
+
+// importRoute53Alias flattens a Route53 alias record.
+func importRoute53Alias(result *ImportResult, base DNSRecord, alias *Route53AliasTarget, o importOptions) {
+	target := alias.DNSName
+
+	if canonicalName(base.Name) != "@" {
+		rr := base
+		rr.Type = RecordTypeCNAME
+		rr.Content = target
+		result.warn(rr, "alias record to %s converted to CNAME", target)
+		result.add(rr)
+		return
+	}
+
+	if o.resolve == nil {
+		result.warn(base, "alias record to %s at the zone apex cannot be expressed as CNAME, skipped", target)
+		return
+	}
+
+	addresses, err := o.resolve(strings.TrimSuffix(target, "."))
+	if err != nil {
+		result.warn(base, "failed to resolve alias target %s: %v", target, err)
+		return
+	}
+
+	result.warn(base, "alias record to %s flattened to its current addresses", target)
+	for _, address := range addresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			continue
+		}
+		rr := base
+		rr.Content = ip.String()
+		rr.Type = RecordTypeAAAA
+		if ip.To4() != nil {
+			rr.Type = RecordTypeA
+		}
+		// An alias of type A only yields IPv4 addresses and vice versa
+		if rr.Type == base.Type {
+			result.add(rr)
+		}
+	}
+}
+
+// unescapeRoute53Name decodes octal escapes (\052 for "*") used by Route53 in names.
+func unescapeRoute53Name(name string) string {
+	if !strings.Contains(name, `\`) {
+		return name
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+4 <= len(name) {
+			if code, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
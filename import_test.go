@@ -0,0 +1,192 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportResult_Params(t *testing.T) {
+	result := ImportResult{
+		Records: []DNSRecord{{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300}},
+	}
+
+	params := result.Params()
+	require.Len(t, params, 1)
+	assert.Equal(t, CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300}, params[0])
+}
+
+func TestImportCloudflareJSON(t *testing.T) {
+	data := `{
+		"success": true,
+		"result": [
+			{"type": "A", "name": "example.com", "content": "192.0.2.1", "proxied": true, "ttl": 1},
+			{"type": "CNAME", "name": "www.example.com", "content": "example.com", "proxied": false, "ttl": 300},
+			{"type": "MX", "name": "example.com", "content": "mail.example.com", "priority": 10, "ttl": 3600},
+			{"type": "SRV", "name": "_sip._tcp.example.com", "content": "0 5060 sip.example.com",
+			 "data": {"priority": 10, "weight": 0, "port": 5060, "target": "sip.example.com"}, "ttl": 1},
+			{"type": "HTTPS", "name": "example.com", "content": "1 . alpn=h2", "ttl": 1}
+		]
+	}`
+
+	result, err := ImportCloudflareJSON(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 4)
+
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"}, result.Records[0], "automatic TTL maps to the default")
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "example.com", TTL: 300}, result.Records[1])
+	assert.Equal(t, "10 mail.example.com", result.Records[2].Content)
+	assert.Equal(t, "10 0 5060 sip.example.com", result.Records[3].Content)
+	assert.Equal(t, "_sip._tcp", result.Records[3].Name)
+
+	require.Len(t, result.Warnings, 2)
+	assert.Contains(t, result.Warnings[0].Message, "proxying")
+	assert.Equal(t, RecordType("HTTPS"), result.Warnings[1].Type)
+}
+
+func TestImportCloudflareJSON_Array(t *testing.T) {
+	data := `[{"type": "TXT", "name": "example.com", "content": "hello", "ttl": 120}]`
+
+	result, err := ImportCloudflareJSON(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, RecordTypeTXT, result.Records[0].Type)
+}
+
+func TestImportCloudflareJSON_Invalid(t *testing.T) {
+	_, err := ImportCloudflareJSON(strings.NewReader("{"), "example.com")
+	require.Error(t, err)
+}
+
+func TestImportCloudflareBIND(t *testing.T) {
+	data := `;; Domain:     example.com.
+example.com.	3600	IN	SOA	ns.cloudflare.com. dns.cloudflare.com. 2045974385 10000 2400 604800 3600
+example.com.	1	IN	A	192.0.2.1 ; cf_tags=cf-proxied:true
+www.example.com.	1	IN	CNAME	example.com. ; cf_tags=cf-proxied:false
+`
+
+	result, err := ImportCloudflareBIND(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2)
+	assert.Equal(t, "@", result.Records[0].Name)
+	assert.Equal(t, "www", result.Records[1].Name)
+	assert.Equal(t, 0, result.Records[1].TTL, "automatic TTL maps to the default")
+
+	require.Len(t, result.Warnings, 2)
+	assert.Contains(t, result.Warnings[0].Message, "not supported", "SOA should be skipped")
+	assert.Contains(t, result.Warnings[1].Message, "proxying")
+}
+
+func TestImportRoute53JSON(t *testing.T) {
+	data := `{
+		"ResourceRecordSets": [
+			{"Name": "example.com.", "Type": "NS", "TTL": 172800,
+			 "ResourceRecords": [{"Value": "ns-1.awsdns-00.com."}]},
+			{"Name": "example.com.", "Type": "A",
+			 "AliasTarget": {"HostedZoneId": "Z2FDTNDATAQYW2", "DNSName": "d111111abcdef8.cloudfront.net.", "EvaluateTargetHealth": false}},
+			{"Name": "www.example.com.", "Type": "A",
+			 "AliasTarget": {"HostedZoneId": "Z2FDTNDATAQYW2", "DNSName": "d111111abcdef8.cloudfront.net.", "EvaluateTargetHealth": false}},
+			{"Name": "\\052.example.com.", "Type": "A", "TTL": 300,
+			 "ResourceRecords": [{"Value": "192.0.2.1"}, {"Value": "192.0.2.2"}]},
+			{"Name": "example.com.", "Type": "TXT", "TTL": 300,
+			 "ResourceRecords": [{"Value": "\"v=spf1 -all\""}]},
+			{"Name": "api.example.com.", "Type": "A", "TTL": 60, "SetIdentifier": "eu", "Weight": 10,
+			 "ResourceRecords": [{"Value": "192.0.2.3"}]}
+		]
+	}`
+
+	result, err := ImportRoute53JSON(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 5)
+
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "d111111abcdef8.cloudfront.net."}, result.Records[0])
+	assert.Equal(t, "*", result.Records[1].Name)
+	assert.Equal(t, "192.0.2.2", result.Records[2].Content)
+	assert.Equal(t, "v=spf1 -all", result.Records[3].Content)
+	assert.Equal(t, "api", result.Records[4].Name)
+
+	require.Len(t, result.Warnings, 4)
+	assert.Contains(t, result.Warnings[0].Message, "apex NS")
+	assert.Contains(t, result.Warnings[1].Message, "apex")
+	assert.Contains(t, result.Warnings[2].Message, "CNAME")
+	assert.Contains(t, result.Warnings[3].Message, "routing policy")
+}
+
+func TestImportRoute53JSON_AliasResolver(t *testing.T) {
+	data := `{
+		"ResourceRecordSets": [
+			{"Name": "example.com.", "Type": "A",
+			 "AliasTarget": {"HostedZoneId": "Z2FDTNDATAQYW2", "DNSName": "lb.example.net."}}
+		]
+	}`
+
+	resolve := func(host string) ([]string, error) {
+		assert.Equal(t, "lb.example.net", host)
+		return []string{"192.0.2.10", "2001:db8::10", "192.0.2.11"}, nil
+	}
+
+	result, err := ImportRoute53JSON(strings.NewReader(data), "example.com", WithAliasResolver(resolve))
+	require.NoError(t, err)
+	require.Len(t, result.Records, 2, "only IPv4 addresses are used for an A alias")
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeA, Content: "192.0.2.10"}, result.Records[0])
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0].Message, "flattened")
+}
+
+func TestUnescapeRoute53Name(t *testing.T) {
+	assert.Equal(t, "*.example.com.", unescapeRoute53Name(`\052.example.com.`))
+	assert.Equal(t, "www.example.com.", unescapeRoute53Name("www.example.com."))
+	assert.Equal(t, `a\0`, unescapeRoute53Name(`a\0`))
+}
+
+func TestImportYandexCloudJSON(t *testing.T) {
+	data := `[
+		{"name": "example.com.", "type": "SOA", "ttl": "3600",
+		 "data": ["ns1.yandexcloud.net. mx.cloud.yandex.net. 1 10800 900 604800 900"]},
+		{"name": "example.com.", "type": "NS", "ttl": "3600", "data": ["ns1.yandexcloud.net."]},
+		{"name": "www.example.com.", "type": "A", "ttl": "600", "data": ["192.0.2.1", "192.0.2.2"]},
+		{"name": "example.com.", "type": "TXT", "ttl": 300, "data": ["\"v=spf1 -all\""]}
+	]`
+
+	result, err := ImportYandexCloudJSON(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 3)
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 600}, result.Records[0])
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 -all", TTL: 300}, result.Records[2])
+	assert.Len(t, result.Warnings, 2)
+}
+
+func TestImportYandexCloudJSON_APIResponse(t *testing.T) {
+	data := `{"recordSets": [{"name": "mail.example.com.", "type": "MX", "ttl": "600", "data": ["10 mx.yandex.net."]}]}`
+
+	result, err := ImportYandexCloudJSON(strings.NewReader(data), "example.com")
+	require.NoError(t, err)
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, "mail", result.Records[0].Name)
+	assert.Equal(t, "10 mx.yandex.net.", result.Records[0].Content)
+}
+
+func TestImportYandexCloudJSON_InvalidTTL(t *testing.T) {
+	data := `[{"name": "www.example.com.", "type": "A", "ttl": "soon", "data": ["192.0.2.1"]}]`
+
+	_, err := ImportYandexCloudJSON(strings.NewReader(data), "example.com")
+	require.Error(t, err)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// YandexRecordSet represents a record set in Yandex Cloud DNS API format.
+type YandexRecordSet struct {
+	Name string    `json:"name"`
+	Type string    `json:"type"`
+	TTL  yandexTTL `json:"ttl"`
+	Data []string  `json:"data"`
+}
+
+// yandexTTL is a TTL that Yandex Cloud encodes either as a number or as a string.
+type yandexTTL int
+
+// UnmarshalJSON decodes a TTL given as a JSON number or string.
+func (t *yandexTTL) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*t = 0
+		return nil
+	}
+	ttl, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid ttl %s: %w", data, err)
+	}
+	*t = yandexTTL(ttl)
+	return nil
+}
+
+// yandexListResponse represents the Yandex Cloud DNS ListRecordSets API response.
+type yandexListResponse struct {
+	RecordSets []YandexRecordSet `json:"recordSets"`
+}
+
+// ImportYandexCloudJSON converts Yandex Cloud DNS record sets for the zone. Both
+// the ListRecordSets API response ({"recordSets": [...]}) and the array printed
+// by "yc dns zone list-records --format json" are accepted. SOA, apex NS and
+// unsupported types are skipped with a warning.
+func ImportYandexCloudJSON(r io.Reader, zone string) (ImportResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	var sets []YandexRecordSet
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &sets)
+	} else {
+		var resp yandexListResponse
+		err = json.Unmarshal(trimmed, &resp)
+		sets = resp.RecordSets
+	}
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to parse Yandex Cloud DNS export: %w", err)
+	}
+
+	var result ImportResult
+	for _, set := range sets {
+		for _, value := range set.Data {
+			rr := DNSRecord{
+				Name:    relativeName(set.Name, zone),
+				Type:    RecordType(set.Type).Canonical(),
+				Content: value,
+				TTL:     int(set.TTL),
+			}
+			if rr.Type == RecordTypeTXT || rr.Type == RecordTypeSPF {
+				rr.Content = canonicalTXT(rr.Content)
+			}
+			result.add(rr)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,232 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command apigen generates the reg.ru API request and response types from
+// schema.json. Field naming quirks of the API (dname vs domain, servtype vs
+// service_type, numbers returned as strings) are described in the schema, so
+// adding an endpoint only requires a schema entry. Run it via go generate in
+// the repository root.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+// Schema describes the generated types.
+type Schema struct {
+	Types   []Type   `json:"types"`
+	Getters []Getter `json:"getters"`
+}
+
+// Type describes a struct type.
+type Type struct {
+	Name   string   `json:"name"`
+	Doc    []string `json:"doc"`
+	Embed  []string `json:"embed,omitempty"`
+	Fields []Field  `json:"fields,omitempty"`
+}
+
+// Field describes a struct field.
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// JSON is the json struct tag value, such as "dname,omitempty".
+	JSON    string `json:"json"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Getter describes an accessor method generated for a type.
+//
+// Kinds:
+//   - "first" returns the first non-empty string of Fields, for values the
+//     API returns under different names depending on the method;
+//   - "string" formats the single interface{} field in Fields as a string,
+//     for values the API returns either as numbers or as strings.
+type Getter struct {
+	Type   string   `json:"type"`
+	Name   string   `json:"name"`
+	Doc    string   `json:"doc"`
+	Kind   string   `json:"kind"`
+	Fields []string `json:"fields"`
+}
+
+// header is prepended to the generated file.
+const header = `/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by apigen from internal/apigen/schema.json. DO NOT EDIT.
+
+`
+
+func main() {
+	schemaPath := flag.String("schema", "internal/apigen/schema.json", "path to the schema")
+	outPath := flag.String("out", "api_types_gen.go", "path to the generated file")
+	pkg := flag.String("package", "regru", "package name of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		log.Fatalf("failed to parse schema: %v", err)
+	}
+
+	src, err := Generate(schema, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Generate returns the formatted Go source for the schema.
+func Generate(schema Schema, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	var imports []string
+	if usesType(schema, "json.") {
+		imports = append(imports, `"encoding/json"`)
+	}
+	for _, getter := range schema.Getters {
+		if getter.Kind == "string" {
+			imports = append(imports, `"fmt"`)
+			break
+		}
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+
+	types := make(map[string]bool, len(schema.Types))
+	for _, t := range schema.Types {
+		types[t.Name] = true
+		writeType(&buf, t)
+	}
+
+	for _, getter := range schema.Getters {
+		if !types[getter.Type] {
+			return nil, fmt.Errorf("getter %s: unknown type %s", getter.Name, getter.Type)
+		}
+		if err := writeGetter(&buf, getter); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// usesType reports whether any field type contains the substring.
+func usesType(schema Schema, substr string) bool {
+	for _, t := range schema.Types {
+		for _, field := range t.Fields {
+			if strings.Contains(field.Type, substr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeType writes a struct type declaration.
+func writeType(buf *bytes.Buffer, t Type) {
+	for _, line := range t.Doc {
+		fmt.Fprintf(buf, "// %s\n", line)
+	}
+	fmt.Fprintf(buf, "type %s struct {\n", t.Name)
+	for _, embed := range t.Embed {
+		fmt.Fprintf(buf, "%s\n", embed)
+	}
+	for _, field := range t.Fields {
+		fmt.Fprintf(buf, "%s %s `json:\"%s\"`", field.Name, field.Type, field.JSON)
+		if field.Comment != "" {
+			fmt.Fprintf(buf, " // %s", field.Comment)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+// writeGetter writes an accessor method.
+func writeGetter(buf *bytes.Buffer, g Getter) error {
+	if len(g.Fields) == 0 {
+		return fmt.Errorf("getter %s: no fields", g.Name)
+	}
+
+	recv := strings.ToLower(g.Type[:1])
+	fmt.Fprintf(buf, "// %s\nfunc (%s *%s) %s() string {\n", g.Doc, recv, g.Type, g.Name)
+
+	switch g.Kind {
+	case "first":
+		last := len(g.Fields) - 1
+		for _, field := range g.Fields[:last] {
+			fmt.Fprintf(buf, "if %[1]s.%[2]s != \"\" {\nreturn %[1]s.%[2]s\n}\n", recv, field)
+		}
+		fmt.Fprintf(buf, "return %s.%s\n", recv, g.Fields[last])
+	case "string":
+		if len(g.Fields) != 1 {
+			return fmt.Errorf("getter %s: kind string requires exactly one field", g.Name)
+		}
+		fmt.Fprintf(buf, `switch v := %s.%s.(type) {
+case nil:
+return ""
+case int:
+return fmt.Sprintf("%%d", v)
+case float64:
+return fmt.Sprintf("%%.0f", v)
+case string:
+return v
+default:
+return fmt.Sprintf("%%v", v)
+}
+`, recv, g.Fields[0])
+	default:
+		return fmt.Errorf("getter %s: unknown kind %q", g.Name, g.Kind)
+	}
+
+	buf.WriteString("}\n\n")
+	return nil
+}
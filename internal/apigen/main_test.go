@@ -0,0 +1,74 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadSchema(t *testing.T) Schema {
+	t.Helper()
+
+	data, err := os.ReadFile("schema.json")
+	require.NoError(t, err)
+
+	var schema Schema
+	require.NoError(t, json.Unmarshal(data, &schema))
+	return schema
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	src, err := Generate(loadSchema(t), "regru")
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("../../api_types_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(current), string(src), "api_types_gen.go is stale, run go generate")
+}
+
+func TestGenerate_Getters(t *testing.T) {
+	schema := Schema{
+		Types: []Type{{Name: "Item", Doc: []string{"Item is a test type."}, Fields: []Field{
+			{Name: "Name", Type: "string", JSON: "name,omitempty"},
+			{Name: "DName", Type: "string", JSON: "dname,omitempty"},
+			{Name: "ID", Type: "interface{}", JSON: "id,omitempty"},
+		}}},
+		Getters: []Getter{
+			{Type: "Item", Name: "GetName", Doc: "GetName returns the name.", Kind: "first", Fields: []string{"Name", "DName"}},
+			{Type: "Item", Name: "GetID", Doc: "GetID returns the ID.", Kind: "string", Fields: []string{"ID"}},
+		},
+	}
+
+	src, err := Generate(schema, "test")
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "func (i *Item) GetName() string {\n\tif i.Name != \"\" {")
+	assert.Contains(t, string(src), "switch v := i.ID.(type) {")
+	assert.Contains(t, string(src), "\"fmt\"")
+
+	schema.Getters[0].Kind = "unknown"
+	_, err = Generate(schema, "test")
+	assert.Error(t, err)
+
+	schema.Getters[0].Type = "Missing"
+	_, err = Generate(schema, "test")
+	assert.Error(t, err)
+}
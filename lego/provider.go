@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lego adapts the reg.ru client to the DNS-01 provider interface of
+// go-acme/lego (challenge.Provider and challenge.ProviderTimeout), so lego,
+// Traefik and other lego-based tools can obtain certificates for reg.ru zones.
+//
+// The package does not import lego; Provider satisfies its interfaces by
+// method set:
+//
+//	provider := lego.NewProvider(regru.NewClient(username, password))
+//	err := legoClient.Challenge.SetDNS01Provider(provider)
+package lego
+
+import (
+	"context"
+	"time"
+
+	"github.com/mixanemca/regru-go/acme"
+)
+
+const (
+	// DefaultPropagationTimeout is how long lego waits for challenge records to propagate.
+	DefaultPropagationTimeout = 10 * time.Minute
+	// DefaultPollingInterval is the delay between lego's propagation checks.
+	DefaultPollingInterval = 10 * time.Second
+	// DefaultTimeout bounds the API calls of a single Present or CleanUp.
+	DefaultTimeout = 60 * time.Second
+)
+
+// Provider implements lego's challenge.Provider and challenge.ProviderTimeout.
+type Provider struct {
+	solver             *acme.Solver
+	ttl                int
+	propagationTimeout time.Duration
+	pollingInterval    time.Duration
+	timeout            time.Duration
+}
+
+// Option represents an option for configuring the provider.
+type Option func(*Provider)
+
+// WithTTL sets the TTL in seconds of challenge records (acme.DefaultTTL by default).
+func WithTTL(ttl int) Option {
+	return func(p *Provider) {
+		p.ttl = ttl
+	}
+}
+
+// WithPropagationTimeout sets how long lego waits for challenge records to propagate.
+func WithPropagationTimeout(timeout time.Duration) Option {
+	return func(p *Provider) {
+		p.propagationTimeout = timeout
+	}
+}
+
+// WithPollingInterval sets the delay between lego's propagation checks.
+func WithPollingInterval(interval time.Duration) Option {
+	return func(p *Provider) {
+		p.pollingInterval = interval
+	}
+}
+
+// WithTimeout sets the maximum duration of the API calls of a single Present or CleanUp.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Provider) {
+		p.timeout = timeout
+	}
+}
+
+// NewProvider creates a new lego DNS-01 provider backed by the client.
+func NewProvider(client acme.Client, opts ...Option) *Provider {
+	p := &Provider{
+		ttl:                acme.DefaultTTL,
+		propagationTimeout: DefaultPropagationTimeout,
+		pollingInterval:    DefaultPollingInterval,
+		timeout:            DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.solver = acme.NewSolver(client, acme.WithTTL(p.ttl))
+
+	return p
+}
+
+// Present publishes the challenge record for the domain.
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	return p.solver.Present(ctx, domain, keyAuth)
+}
+
+// CleanUp removes the challenge record of the domain for the key
+// authorization, leaving records of concurrent challenges for the same name,
+// such as those of a wildcard and its base domain.
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	return p.solver.CleanUpValue(ctx, domain, acme.ChallengeValue(keyAuth))
+}
+
+// Timeout returns the propagation timeout and polling interval used by lego.
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.propagationTimeout, p.pollingInterval
+}
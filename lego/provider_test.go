@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lego
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/mixanemca/regru-go/acme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps the records of its zones in memory.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient(zones ...string) *fakeClient {
+	f := &fakeClient{records: make(map[string][]regru.DNSRecord)}
+	for _, zone := range zones {
+		f.zones = append(f.zones, regru.Zone{Name: zone})
+	}
+	return f
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	record := regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}
+	f.records[zone] = append(f.records[zone], record)
+	return record, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+// challengeProvider mirrors lego's challenge.Provider and challenge.ProviderTimeout.
+type challengeProvider interface {
+	Present(domain, token, keyAuth string) error
+	CleanUp(domain, token, keyAuth string) error
+	Timeout() (timeout, interval time.Duration)
+}
+
+var _ challengeProvider = (*Provider)(nil)
+
+func TestProvider_PresentAndCleanUp(t *testing.T) {
+	client := newFakeClient("example.com")
+	provider := NewProvider(client, WithTTL(300))
+
+	require.NoError(t, provider.Present("www.example.com", "token", "key-auth"))
+	records := client.records["example.com"]
+	require.Len(t, records, 1)
+	assert.Equal(t, "_acme-challenge.www", records[0].Name)
+	assert.Equal(t, regru.RecordTypeTXT, records[0].Type)
+	assert.Equal(t, acme.ChallengeValue("key-auth"), records[0].Content)
+	assert.Equal(t, 300, records[0].TTL)
+
+	require.NoError(t, provider.CleanUp("www.example.com", "token", "key-auth"))
+	assert.Equal(t, records, client.deleted)
+}
+
+func TestProvider_CleanUp_ConcurrentChallenges(t *testing.T) {
+	client := newFakeClient("example.com")
+	provider := NewProvider(client)
+
+	require.NoError(t, provider.Present("example.com", "token", "key-auth-base"))
+	require.NoError(t, provider.Present("*.example.com", "token", "key-auth-wildcard"))
+	require.Len(t, client.records["example.com"], 2)
+
+	require.NoError(t, provider.CleanUp("*.example.com", "token", "key-auth-wildcard"))
+	require.Len(t, client.deleted, 1)
+	assert.Equal(t, acme.ChallengeValue("key-auth-wildcard"), client.deleted[0].Content)
+}
+
+func TestProvider_UnknownZone(t *testing.T) {
+	provider := NewProvider(newFakeClient("example.com"))
+	assert.Error(t, provider.Present("example.org", "token", "key-auth"))
+}
+
+func TestProvider_Timeout(t *testing.T) {
+	timeout, interval := NewProvider(newFakeClient()).Timeout()
+	assert.Equal(t, DefaultPropagationTimeout, timeout)
+	assert.Equal(t, DefaultPollingInterval, interval)
+
+	timeout, interval = NewProvider(newFakeClient(),
+		WithPropagationTimeout(time.Minute), WithPollingInterval(time.Second)).Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package libdns implements the libdns interfaces (RecordGetter, RecordAppender,
+// RecordSetter, RecordDeleter and ZoneLister) on top of the reg.ru client, so
+// Caddy and other libdns consumers can manage reg.ru zones:
+//
+//	provider := libdns.NewProvider(regru.NewClient(username, password))
+//	records, err := provider.GetRecords(ctx, "example.com.")
+//
+// The reg.ru API has no batch changes, so SetRecords is not atomic: on error
+// the changes made before the failing call remain in place.
+package libdns
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/libdns/libdns"
+	"github.com/mixanemca/regru-go"
+)
+
+// Client is the subset of regru.Client methods used by the provider.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// Provider implements the libdns interfaces for reg.ru zones.
+type Provider struct {
+	client Client
+}
+
+var (
+	_ libdns.RecordGetter   = (*Provider)(nil)
+	_ libdns.RecordAppender = (*Provider)(nil)
+	_ libdns.RecordSetter   = (*Provider)(nil)
+	_ libdns.RecordDeleter  = (*Provider)(nil)
+	_ libdns.ZoneLister     = (*Provider)(nil)
+)
+
+// NewProvider creates a new libdns provider backed by the client.
+func NewProvider(client Client) *Provider {
+	return &Provider{client: client}
+}
+
+// ListZones returns the zones of the account as fully qualified names.
+func (p *Provider) ListZones(ctx context.Context) ([]libdns.Zone, error) {
+	zones, err := p.client.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]libdns.Zone, 0, len(zones))
+	for _, z := range zones {
+		result = append(result, libdns.Zone{Name: z.Name + "."})
+	}
+	return result, nil
+}
+
+// GetRecords returns all records of the zone.
+func (p *Provider) GetRecords(ctx context.Context, zone string) ([]libdns.Record, error) {
+	current, err := p.list(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]libdns.Record, 0, len(current))
+	for _, rr := range current {
+		result = append(result, toLibdns(rr))
+	}
+	return result, nil
+}
+
+// AppendRecords creates the records in the zone and returns the created records.
+func (p *Provider) AppendRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	zone = zoneName(zone)
+
+	var result []libdns.Record
+	for _, rec := range recs {
+		created, err := p.add(ctx, zone, fromLibdns(rec.RR()))
+		if err != nil {
+			return result, err
+		}
+		result = append(result, created)
+	}
+	return result, nil
+}
+
+// SetRecords makes the given records the only members of their RRsets
+// (records with the same name and type) and returns the records that were set.
+// Records already present with the same TTL are left untouched.
+func (p *Provider) SetRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	current, err := p.list(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	zone = zoneName(zone)
+
+	type rrset struct {
+		name       string
+		recordType regru.RecordType
+	}
+	desired := make([]regru.DNSRecord, 0, len(recs))
+	sets := make(map[rrset]bool, len(recs))
+	for _, rec := range recs {
+		rr := regru.Canonicalize(fromLibdns(rec.RR()))
+		desired = append(desired, rr)
+		sets[rrset{rr.Name, rr.Type}] = true
+	}
+
+	kept := make([]bool, len(desired))
+	for _, rr := range current {
+		c := regru.Canonicalize(rr)
+		if !sets[rrset{c.Name, c.Type}] {
+			continue
+		}
+		if i := indexOf(desired, rr); i >= 0 && !kept[i] {
+			kept[i] = true
+			continue
+		}
+		if err := p.client.DeleteRR(ctx, zone, rr); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]libdns.Record, 0, len(desired))
+	for i, rr := range desired {
+		if kept[i] {
+			result = append(result, toLibdns(rr))
+			continue
+		}
+		created, err := p.add(ctx, zone, rr)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, created)
+	}
+	return result, nil
+}
+
+// DeleteRecords deletes the zone records matching the input and returns the
+// deleted records. Empty type, zero TTL and empty data match any value.
+func (p *Provider) DeleteRecords(ctx context.Context, zone string, recs []libdns.Record) ([]libdns.Record, error) {
+	current, err := p.list(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	zone = zoneName(zone)
+
+	deleted := make([]bool, len(current))
+	var result []libdns.Record
+	for _, rec := range recs {
+		for i, rr := range current {
+			if deleted[i] || !matches(rr, rec.RR()) {
+				continue
+			}
+			if err := p.client.DeleteRR(ctx, zone, rr); err != nil {
+				return result, err
+			}
+			deleted[i] = true
+			result = append(result, toLibdns(rr))
+		}
+	}
+	return result, nil
+}
+
+// list returns the records of the zone.
+func (p *Provider) list(ctx context.Context, zone string) ([]regru.DNSRecord, error) {
+	return p.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zoneName(zone)})
+}
+
+// add creates the record and returns it as a libdns record.
+func (p *Provider) add(ctx context.Context, zone string, rr regru.DNSRecord) (libdns.Record, error) {
+	created, err := p.client.AddRR(ctx, zone, regru.CreateDNSRecordParams{
+		Name:    rr.Name,
+		Type:    rr.Type,
+		Content: rr.Content,
+		TTL:     rr.TTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created.TTL == 0 {
+		created.TTL = rr.TTL
+	}
+	return toLibdns(created), nil
+}
+
+// indexOf returns the index of the record with the same data and TTL, or -1.
+func indexOf(records []regru.DNSRecord, rr regru.DNSRecord) int {
+	for i, r := range records {
+		if r.Equal(rr) && (rr.TTL == 0 || r.TTL == rr.TTL) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matches reports whether the zone record matches the libdns deletion criteria.
+func matches(rr regru.DNSRecord, want libdns.RR) bool {
+	c := regru.Canonicalize(rr)
+	w := regru.Canonicalize(fromLibdns(want))
+
+	switch {
+	case c.Name != w.Name:
+		return false
+	case want.Type != "" && c.Type != w.Type:
+		return false
+	case want.TTL != 0 && rr.TTL != 0 && rr.TTL != w.TTL:
+		return false
+	case want.Data != "" && c.Content != w.Content:
+		return false
+	}
+	return true
+}
+
+// zoneName returns the zone name as used by the reg.ru API.
+func zoneName(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// targetFields maps record types to the index of the RDATA field holding a domain name.
+var targetFields = map[regru.RecordType]int{
+	regru.RecordTypeCNAME: 0,
+	regru.RecordTypeNS:    0,
+	regru.RecordTypePTR:   0,
+	regru.RecordTypeMX:    1,
+	regru.RecordTypeSRV:   3,
+}
+
+// toLibdns converts a reg.ru record into the libdns type for its record type.
+// Hostname targets are returned fully qualified.
+func toLibdns(rr regru.DNSRecord) libdns.Record {
+	c := regru.Canonicalize(rr)
+	data := c.Content
+	if i, ok := targetFields[c.Type]; ok {
+		fields := strings.Fields(data)
+		if i < len(fields) && fields[i] != "." {
+			fields[i] += "."
+		}
+		data = strings.Join(fields, " ")
+	}
+
+	record := libdns.RR{
+		Name: c.Name,
+		TTL:  time.Duration(rr.TTL) * time.Second,
+		Type: string(c.Type),
+		Data: data,
+	}
+	parsed, err := record.Parse()
+	if err != nil {
+		return record
+	}
+	return parsed
+}
+
+// fromLibdns converts a libdns record into a reg.ru record.
+func fromLibdns(rr libdns.RR) regru.DNSRecord {
+	recordType := regru.RecordType(strings.ToUpper(rr.Type))
+	content := rr.Data
+	if i, ok := targetFields[recordType]; ok {
+		fields := strings.Fields(content)
+		if i < len(fields) {
+			fields[i] = strings.TrimSuffix(fields[i], ".")
+		}
+		content = strings.Join(fields, " ")
+	}
+
+	return regru.DNSRecord{
+		Name:    rr.Name,
+		Type:    recordType,
+		Content: content,
+		TTL:     int(rr.TTL / time.Second),
+	}
+}
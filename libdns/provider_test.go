@@ -0,0 +1,159 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libdns
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/libdns/libdns"
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps the records of its zones in memory.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient(zone string, records ...regru.DNSRecord) *fakeClient {
+	return &fakeClient{
+		zones:   []regru.Zone{{Name: zone}},
+		records: map[string][]regru.DNSRecord{zone: records},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	f.added = append(f.added, params)
+	record := regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}
+	f.records[zone] = append(f.records[zone], record)
+	return record, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func testRecords() []regru.DNSRecord {
+	return []regru.DNSRecord{
+		{Name: "@", Type: regru.RecordTypeA, Content: "192.0.2.1", TTL: 3600},
+		{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.2", TTL: 3600},
+		{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.3", TTL: 3600},
+		{Name: "@", Type: regru.RecordTypeMX, Content: "mail.example.com", Priority: 10, TTL: 3600},
+		{Name: "@", Type: regru.RecordTypeTXT, Content: `"v=spf1 -all"`, TTL: 3600},
+	}
+}
+
+func TestProvider_ListZones(t *testing.T) {
+	zones, err := NewProvider(newFakeClient("example.com")).ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []libdns.Zone{{Name: "example.com."}}, zones)
+}
+
+func TestProvider_GetRecords(t *testing.T) {
+	records, err := NewProvider(newFakeClient("example.com", testRecords()...)).GetRecords(context.Background(), "example.com.")
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	assert.Equal(t, libdns.Address{Name: "@", TTL: time.Hour, IP: netip.MustParseAddr("192.0.2.1")}, records[0])
+	assert.Equal(t, libdns.MX{Name: "@", TTL: time.Hour, Preference: 10, Target: "mail.example.com."}, records[3])
+	assert.Equal(t, libdns.TXT{Name: "@", TTL: time.Hour, Text: "v=spf1 -all"}, records[4])
+}
+
+func TestProvider_AppendRecords(t *testing.T) {
+	client := newFakeClient("example.com")
+	created, err := NewProvider(client).AppendRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.TXT{Name: "_acme-challenge", TTL: time.Minute, Text: "token"},
+		libdns.CNAME{Name: "blog", TTL: time.Hour, Target: "example.net."},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []regru.CreateDNSRecordParams{
+		{Name: "_acme-challenge", Type: regru.RecordTypeTXT, Content: "token", TTL: 60},
+		{Name: "blog", Type: regru.RecordTypeCNAME, Content: "example.net", TTL: 3600},
+	}, client.added)
+	assert.Equal(t, []libdns.Record{
+		libdns.TXT{Name: "_acme-challenge", TTL: time.Minute, Text: "token"},
+		libdns.CNAME{Name: "blog", TTL: time.Hour, Target: "example.net."},
+	}, created)
+}
+
+func TestProvider_SetRecords(t *testing.T) {
+	client := newFakeClient("example.com", testRecords()...)
+	set, err := NewProvider(client).SetRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.Address{Name: "www", TTL: time.Hour, IP: netip.MustParseAddr("192.0.2.2")},
+		libdns.Address{Name: "www", TTL: time.Hour, IP: netip.MustParseAddr("192.0.2.4")},
+	})
+	require.NoError(t, err)
+	assert.Len(t, set, 2)
+
+	// Only the www A RRset changes: 192.0.2.3 is replaced by 192.0.2.4
+	assert.Equal(t, []regru.DNSRecord{testRecords()[2]}, client.deleted)
+	assert.Equal(t, []regru.CreateDNSRecordParams{
+		{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.4", TTL: 3600},
+	}, client.added)
+}
+
+func TestProvider_SetRecords_TTLChange(t *testing.T) {
+	client := newFakeClient("example.com", testRecords()...)
+	_, err := NewProvider(client).SetRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.Address{Name: "@", TTL: 5 * time.Minute, IP: netip.MustParseAddr("192.0.2.1")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []regru.DNSRecord{testRecords()[0]}, client.deleted)
+	require.Len(t, client.added, 1)
+	assert.Equal(t, 300, client.added[0].TTL)
+}
+
+func TestProvider_DeleteRecords(t *testing.T) {
+	client := newFakeClient("example.com", testRecords()...)
+	provider := NewProvider(client)
+
+	// Empty type, TTL and data match every record with the name
+	deleted, err := provider.DeleteRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.RR{Name: "www"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, deleted, 2)
+	assert.Equal(t, testRecords()[1:3], client.deleted)
+
+	// Records that do not exist are ignored
+	client.deleted = nil
+	deleted, err = provider.DeleteRecords(context.Background(), "example.com.", []libdns.Record{
+		libdns.MX{Name: "@", Preference: 20, Target: "mail.example.com."},
+		libdns.TXT{Name: "@", TTL: time.Hour, Text: "v=spf1 -all"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []libdns.Record{libdns.TXT{Name: "@", TTL: time.Hour, Text: "v=spf1 -all"}}, deleted)
+	assert.Equal(t, []regru.DNSRecord{testRecords()[4]}, client.deleted)
+}
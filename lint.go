@@ -0,0 +1,296 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// Built-in lint rule IDs
+const (
+	RuleDanglingCNAME = "dangling-cname"
+	RuleLowTTL        = "low-ttl"
+	RuleNSMismatch    = "ns-mismatch"
+)
+
+// DefaultMinTTL is the TTL in seconds below which LowTTLRule reports a record.
+const DefaultMinTTL = 60
+
+// LintZone is the zone data a lint rule checks.
+type LintZone struct {
+	Name    string
+	Records []DNSRecord
+	// Nameservers are the nameservers delegated at the registrar, if known.
+	Nameservers []string
+}
+
+// LintFinding is a problem reported by a lint rule.
+type LintFinding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Record is the record the finding refers to, if any.
+	Record *DNSRecord `json:"record,omitempty"`
+}
+
+// Rule is a zone lint check.
+type Rule interface {
+	// ID returns a short stable identifier of the rule, such as "low-ttl".
+	ID() string
+	// Check returns the findings of the rule for the zone.
+	Check(ctx context.Context, zone LintZone) []LintFinding
+}
+
+// LintReport is the result of linting a zone.
+type LintReport struct {
+	Zone     string        `json:"zone"`
+	Findings []LintFinding `json:"findings,omitempty"`
+}
+
+// MaxSeverity returns the highest severity among the findings, or "" if there are none.
+func (r LintReport) MaxSeverity() Severity {
+	var max Severity
+	for _, f := range r.Findings {
+		if severityRank(f.Severity) > severityRank(max) {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// LintOption represents an option for LintZone and LintAll.
+type LintOption func(*lintOptions)
+
+type lintOptions struct {
+	rules       []Rule
+	minSeverity Severity
+}
+
+// WithLintRules replaces the default rule set.
+func WithLintRules(rules ...Rule) LintOption {
+	return func(o *lintOptions) {
+		o.rules = rules
+	}
+}
+
+// WithMinSeverity drops findings below the severity.
+func WithMinSeverity(severity Severity) LintOption {
+	return func(o *lintOptions) {
+		o.minSeverity = severity
+	}
+}
+
+// DefaultLintRules returns the default rule set: dangling CNAME targets
+// (resolved with net.DefaultResolver), TTLs below DefaultMinTTL and apex NS
+// records that differ from the registrar nameservers.
+func DefaultLintRules() []Rule {
+	return []Rule{
+		DanglingCNAMERule{Resolver: net.DefaultResolver},
+		LowTTLRule{MinTTL: DefaultMinTTL},
+		NSMismatchRule{},
+	}
+}
+
+// Lint runs the rules against the zone. Findings are ordered by rule, in the
+// order the rules are given.
+func Lint(ctx context.Context, zone LintZone, rules []Rule) LintReport {
+	report := LintReport{Zone: zone.Name}
+	for _, rule := range rules {
+		for _, f := range rule.Check(ctx, zone) {
+			if f.Rule == "" {
+				f.Rule = rule.ID()
+			}
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	return report
+}
+
+// LintZone fetches the records and registrar nameservers of the zone and runs
+// the lint rules against them.
+func (c *Client) LintZone(ctx context.Context, zone string, opts ...LintOption) (LintReport, error) {
+	o := lintOptions{rules: DefaultLintRules()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return LintReport{}, err
+	}
+	nameservers, err := c.GetNameservers(ctx, zone)
+	if err != nil {
+		return LintReport{}, err
+	}
+
+	report := Lint(ctx, LintZone{Name: zone, Records: records, Nameservers: nameservers}, o.rules)
+	if o.minSeverity != "" {
+		findings := report.Findings[:0]
+		for _, f := range report.Findings {
+			if severityRank(f.Severity) >= severityRank(o.minSeverity) {
+				findings = append(findings, f)
+			}
+		}
+		report.Findings = findings
+	}
+	return report, nil
+}
+
+// LintAll runs LintZone for every zone of the account.
+func (c *Client) LintAll(ctx context.Context, opts ...LintOption) ([]LintReport, error) {
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]LintReport, 0, len(zones))
+	for _, zone := range zones {
+		report, err := c.LintZone(ctx, zone.Name, opts...)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// DanglingCNAMERule reports CNAME records whose target does not exist.
+// Targets inside the zone are checked against the zone records; other targets
+// are resolved with Resolver, and are not checked if Resolver is nil.
+type DanglingCNAMERule struct {
+	Resolver *net.Resolver
+}
+
+// ID implements Rule.
+func (DanglingCNAMERule) ID() string { return RuleDanglingCNAME }
+
+// Check implements Rule.
+func (r DanglingCNAMERule) Check(ctx context.Context, zone LintZone) []LintFinding {
+	origin := canonicalHost(zone.Name)
+	names := make(map[string]bool, len(zone.Records))
+	for _, rr := range zone.Records {
+		names[absoluteRecordName(Canonicalize(rr).Name, origin)] = true
+	}
+
+	var findings []LintFinding
+	for _, rr := range zone.Records {
+		c := Canonicalize(rr)
+		if c.Type != RecordTypeCNAME {
+			continue
+		}
+		target := canonicalHost(c.Content)
+
+		dangling := false
+		if target == origin || strings.HasSuffix(target, "."+origin) {
+			dangling = !names[target]
+		} else if r.Resolver != nil {
+			_, err := r.Resolver.LookupHost(ctx, target)
+			var dnsErr *net.DNSError
+			dangling = errors.As(err, &dnsErr) && dnsErr.IsNotFound
+		}
+
+		if dangling {
+			record := rr
+			findings = append(findings, LintFinding{
+				Rule:     RuleDanglingCNAME,
+				Severity: SeverityCritical,
+				Message:  fmt.Sprintf("CNAME %s points at %s, which does not exist", c.Name, target),
+				Record:   &record,
+			})
+		}
+	}
+	return findings
+}
+
+// absoluteRecordName returns the lowercase absolute name of a relative record name.
+func absoluteRecordName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	return name + "." + origin
+}
+
+// LowTTLRule reports records with a TTL below MinTTL seconds, which multiply
+// resolver load and are usually left over from a migration.
+type LowTTLRule struct {
+	MinTTL int
+}
+
+// ID implements Rule.
+func (LowTTLRule) ID() string { return RuleLowTTL }
+
+// Check implements Rule.
+func (r LowTTLRule) Check(_ context.Context, zone LintZone) []LintFinding {
+	var findings []LintFinding
+	for _, rr := range zone.Records {
+		if rr.TTL <= 0 || rr.TTL >= r.MinTTL {
+			continue
+		}
+		record := rr
+		findings = append(findings, LintFinding{
+			Rule:     RuleLowTTL,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%s %s has a TTL of %d seconds, below %d", rr.Name, rr.Type.Canonical(), rr.TTL, r.MinTTL),
+			Record:   &record,
+		})
+	}
+	return findings
+}
+
+// NSMismatchRule reports apex NS records that differ from the nameservers
+// delegated at the registrar. Zones without apex NS records or without known
+// registrar nameservers are not checked.
+type NSMismatchRule struct{}
+
+// ID implements Rule.
+func (NSMismatchRule) ID() string { return RuleNSMismatch }
+
+// Check implements Rule.
+func (NSMismatchRule) Check(_ context.Context, zone LintZone) []LintFinding {
+	var apex []string
+	for _, rr := range zone.Records {
+		c := Canonicalize(rr)
+		if c.Type == RecordTypeNS && c.Name == "@" {
+			apex = append(apex, canonicalHost(c.Content))
+		}
+	}
+	if len(apex) == 0 || len(zone.Nameservers) == 0 {
+		return nil
+	}
+
+	registrar := make([]string, 0, len(zone.Nameservers))
+	for _, ns := range zone.Nameservers {
+		registrar = append(registrar, canonicalHost(ns))
+	}
+	sort.Strings(apex)
+	sort.Strings(registrar)
+
+	if strings.Join(apex, " ") == strings.Join(registrar, " ") {
+		return nil
+	}
+	return []LintFinding{{
+		Rule:     RuleNSMismatch,
+		Severity: SeverityCritical,
+		Message: fmt.Sprintf("apex NS records (%s) differ from the registrar nameservers (%s)",
+			strings.Join(apex, ", "), strings.Join(registrar, ", ")),
+	}}
+}
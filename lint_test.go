@@ -0,0 +1,140 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDanglingCNAMERule(t *testing.T) {
+	zone := LintZone{
+		Name: "example.com",
+		Records: []DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"},
+			{Name: "web", Type: RecordTypeCNAME, Content: "www.example.com."},
+			{Name: "old", Type: RecordTypeCNAME, Content: "gone.example.com"},
+			{Name: "cdn", Type: RecordTypeCNAME, Content: "example.cdn.net"},
+		},
+	}
+
+	findings := DanglingCNAMERule{}.Check(context.Background(), zone)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleDanglingCNAME, findings[0].Rule)
+	assert.Equal(t, SeverityCritical, findings[0].Severity)
+	assert.Equal(t, "old", findings[0].Record.Name)
+}
+
+func TestLowTTLRule(t *testing.T) {
+	zone := LintZone{
+		Name: "example.com",
+		Records: []DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 30},
+			{Name: "api", Type: RecordTypeA, Content: "192.0.2.2", TTL: 300},
+			{Name: "@", Type: RecordTypeA, Content: "192.0.2.3"},
+		},
+	}
+
+	findings := LowTTLRule{MinTTL: DefaultMinTTL}.Check(context.Background(), zone)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityWarning, findings[0].Severity)
+	assert.Equal(t, "www", findings[0].Record.Name)
+}
+
+func TestNSMismatchRule(t *testing.T) {
+	records := []DNSRecord{
+		{Name: "@", Type: RecordTypeNS, Content: "ns1.reg.ru."},
+		{Name: "@", Type: RecordTypeNS, Content: "ns2.reg.ru"},
+		{Name: "sub", Type: RecordTypeNS, Content: "ns.other.net"},
+	}
+
+	findings := NSMismatchRule{}.Check(context.Background(), LintZone{
+		Name:        "example.com",
+		Records:     records,
+		Nameservers: []string{"NS2.REG.RU", "ns1.reg.ru"},
+	})
+	assert.Empty(t, findings)
+
+	findings = NSMismatchRule{}.Check(context.Background(), LintZone{
+		Name:        "example.com",
+		Records:     records,
+		Nameservers: []string{"ns1.hosting.net", "ns2.hosting.net"},
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleNSMismatch, findings[0].Rule)
+	assert.Contains(t, findings[0].Message, "ns1.hosting.net")
+
+	findings = NSMismatchRule{}.Check(context.Background(), LintZone{Name: "example.com", Records: records})
+	assert.Empty(t, findings)
+}
+
+// customRule reports every record, leaving the rule ID to Lint.
+type customRule struct{}
+
+func (customRule) ID() string { return "custom" }
+
+func (customRule) Check(_ context.Context, zone LintZone) []LintFinding {
+	var findings []LintFinding
+	for range zone.Records {
+		findings = append(findings, LintFinding{Severity: SeverityInfo, Message: "seen"})
+	}
+	return findings
+}
+
+func TestClient_LintZone(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+				DName: "example.com",
+				RRList: []ResourceRecord{
+					{Subname: "@", Rectype: "NS", Content: "ns1.reg.ru"},
+					{Subname: "www", Rectype: "A", Content: "192.0.2.1"},
+				},
+			}}},
+		},
+		"domain/get_nss": DomainGetNSSResponse{
+			Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+				DName:  "example.com",
+				Result: "success",
+				NSS:    []NameserverInfo{{NS: "ns1.hosting.net."}},
+			}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.LintZone(context.Background(), "example.com",
+		WithLintRules(NSMismatchRule{}, customRule{}))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", report.Zone)
+	require.Len(t, report.Findings, 3)
+	assert.Equal(t, RuleNSMismatch, report.Findings[0].Rule)
+	assert.Equal(t, "custom", report.Findings[1].Rule)
+	assert.Equal(t, SeverityCritical, report.MaxSeverity())
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "domain/get_nss", (*calls)[1].Path)
+
+	report, err = client.LintZone(context.Background(), "example.com",
+		WithLintRules(NSMismatchRule{}, customRule{}), WithMinSeverity(SeverityWarning))
+	require.NoError(t, err)
+	require.Len(t, report.Findings, 1)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// LOC coordinate encoding (RFC 1876): latitude and longitude are stored in
+// thousandths of an arc second offset by 2^31, altitude in centimeters above
+// a base 100000 m below the WGS 84 reference spheroid.
+const (
+	locEquator  = 1 << 31
+	locBase     = 100000 * 100
+	locMaxAlt   = 42849672.95
+	locMinAlt   = -100000
+	locArcMilli = 3600000
+)
+
+// LOCContent returns the content of a LOC record for a position in degrees
+// (negative latitude is south, negative longitude is west) and an altitude in
+// meters, in the format expected by AddRR, such as
+// "55 45 21.000 N 37 37 4.000 E 150.00m".
+func LOCContent(latitude, longitude, altitude float64) string {
+	return fmt.Sprintf("%s %s %.2fm",
+		locCoordinate(latitude, "N", "S"), locCoordinate(longitude, "E", "W"), altitude)
+}
+
+// locCoordinate formats a coordinate in degrees as "<deg> <min> <sec> <hemisphere>".
+func locCoordinate(degrees float64, positive, negative string) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+	}
+	ms := int64(math.Round(math.Abs(degrees) * locArcMilli))
+	return fmt.Sprintf("%d %d %.3f %s",
+		ms/locArcMilli, ms%locArcMilli/60000, float64(ms%60000)/1000, hemisphere)
+}
+
+// checkLOCPosition validates a position given in degrees and meters.
+func checkLOCPosition(latitude, longitude, altitude float64) error {
+	switch {
+	case latitude < -90 || latitude > 90:
+		return fmt.Errorf("invalid LOC latitude %g: must be between -90 and 90", latitude)
+	case longitude < -180 || longitude > 180:
+		return fmt.Errorf("invalid LOC longitude %g: must be between -180 and 180", longitude)
+	case altitude < locMinAlt || altitude > locMaxAlt:
+		return fmt.Errorf("invalid LOC altitude %g: must be between %d and %g meters", altitude, locMinAlt, locMaxAlt)
+	}
+	return nil
+}
+
+// parseLOC parses LOC content in RFC 1876 presentation format.
+func parseLOC(content string) (*dns.LOC, bool) {
+	rr, err := dns.NewRR(". LOC " + content)
+	if err != nil {
+		return nil, false
+	}
+	loc, ok := rr.(*dns.LOC)
+	return loc, ok
+}
+
+// locPosition returns the latitude and longitude in degrees and the altitude in meters of a LOC record.
+func locPosition(loc *dns.LOC) (float64, float64, float64) {
+	latitude := (float64(loc.Latitude) - locEquator) / locArcMilli
+	longitude := (float64(loc.Longitude) - locEquator) / locArcMilli
+	altitude := (float64(loc.Altitude) - locBase) / 100
+	return latitude, longitude, altitude
+}
+
+// locRData returns the RDATA of a LOC record in presentation format.
+func locRData(loc *dns.LOC) string {
+	return strings.TrimPrefix(loc.String(), loc.Hdr.String())
+}
+
+// canonicalLOC returns LOC content in the full presentation format, including
+// the default size and precision values.
+func canonicalLOC(content string) string {
+	loc, ok := parseLOC(content)
+	if !ok {
+		return strings.TrimSpace(content)
+	}
+	return locRData(loc)
+}
+
+// locParams returns the position of a LOC record to add: the structured
+// Latitude, Longitude and Altitude fields, or the parsed Content when it is set.
+func locParams(params CreateDNSRecordParams) (float64, float64, float64, error) {
+	if params.Content == "" {
+		if err := checkLOCPosition(params.Latitude, params.Longitude, params.Altitude); err != nil {
+			return 0, 0, 0, err
+		}
+		return params.Latitude, params.Longitude, params.Altitude, nil
+	}
+	loc, ok := parseLOC(params.Content)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid LOC content %q: expected \"<latitude> <longitude> <altitude>m\"", params.Content)
+	}
+	latitude, longitude, altitude := locPosition(loc)
+	return latitude, longitude, altitude, nil
+}
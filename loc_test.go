@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLOCContent(t *testing.T) {
+	assert.Equal(t, "55 45 0.000 N 37 37 12.000 E 150.00m", LOCContent(55.75, 37.62, 150))
+	assert.Equal(t, "33 51 36.000 S 151 12 36.000 W -5.50m", LOCContent(-33.86, -151.21, -5.5))
+
+	loc, ok := parseLOC(LOCContent(-33.859972, -151.211111, 10))
+	require.True(t, ok)
+	latitude, longitude, altitude := locPosition(loc)
+	assert.InDelta(t, -33.859972, latitude, 1e-6)
+	assert.InDelta(t, -151.211111, longitude, 1e-6)
+	assert.InDelta(t, 10, altitude, 1e-6)
+}
+
+func TestCanonicalize_LOC(t *testing.T) {
+	a := DNSRecord{Name: "office", Type: RecordTypeLOC, Content: "55 45 21.000 N 37 37 4.000 E 150.00m"}
+	b := DNSRecord{Name: "office", Type: "loc", Content: "55 45 21 N 37 37 4 E 150m 1m 10000m 10m"}
+	assert.True(t, a.Equal(b))
+}
+
+func TestClient_LOCRecords(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	record, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "office", Type: RecordTypeLOC, Latitude: 55.75, Longitude: 37.62, Altitude: 150, TTL: 3600,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "55 45 0.000 N 37 37 12.000 E 150.00m", record.Content)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "dc", Type: RecordTypeLOC, Content: "33 51 35.900 S 151 12 40.000 E -5.50m",
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteRR(ctx, "example.com", record)
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 3)
+	assert.Equal(t, "zone/add_loc", (*calls)[0].Path)
+	assert.Equal(t, "office", (*calls)[0].Input["subdomain"])
+	assert.Equal(t, 55.75, (*calls)[0].Input["latitude"])
+	assert.Equal(t, 37.62, (*calls)[0].Input["longitude"])
+	assert.Equal(t, float64(150), (*calls)[0].Input["altitude"])
+	assert.Equal(t, float64(3600), (*calls)[0].Input["ttl"])
+
+	assert.Equal(t, "zone/add_loc", (*calls)[1].Path)
+	assert.InDelta(t, -33.859972, (*calls)[1].Input["latitude"], 1e-6)
+	assert.InDelta(t, 151.211111, (*calls)[1].Input["longitude"], 1e-6)
+	assert.InDelta(t, -5.5, (*calls)[1].Input["altitude"], 1e-6)
+
+	assert.Equal(t, "zone/remove_record", (*calls)[2].Path)
+	assert.Equal(t, "LOC", (*calls)[2].Input["record_type"])
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "office", Type: RecordTypeLOC, Latitude: 91,
+	})
+	assert.ErrorContains(t, err, "invalid LOC latitude")
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "office", Type: RecordTypeLOC, Content: "north pole",
+	})
+	assert.ErrorContains(t, err, "invalid LOC content")
+}
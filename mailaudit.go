@@ -0,0 +1,215 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity ranks the importance of a finding.
+type Severity string
+
+// Finding severities
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// MailAuthFinding is a problem or observation reported by AuditMailAuth.
+type MailAuthFinding struct {
+	// Check is the area of the finding: "spf", "dkim" or "dmarc".
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Record is the content of the record the finding refers to, if any.
+	Record string `json:"record,omitempty"`
+}
+
+// MailAuthReport is the mail authentication state of a zone.
+type MailAuthReport struct {
+	Zone string `json:"zone"`
+	// HasMX is true if the zone apex has MX records, i.e. the domain receives mail.
+	HasMX bool `json:"has_mx"`
+	// SPF and DMARC are the parsed records, if exactly one valid record was found.
+	SPF   *SPF   `json:"spf,omitempty"`
+	DMARC *DMARC `json:"dmarc,omitempty"`
+	// DKIMSelectors lists the selectors with a published DKIM record.
+	DKIMSelectors []string          `json:"dkim_selectors,omitempty"`
+	Findings      []MailAuthFinding `json:"findings,omitempty"`
+}
+
+// MaxSeverity returns the highest severity among the findings, or "" if there are none.
+func (r MailAuthReport) MaxSeverity() Severity {
+	var max Severity
+	for _, f := range r.Findings {
+		if severityRank(f.Severity) > severityRank(max) {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// severityRank orders severities from lowest to highest.
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AuditMailAuth checks the SPF, DKIM and DMARC records of the zone and reports
+// missing records, weak policies and configuration errors. DKIM selectors are
+// found among the TXT and CNAME records under _domainkey.
+func (c *Client) AuditMailAuth(ctx context.Context, zone string) (MailAuthReport, error) {
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return MailAuthReport{}, err
+	}
+	return auditMailAuth(zone, records), nil
+}
+
+// AuditMailAuthAll runs AuditMailAuth for every zone of the account.
+func (c *Client) AuditMailAuthAll(ctx context.Context) ([]MailAuthReport, error) {
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]MailAuthReport, 0, len(zones))
+	for _, zone := range zones {
+		report, err := c.AuditMailAuth(ctx, zone.Name)
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// auditMailAuth evaluates the mail authentication records of a zone.
+func auditMailAuth(zone string, records []DNSRecord) MailAuthReport {
+	report := MailAuthReport{Zone: zone}
+	add := func(check string, severity Severity, record, format string, args ...interface{}) {
+		report.Findings = append(report.Findings, MailAuthFinding{
+			Check:    check,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+			Record:   record,
+		})
+	}
+
+	var spfs, dmarcs []string
+	selectors := make(map[string]bool)
+	for _, rr := range records {
+		c := Canonicalize(rr)
+		switch {
+		case c.Type == RecordTypeMX && c.Name == "@":
+			report.HasMX = true
+		case c.Type == RecordTypeTXT && c.Name == "@" && hasTXTPrefix(c, "v=spf1"):
+			spfs = append(spfs, c.Content)
+		case c.Type == RecordTypeTXT && c.Name == "_dmarc":
+			dmarcs = append(dmarcs, c.Content)
+		case (c.Type == RecordTypeTXT || c.Type == RecordTypeCNAME) && strings.HasSuffix(c.Name, "._domainkey"):
+			selector := strings.TrimSuffix(c.Name, "._domainkey")
+			selectors[selector] = true
+			if c.Type == RecordTypeTXT && strings.Contains(strings.ReplaceAll(c.Content, " ", "")+";", "p=;") {
+				add("dkim", SeverityInfo, c.Content, "DKIM key for selector %s is revoked", selector)
+			}
+		}
+	}
+	for selector := range selectors {
+		report.DKIMSelectors = append(report.DKIMSelectors, selector)
+	}
+	sort.Strings(report.DKIMSelectors)
+
+	// SPF
+	switch {
+	case len(spfs) == 0 && report.HasMX:
+		add("spf", SeverityCritical, "", "no SPF record")
+	case len(spfs) == 0:
+		add("spf", SeverityWarning, "", "no SPF record; publish \"v=spf1 -all\" if the domain sends no mail")
+	case len(spfs) > 1:
+		add("spf", SeverityCritical, "", "%d SPF records found; receivers treat this as a permanent error", len(spfs))
+	default:
+		spf, err := ParseSPF(spfs[0])
+		if err != nil {
+			add("spf", SeverityCritical, spfs[0], "%v", err)
+			break
+		}
+		report.SPF = &spf
+		if err := spf.Validate(); err != nil {
+			add("spf", SeverityCritical, spfs[0], "%v", err)
+		}
+		switch strings.ToLower(strings.TrimPrefix(spf.All, "+")) {
+		case "all":
+			add("spf", SeverityCritical, spfs[0], "\"+all\" allows any server to send mail")
+		case "?all":
+			add("spf", SeverityWarning, spfs[0], "\"?all\" does not restrict senders")
+		case "":
+			if spf.Redirect == "" {
+				add("spf", SeverityWarning, spfs[0], "no \"all\" mechanism; unlisted senders are neutral")
+			}
+		}
+	}
+
+	// DKIM
+	if report.HasMX && len(report.DKIMSelectors) == 0 {
+		add("dkim", SeverityWarning, "", "no DKIM selectors found")
+	}
+
+	// DMARC
+	switch {
+	case len(dmarcs) == 0:
+		add("dmarc", SeverityCritical, "", "no DMARC record")
+	case len(dmarcs) > 1:
+		add("dmarc", SeverityCritical, "", "%d DMARC records found; receivers ignore DMARC", len(dmarcs))
+	default:
+		dmarc, err := ParseDMARC(dmarcs[0])
+		if err == nil {
+			err = dmarc.Validate()
+		}
+		if err != nil {
+			add("dmarc", SeverityCritical, dmarcs[0], "%v", err)
+			break
+		}
+		report.DMARC = &dmarc
+		if dmarc.Policy == DMARCPolicyNone {
+			add("dmarc", SeverityWarning, dmarcs[0], "policy \"none\" only monitors and does not protect the domain")
+		}
+		if dmarc.SubdomainPolicy == DMARCPolicyNone && dmarc.Policy != DMARCPolicyNone {
+			add("dmarc", SeverityWarning, dmarcs[0], "subdomain policy \"none\" leaves subdomains unprotected")
+		}
+		if dmarc.Percent > 0 && dmarc.Percent < 100 {
+			add("dmarc", SeverityWarning, dmarcs[0], "policy applies to only %d%% of messages", dmarc.Percent)
+		}
+		if len(dmarc.AggregateReports) == 0 {
+			add("dmarc", SeverityInfo, dmarcs[0], "no aggregate report address (rua)")
+		}
+	}
+
+	return report
+}
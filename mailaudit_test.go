@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findingMessages returns the check and message of every finding.
+func findingMessages(report MailAuthReport) []string {
+	var messages []string
+	for _, f := range report.Findings {
+		messages = append(messages, f.Check+": "+f.Message)
+	}
+	return messages
+}
+
+func TestAuditMailAuth_Healthy(t *testing.T) {
+	report := auditMailAuth("example.com", []DNSRecord{
+		{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"},
+		{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 mx -all"},
+		{Name: "@", Type: RecordTypeTXT, Content: "google-site-verification=abc"},
+		{Name: "mail._domainkey", Type: RecordTypeTXT, Content: "v=DKIM1; k=rsa; p=AAAA"},
+		{Name: "s2._domainkey", Type: RecordTypeCNAME, Content: "s2.dkim.example.net"},
+		{Name: "_dmarc", Type: RecordTypeTXT, Content: "v=DMARC1; p=reject; rua=mailto:dmarc@example.com"},
+	})
+
+	assert.True(t, report.HasMX)
+	require.NotNil(t, report.SPF)
+	require.NotNil(t, report.DMARC)
+	assert.Equal(t, []string{"mail", "s2"}, report.DKIMSelectors)
+	assert.Empty(t, report.Findings)
+	assert.Equal(t, Severity(""), report.MaxSeverity())
+}
+
+func TestAuditMailAuth_Missing(t *testing.T) {
+	report := auditMailAuth("example.com", []DNSRecord{
+		{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"},
+	})
+
+	assert.Equal(t, []string{
+		"spf: no SPF record",
+		"dkim: no DKIM selectors found",
+		"dmarc: no DMARC record",
+	}, findingMessages(report))
+	assert.Equal(t, SeverityCritical, report.MaxSeverity())
+}
+
+func TestAuditMailAuth_Weak(t *testing.T) {
+	report := auditMailAuth("example.com", []DNSRecord{
+		{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 +all"},
+		{Name: "old._domainkey", Type: RecordTypeTXT, Content: "v=DKIM1; p="},
+		{Name: "_dmarc", Type: RecordTypeTXT, Content: "v=DMARC1; p=none; pct=20"},
+	})
+
+	assert.False(t, report.HasMX)
+	assert.Equal(t, []string{
+		"dkim: DKIM key for selector old is revoked",
+		"spf: \"+all\" allows any server to send mail",
+		"dmarc: policy \"none\" only monitors and does not protect the domain",
+		"dmarc: policy applies to only 20% of messages",
+		"dmarc: no aggregate report address (rua)",
+	}, findingMessages(report))
+}
+
+func TestAuditMailAuth_Duplicates(t *testing.T) {
+	report := auditMailAuth("example.com", []DNSRecord{
+		{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 mx -all"},
+		{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 include:_spf.google.com ~all"},
+		{Name: "_dmarc", Type: RecordTypeTXT, Content: "v=DMARC1; p=block"},
+	})
+
+	assert.Nil(t, report.SPF)
+	assert.Nil(t, report.DMARC)
+	assert.Equal(t, []string{
+		"spf: 2 SPF records found; receivers treat this as a permanent error",
+		"dmarc: invalid DMARC record: invalid policy \"block\"",
+	}, findingMessages(report))
+}
+
+func TestClient_AuditMailAuthAll(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+		"service/get_list": ServiceListResponse{
+			Answer: ServiceListAnswer{Services: []Service{{ServiceType: "domain", Domain: "example.com"}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	reports, err := client.AuditMailAuthAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "example.com", reports[0].Zone)
+	assert.True(t, reports[0].HasMX)
+	assert.Equal(t, SeverityCritical, reports[0].MaxSeverity())
+}
@@ -0,0 +1,842 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SPFMaxLookups is the maximum number of DNS lookups an SPF record may cause (RFC 7208, section 4.6.4).
+	SPFMaxLookups = 10
+	// SPFMaxLength is the maximum length of an SPF record kept in a single TXT character-string.
+	SPFMaxLength = 255
+)
+
+// SPF qualifiers (RFC 7208, section 4.6.2)
+const (
+	SPFPass     = "+"
+	SPFFail     = "-"
+	SPFSoftFail = "~"
+	SPFNeutral  = "?"
+)
+
+// SPF is a Sender Policy Framework record (RFC 7208).
+type SPF struct {
+	// Mechanisms are the terms between the version and the final "all", such as
+	// "mx", "ip4:192.0.2.0/24" or "include:_spf.google.com".
+	Mechanisms []string
+	// Redirect is the domain of the redirect= modifier, if any.
+	Redirect string
+	// All is the final "all" mechanism with its qualifier, such as "-all" or "~all".
+	All string
+}
+
+// ParseSPF parses the content of an SPF TXT record.
+func ParseSPF(txt string) (SPF, error) {
+	fields := strings.Fields(canonicalTXT(txt))
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "v=spf1") {
+		return SPF{}, &EmailAuthError{Kind: "SPF", Message: "record does not start with v=spf1"}
+	}
+
+	var spf SPF
+	for _, term := range fields[1:] {
+		lower := strings.ToLower(term)
+		switch {
+		case strings.HasPrefix(lower, "redirect="):
+			spf.Redirect = term[len("redirect="):]
+		case strings.TrimLeft(lower, "+-~?") == "all":
+			spf.All = term
+		default:
+			spf.Mechanisms = append(spf.Mechanisms, term)
+		}
+	}
+	return spf, nil
+}
+
+// String returns the TXT record content.
+func (s SPF) String() string {
+	terms := append([]string{"v=spf1"}, s.Mechanisms...)
+	if s.Redirect != "" {
+		terms = append(terms, "redirect="+s.Redirect)
+	}
+	if s.All != "" {
+		terms = append(terms, s.All)
+	}
+	return strings.Join(terms, " ")
+}
+
+// Includes returns the domains of the include: mechanisms.
+func (s SPF) Includes() []string {
+	var domains []string
+	for _, m := range s.Mechanisms {
+		if name, value := spfTerm(m); name == "include" {
+			domains = append(domains, value)
+		}
+	}
+	return domains
+}
+
+// AddInclude adds include: mechanisms for the domains that are not included yet.
+func (s *SPF) AddInclude(domains ...string) {
+	for _, domain := range domains {
+		s.addMechanism("include:" + domain)
+	}
+}
+
+// AddMechanism adds a mechanism with the qualifier, such as
+// AddMechanism(SPFSoftFail, "a:mail.example.com"), unless an equal one is
+// present. The default SPFPass qualifier is omitted from the record.
+func (s *SPF) AddMechanism(qualifier, mechanism string) {
+	if qualifier == SPFPass {
+		qualifier = ""
+	}
+	s.addMechanism(qualifier + mechanism)
+}
+
+// AddIP adds ip4: or ip6: mechanisms for the addresses or CIDR prefixes.
+func (s *SPF) AddIP(prefixes ...string) {
+	for _, prefix := range prefixes {
+		if strings.Contains(prefix, ":") {
+			s.addMechanism("ip6:" + prefix)
+		} else {
+			s.addMechanism("ip4:" + prefix)
+		}
+	}
+}
+
+// RemoveMechanism removes the mechanisms equal to mechanism, ignoring their
+// qualifier and case. It reports whether any were removed.
+func (s *SPF) RemoveMechanism(mechanism string) bool {
+	kept := s.Mechanisms[:0]
+	for _, m := range s.Mechanisms {
+		if !strings.EqualFold(strings.TrimLeft(m, "+-~?"), strings.TrimLeft(mechanism, "+-~?")) {
+			kept = append(kept, m)
+		}
+	}
+	removed := len(kept) < len(s.Mechanisms)
+	s.Mechanisms = kept
+	return removed
+}
+
+// Merge adds the mechanisms of other that are not present yet. The redirect
+// and "all" of s are kept; those of other are used only where s has none.
+func (s *SPF) Merge(other SPF) {
+	for _, m := range other.Mechanisms {
+		s.addMechanism(m)
+	}
+	if s.Redirect == "" && s.All == "" {
+		s.Redirect = other.Redirect
+	}
+	if s.All == "" {
+		s.All = other.All
+	}
+}
+
+// addMechanism appends the mechanism unless an equal one is present.
+func (s *SPF) addMechanism(mechanism string) {
+	for _, m := range s.Mechanisms {
+		if strings.EqualFold(m, mechanism) {
+			return
+		}
+	}
+	s.Mechanisms = append(s.Mechanisms, mechanism)
+}
+
+// Lookups returns the number of DNS lookups the record itself causes, not
+// counting the lookups of included records.
+func (s SPF) Lookups() int {
+	n := 0
+	for _, m := range s.Mechanisms {
+		switch name, _ := spfTerm(m); name {
+		case "include", "a", "mx", "ptr", "exists":
+			n++
+		}
+	}
+	if s.Redirect != "" {
+		n++
+	}
+	return n
+}
+
+// Validate checks the record syntax and the lookup and length limits.
+func (s SPF) Validate() error {
+	for _, m := range s.Mechanisms {
+		switch name, value := spfTerm(m); name {
+		case "a", "mx", "ptr":
+		case "include", "exists":
+			if value == "" {
+				return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("mechanism %q requires a value", m)}
+			}
+		case "ip4", "ip6":
+			if value == "" {
+				return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("mechanism %q requires a value", m)}
+			}
+			if !validSPFNetwork(value, name == "ip6") {
+				return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("invalid network in mechanism %q", m)}
+			}
+		case "exp":
+		default:
+			return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("unknown mechanism %q", m)}
+		}
+	}
+	if s.All != "" && strings.TrimLeft(strings.ToLower(s.All), "+-~?") != "all" {
+		return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("invalid all mechanism %q", s.All)}
+	}
+	if n := s.Lookups(); n > SPFMaxLookups {
+		return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("%d DNS lookups exceed the limit of %d", n, SPFMaxLookups)}
+	}
+	if n := len(s.String()); n > SPFMaxLength {
+		return &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("record length %d exceeds %d characters", n, SPFMaxLength)}
+	}
+	return nil
+}
+
+// ResolveLookups estimates the DNS lookups of the record including those of
+// the records it includes or redirects to, which are resolved with resolver.
+// Evaluation stops once the estimate exceeds SPFMaxLookups.
+func (s SPF) ResolveLookups(ctx context.Context, resolver *net.Resolver) (int, error) {
+	n := s.Lookups()
+	targets := s.Includes()
+	if s.Redirect != "" {
+		targets = append(targets, s.Redirect)
+	}
+	for _, domain := range targets {
+		if n > SPFMaxLookups {
+			break
+		}
+		txts, err := resolver.LookupTXT(ctx, domain)
+		if err != nil {
+			return n, err
+		}
+		var nested *SPF
+		for _, txt := range txts {
+			if spf, err := ParseSPF(txt); err == nil {
+				nested = &spf
+				break
+			}
+		}
+		if nested == nil {
+			return n, &EmailAuthError{Kind: "SPF", Message: fmt.Sprintf("%s has no SPF record", domain)}
+		}
+		m, err := nested.ResolveLookups(ctx, resolver)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// validSPFNetwork reports whether value is an address or CIDR prefix of the family.
+func validSPFNetwork(value string, ipv6 bool) bool {
+	var addr netip.Addr
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		addr = prefix.Addr()
+	} else if a, err := netip.ParseAddr(value); err == nil {
+		addr = a
+	} else {
+		return false
+	}
+	return addr.Is6() == ipv6 && !addr.Is4In6()
+}
+
+// spfTerm splits an SPF mechanism or modifier into its lower-case name and value.
+func spfTerm(term string) (string, string) {
+	term = strings.TrimLeft(term, "+-~?")
+	i := strings.IndexAny(term, ":=/")
+	if i < 0 {
+		return strings.ToLower(term), ""
+	}
+	return strings.ToLower(term[:i]), term[i+1:]
+}
+
+// DKIM is a DomainKeys Identified Mail public key record (RFC 6376).
+type DKIM struct {
+	// Selector is the selector the key is published under.
+	Selector string
+	// KeyType is "rsa" (the default) or "ed25519".
+	KeyType string
+	// PublicKey is the base64-encoded public key.
+	PublicKey string
+	// Flags are the t= flags, such as DKIMFlagTesting.
+	Flags []string
+}
+
+// DKIM flags (RFC 6376, section 3.6.1)
+const (
+	// DKIMFlagTesting marks the domain as testing DKIM; verifiers treat
+	// signed and unsigned mail alike.
+	DKIMFlagTesting = "y"
+	// DKIMFlagStrict forbids signatures whose i= domain is a subdomain of d=.
+	DKIMFlagStrict = "s"
+)
+
+// ParseDKIM parses the content of a DKIM TXT record. The selector is not part
+// of the content and is left empty.
+func ParseDKIM(txt string) (DKIM, error) {
+	var d DKIM
+	for i, tag := range strings.Split(canonicalTXT(txt), ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		switch name {
+		case "v":
+			if i != 0 || value != "DKIM1" {
+				return DKIM{}, &EmailAuthError{Kind: "DKIM", Message: "invalid version tag"}
+			}
+		case "k":
+			d.KeyType = strings.ToLower(value)
+		case "p":
+			d.PublicKey = strings.Join(strings.Fields(value), "")
+		case "t":
+			for _, flag := range strings.Split(value, ":") {
+				if flag = strings.TrimSpace(flag); flag != "" {
+					d.Flags = append(d.Flags, flag)
+				}
+			}
+		}
+	}
+	if d.PublicKey == "" {
+		return DKIM{}, &EmailAuthError{Kind: "DKIM", Message: "record has no public key"}
+	}
+	return d, nil
+}
+
+// Name returns the record name relative to the zone, "<selector>._domainkey".
+func (d DKIM) Name() string {
+	return d.Selector + "._domainkey"
+}
+
+// String returns the TXT record content.
+func (d DKIM) String() string {
+	keyType := d.KeyType
+	if keyType == "" {
+		keyType = "rsa"
+	}
+	if len(d.Flags) > 0 {
+		return fmt.Sprintf("v=DKIM1; k=%s; t=%s; p=%s", keyType, strings.Join(d.Flags, ":"), d.PublicKey)
+	}
+	return fmt.Sprintf("v=DKIM1; k=%s; p=%s", keyType, d.PublicKey)
+}
+
+// Chunked returns the TXT record content split into character strings of at
+// most MaxTXTStringLength bytes, as needed for 2048-bit and longer RSA keys.
+func (d DKIM) Chunked() string {
+	return chunkTXT(d.String())
+}
+
+// Validate checks the selector, key type and public key encoding.
+func (d DKIM) Validate() error {
+	if d.Selector == "" || strings.ContainsAny(d.Selector, " ;") {
+		return &EmailAuthError{Kind: "DKIM", Message: fmt.Sprintf("invalid selector %q", d.Selector)}
+	}
+	switch d.KeyType {
+	case "", "rsa", "ed25519":
+	default:
+		return &EmailAuthError{Kind: "DKIM", Message: fmt.Sprintf("unsupported key type %q", d.KeyType)}
+	}
+	if _, err := base64.StdEncoding.DecodeString(d.PublicKey); err != nil || d.PublicKey == "" {
+		return &EmailAuthError{Kind: "DKIM", Message: "public key is not valid base64"}
+	}
+	for _, flag := range d.Flags {
+		if flag != DKIMFlagTesting && flag != DKIMFlagStrict {
+			return &EmailAuthError{Kind: "DKIM", Message: fmt.Sprintf("unknown flag %q", flag)}
+		}
+	}
+	return nil
+}
+
+// DMARC policies
+const (
+	DMARCPolicyNone       = "none"
+	DMARCPolicyQuarantine = "quarantine"
+	DMARCPolicyReject     = "reject"
+)
+
+// DMARC is a Domain-based Message Authentication, Reporting and Conformance record (RFC 7489).
+type DMARC struct {
+	// Policy is the p= policy: none, quarantine or reject.
+	Policy string
+	// SubdomainPolicy is the sp= policy for subdomains, if different.
+	SubdomainPolicy string
+	// Percent is the pct= share of messages the policy applies to. Zero means 100.
+	Percent int
+	// AggregateReports are the rua= report addresses. A missing "mailto:" is added.
+	AggregateReports []string
+	// ForensicReports are the ruf= report addresses. A missing "mailto:" is added.
+	ForensicReports []string
+	// DKIMAlignment and SPFAlignment are the adkim= and aspf= modes, "r" or "s".
+	DKIMAlignment string
+	SPFAlignment  string
+}
+
+// Name returns the record name relative to the zone.
+func (d DMARC) Name() string {
+	return "_dmarc"
+}
+
+// ParseDMARC parses the content of a DMARC TXT record.
+func ParseDMARC(txt string) (DMARC, error) {
+	tags := strings.Split(canonicalTXT(txt), ";")
+	if strings.ReplaceAll(strings.TrimSpace(tags[0]), " ", "") != "v=DMARC1" {
+		return DMARC{}, &EmailAuthError{Kind: "DMARC", Message: "record does not start with v=DMARC1"}
+	}
+
+	var d DMARC
+	for _, tag := range tags[1:] {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "p":
+			d.Policy = strings.ToLower(value)
+		case "sp":
+			d.SubdomainPolicy = strings.ToLower(value)
+		case "pct":
+			pct, err := strconv.Atoi(value)
+			if err != nil {
+				return DMARC{}, &EmailAuthError{Kind: "DMARC", Message: fmt.Sprintf("invalid pct %q", value)}
+			}
+			d.Percent = pct
+		case "rua":
+			d.AggregateReports = splitDMARCList(value)
+		case "ruf":
+			d.ForensicReports = splitDMARCList(value)
+		case "adkim":
+			d.DKIMAlignment = strings.ToLower(value)
+		case "aspf":
+			d.SPFAlignment = strings.ToLower(value)
+		}
+	}
+	return d, nil
+}
+
+// String returns the TXT record content.
+func (d DMARC) String() string {
+	tags := []string{"v=DMARC1", "p=" + d.Policy}
+	if d.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+d.SubdomainPolicy)
+	}
+	if d.Percent > 0 && d.Percent < 100 {
+		tags = append(tags, "pct="+strconv.Itoa(d.Percent))
+	}
+	if len(d.AggregateReports) > 0 {
+		tags = append(tags, "rua="+joinDMARCList(d.AggregateReports))
+	}
+	if len(d.ForensicReports) > 0 {
+		tags = append(tags, "ruf="+joinDMARCList(d.ForensicReports))
+	}
+	if d.DKIMAlignment != "" {
+		tags = append(tags, "adkim="+d.DKIMAlignment)
+	}
+	if d.SPFAlignment != "" {
+		tags = append(tags, "aspf="+d.SPFAlignment)
+	}
+	return strings.Join(tags, "; ")
+}
+
+// Validate checks the policies, percentage and alignment modes.
+func (d DMARC) Validate() error {
+	if !isDMARCPolicy(d.Policy) {
+		return &EmailAuthError{Kind: "DMARC", Message: fmt.Sprintf("invalid policy %q", d.Policy)}
+	}
+	if d.SubdomainPolicy != "" && !isDMARCPolicy(d.SubdomainPolicy) {
+		return &EmailAuthError{Kind: "DMARC", Message: fmt.Sprintf("invalid subdomain policy %q", d.SubdomainPolicy)}
+	}
+	if d.Percent < 0 || d.Percent > 100 {
+		return &EmailAuthError{Kind: "DMARC", Message: fmt.Sprintf("pct %d is out of range", d.Percent)}
+	}
+	for _, mode := range []string{d.DKIMAlignment, d.SPFAlignment} {
+		if mode != "" && mode != "r" && mode != "s" {
+			return &EmailAuthError{Kind: "DMARC", Message: fmt.Sprintf("invalid alignment mode %q", mode)}
+		}
+	}
+	return nil
+}
+
+// isDMARCPolicy reports whether the value is a valid DMARC policy.
+func isDMARCPolicy(policy string) bool {
+	return policy == DMARCPolicyNone || policy == DMARCPolicyQuarantine || policy == DMARCPolicyReject
+}
+
+// splitDMARCList splits a comma-separated list of report URIs.
+func splitDMARCList(value string) []string {
+	var uris []string
+	for _, uri := range strings.Split(value, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+	return uris
+}
+
+// joinDMARCList joins report addresses, adding the mailto: scheme where it is missing.
+func joinDMARCList(addresses []string) string {
+	uris := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		if !strings.Contains(addr, ":") {
+			addr = "mailto:" + addr
+		}
+		uris = append(uris, addr)
+	}
+	return strings.Join(uris, ",")
+}
+
+// BIMI is a Brand Indicators for Message Identification record, which points
+// mail clients at the logo of the sender. BIMI requires an enforcing DMARC
+// policy (quarantine or reject).
+type BIMI struct {
+	// Selector is the selector the record is published under. Defaults to "default".
+	Selector string
+	// Logo is the HTTPS URL of the SVG Tiny PS logo (l=).
+	Logo string
+	// Authority is the HTTPS URL of the PEM-encoded Verified Mark Certificate (a=), if any.
+	Authority string
+}
+
+// Name returns the record name relative to the zone, "<selector>._bimi".
+func (b BIMI) Name() string {
+	selector := b.Selector
+	if selector == "" {
+		selector = "default"
+	}
+	return selector + "._bimi"
+}
+
+// ParseBIMI parses the content of a BIMI TXT record. The selector is not part
+// of the content and is left empty.
+func ParseBIMI(txt string) (BIMI, error) {
+	var b BIMI
+	for i, tag := range strings.Split(canonicalTXT(txt), ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "v":
+			if i != 0 || value != "BIMI1" {
+				return BIMI{}, &EmailAuthError{Kind: "BIMI", Message: "record does not start with v=BIMI1"}
+			}
+		case "l":
+			b.Logo = value
+		case "a":
+			b.Authority = value
+		default:
+			if i == 0 {
+				return BIMI{}, &EmailAuthError{Kind: "BIMI", Message: "record does not start with v=BIMI1"}
+			}
+		}
+	}
+	return b, nil
+}
+
+// String returns the TXT record content.
+func (b BIMI) String() string {
+	s := "v=BIMI1; l=" + b.Logo
+	if b.Authority != "" {
+		s += "; a=" + b.Authority
+	}
+	return s
+}
+
+// Validate checks the selector and that the logo is an HTTPS URL of an SVG
+// file and the authority, if set, an HTTPS URL of a PEM file.
+func (b BIMI) Validate() error {
+	if strings.ContainsAny(b.Selector, " ;.") {
+		return &EmailAuthError{Kind: "BIMI", Message: fmt.Sprintf("invalid selector %q", b.Selector)}
+	}
+	if err := validBIMIURL(b.Logo, ".svg"); err != nil {
+		return &EmailAuthError{Kind: "BIMI", Message: "logo " + err.Error()}
+	}
+	if b.Authority != "" {
+		if err := validBIMIURL(b.Authority, ".pem"); err != nil {
+			return &EmailAuthError{Kind: "BIMI", Message: "authority " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// validBIMIURL checks that raw is an absolute HTTPS URL whose path has the extension.
+func validBIMIURL(raw, ext string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || strings.ContainsAny(raw, " ;") {
+		return fmt.Errorf("%q is not a valid URL", raw)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%q is not an HTTPS URL", raw)
+	}
+	if !strings.HasSuffix(strings.ToLower(u.Path), ext) {
+		return fmt.Errorf("%q does not point at a %s file", raw, ext)
+	}
+	return nil
+}
+
+// EmailAuthPolicy describes the mail authentication records of a zone.
+// Nil or empty parts are left untouched.
+type EmailAuthPolicy struct {
+	SPF   *SPF
+	DKIM  []DKIM
+	DMARC *DMARC
+	BIMI  *BIMI
+	// ReplaceSPF replaces an existing SPF record instead of merging the
+	// mechanisms of the policy into it.
+	ReplaceSPF bool
+}
+
+// UpsertEmailAuth validates the policy and makes the zone publish its SPF
+// (at the apex), DKIM, DMARC and BIMI records using EnsureRR. By default the
+// mechanisms of an existing SPF record are kept and the policy's are merged
+// in, with the policy's "all" taking precedence if set. Only the SPF, DKIM,
+// DMARC or BIMI record being written is replaced; other TXT records at the
+// same name, such as verification tokens, are left alone.
+func (c *Client) UpsertEmailAuth(ctx context.Context, zone string, policy EmailAuthPolicy) ([]EnsureResult, error) {
+	if policy.SPF != nil {
+		if err := policy.SPF.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, dkim := range policy.DKIM {
+		if err := dkim.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if policy.DMARC != nil {
+		if err := policy.DMARC.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if policy.BIMI != nil {
+		if err := policy.BIMI.Validate(); err != nil {
+			return nil, err
+		}
+		if policy.DMARC != nil && policy.DMARC.Policy == DMARCPolicyNone {
+			return nil, &EmailAuthError{Kind: "BIMI", Message: "BIMI requires a DMARC policy of quarantine or reject"}
+		}
+	}
+
+	var results []EnsureResult
+	if policy.SPF != nil {
+		spf := *policy.SPF
+		if !policy.ReplaceSPF {
+			existing, err := c.findSPF(ctx, zone)
+			if err != nil {
+				return results, err
+			}
+			if existing != nil {
+				existing.Merge(spf)
+				if spf.All != "" {
+					existing.All = spf.All
+				}
+				spf = *existing
+				if err := spf.Validate(); err != nil {
+					return results, err
+				}
+			}
+		}
+		result, err := c.ensureTXT(ctx, zone, "@", spf.String(), "v=spf1")
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	for _, dkim := range policy.DKIM {
+		result, err := c.ensureTXT(ctx, zone, dkim.Name(), dkim.Chunked(), "v=DKIM1")
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	if policy.DMARC != nil {
+		result, err := c.ensureTXT(ctx, zone, policy.DMARC.Name(), policy.DMARC.String(), "v=DMARC1")
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	if policy.BIMI != nil {
+		result, err := c.ensureTXT(ctx, zone, policy.BIMI.Name(), policy.BIMI.String(), "v=BIMI1")
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// PublishDKIM validates the DKIM key and publishes it at
+// <selector>._domainkey, split into character strings as needed and replacing
+// an existing DKIM record. The record is read back and verified with VerifyDKIM
+// after it is written.
+func (c *Client) PublishDKIM(ctx context.Context, zone string, dkim DKIM) (EnsureResult, error) {
+	if err := dkim.Validate(); err != nil {
+		return EnsureResult{}, err
+	}
+	result, err := c.ensureTXT(ctx, zone, dkim.Name(), dkim.Chunked(), "v=DKIM1")
+	if err != nil {
+		return result, err
+	}
+	return result, c.VerifyDKIM(ctx, zone, dkim)
+}
+
+// VerifyDKIM checks that the zone publishes the key, type and flags of dkim
+// under its selector. It fails with a RecordNotFoundError if there is no DKIM
+// record for the selector, or an EmailAuthError if the published one differs.
+func (c *Client) VerifyDKIM(ctx context.Context, zone string, dkim DKIM) error {
+	name := dkim.Name()
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone, Type: RecordTypeTXT})
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, rr := range records {
+		if canonicalName(rr.Name) != strings.ToLower(name) || !hasTXTPrefix(rr, "v=DKIM1") {
+			continue
+		}
+		found = true
+		published, err := ParseDKIM(rr.Content)
+		if err != nil {
+			continue
+		}
+		published.Selector = dkim.Selector
+		if published.String() == dkim.String() {
+			return nil
+		}
+	}
+	if !found {
+		return &RecordNotFoundError{RecordName: name}
+	}
+	return &EmailAuthError{Kind: "DKIM", Message: fmt.Sprintf("record at %s does not match the key", name)}
+}
+
+// SetSPF validates the SPF record and publishes it at the zone apex,
+// replacing an existing SPF record. Use UpsertEmailAuth to merge with the
+// existing record instead.
+func (c *Client) SetSPF(ctx context.Context, zone string, spf SPF) (EnsureResult, error) {
+	if err := spf.Validate(); err != nil {
+		return EnsureResult{}, err
+	}
+	return c.ensureTXT(ctx, zone, "@", spf.String(), "v=spf1")
+}
+
+// GetSPF returns the SPF record at the zone apex. It fails with a
+// RecordNotFoundError if the zone has none.
+func (c *Client) GetSPF(ctx context.Context, zone string) (SPF, error) {
+	spf, err := c.findSPF(ctx, zone)
+	if err != nil {
+		return SPF{}, err
+	}
+	if spf == nil {
+		return SPF{}, &RecordNotFoundError{RecordName: "@"}
+	}
+	return *spf, nil
+}
+
+// SetDMARC validates the DMARC policy and publishes it at _dmarc, replacing
+// an existing DMARC record. Other TXT records at the name are left alone.
+func (c *Client) SetDMARC(ctx context.Context, zone string, dmarc DMARC) (EnsureResult, error) {
+	if err := dmarc.Validate(); err != nil {
+		return EnsureResult{}, err
+	}
+	return c.ensureTXT(ctx, zone, dmarc.Name(), dmarc.String(), "v=DMARC1")
+}
+
+// GetDMARC returns the DMARC policy published at _dmarc. It fails with a
+// RecordNotFoundError if the zone has none.
+func (c *Client) GetDMARC(ctx context.Context, zone string) (DMARC, error) {
+	name := DMARC{}.Name()
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone, Type: RecordTypeTXT})
+	if err != nil {
+		return DMARC{}, err
+	}
+	for _, rr := range records {
+		if canonicalName(rr.Name) == name && hasTXTPrefix(rr, "v=DMARC1") {
+			return ParseDMARC(rr.Content)
+		}
+	}
+	return DMARC{}, &RecordNotFoundError{RecordName: name}
+}
+
+// SetBIMI validates the BIMI record and publishes it at <selector>._bimi,
+// replacing an existing BIMI record.
+func (c *Client) SetBIMI(ctx context.Context, zone string, bimi BIMI) (EnsureResult, error) {
+	if err := bimi.Validate(); err != nil {
+		return EnsureResult{}, err
+	}
+	return c.ensureTXT(ctx, zone, bimi.Name(), bimi.String(), "v=BIMI1")
+}
+
+// GetBIMI returns the BIMI record published under the selector ("" for the
+// default selector). It fails with a RecordNotFoundError if there is none.
+func (c *Client) GetBIMI(ctx context.Context, zone, selector string) (BIMI, error) {
+	name := BIMI{Selector: selector}.Name()
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone, Type: RecordTypeTXT})
+	if err != nil {
+		return BIMI{}, err
+	}
+	for _, rr := range records {
+		if canonicalName(rr.Name) == strings.ToLower(name) && hasTXTPrefix(rr, "v=BIMI1") {
+			bimi, err := ParseBIMI(rr.Content)
+			bimi.Selector = selector
+			return bimi, err
+		}
+	}
+	return BIMI{}, &RecordNotFoundError{RecordName: name}
+}
+
+// findSPF returns the SPF record at the zone apex, or nil if there is none.
+func (c *Client) findSPF(ctx context.Context, zone string) (*SPF, error) {
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone, Type: RecordTypeTXT})
+	if err != nil {
+		return nil, err
+	}
+	for _, rr := range records {
+		if canonicalName(rr.Name) != "@" || !hasTXTPrefix(rr, "v=spf1") {
+			continue
+		}
+		if spf, err := ParseSPF(rr.Content); err == nil {
+			return &spf, nil
+		}
+	}
+	return nil, nil
+}
+
+// ensureTXT ensures a TXT record, replacing only TXT records at the name that start with prefix.
+func (c *Client) ensureTXT(ctx context.Context, zone, name, content, prefix string) (EnsureResult, error) {
+	params := CreateDNSRecordParams{Name: name, Type: RecordTypeTXT, Content: content}
+	return c.ensureRR(ctx, zone, params, func(rr DNSRecord) bool {
+		return hasTXTPrefix(rr, prefix)
+	})
+}
+
+// hasTXTPrefix reports whether the TXT content starts with the tag, ignoring case.
+func hasTXTPrefix(rr DNSRecord, prefix string) bool {
+	content := Canonicalize(rr).Content
+	return len(content) >= len(prefix) && strings.EqualFold(content[:len(prefix)], prefix)
+}
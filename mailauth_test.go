@@ -0,0 +1,507 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSPF(t *testing.T) {
+	spf, err := ParseSPF(`"v=spf1 mx ip4:192.0.2.0/24 include:_spf.google.com ~all"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mx", "ip4:192.0.2.0/24", "include:_spf.google.com"}, spf.Mechanisms)
+	assert.Equal(t, "~all", spf.All)
+	assert.Equal(t, []string{"_spf.google.com"}, spf.Includes())
+	assert.Equal(t, 2, spf.Lookups())
+	assert.Equal(t, "v=spf1 mx ip4:192.0.2.0/24 include:_spf.google.com ~all", spf.String())
+
+	spf, err = ParseSPF("v=spf1 redirect=_spf.yandex.net")
+	require.NoError(t, err)
+	assert.Equal(t, "_spf.yandex.net", spf.Redirect)
+
+	_, err = ParseSPF("google-site-verification=abc")
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestSPF_MergeAndValidate(t *testing.T) {
+	spf := SPF{Mechanisms: []string{"mx"}, All: "-all"}
+	spf.AddInclude("_spf.google.com", "_spf.google.com")
+	spf.Merge(SPF{Mechanisms: []string{"MX", "include:spf.protection.outlook.com"}, All: "~all"})
+	assert.Equal(t, "v=spf1 mx include:_spf.google.com include:spf.protection.outlook.com -all", spf.String())
+	assert.NoError(t, spf.Validate())
+
+	for i := 0; i < 9; i++ {
+		spf.AddInclude(strings.Repeat("x", i+1) + ".example.com")
+	}
+	var authErr *EmailAuthError
+	require.True(t, errors.As(spf.Validate(), &authErr))
+	assert.Contains(t, authErr.Message, "DNS lookups")
+
+	long := SPF{All: "-all"}
+	for i := 0; i < 20; i++ {
+		long.Mechanisms = append(long.Mechanisms, fmt.Sprintf("ip4:192.0.2.%d", 100+i))
+	}
+	assert.ErrorContains(t, long.Validate(), "length")
+
+	assert.ErrorContains(t, SPF{Mechanisms: []string{"bogus:1"}}.Validate(), "unknown mechanism")
+	assert.ErrorContains(t, SPF{Mechanisms: []string{"include:"}}.Validate(), "requires a value")
+}
+
+func TestSPF_Builder(t *testing.T) {
+	var spf SPF
+	spf.AddMechanism(SPFPass, "mx")
+	spf.AddIP("192.0.2.0/24", "2001:db8::/32", "192.0.2.0/24")
+	spf.AddMechanism(SPFSoftFail, "a:legacy.example.com")
+	spf.AddInclude("_spf.google.com")
+	spf.All = SPFFail + "all"
+	assert.Equal(t, "v=spf1 mx ip4:192.0.2.0/24 ip6:2001:db8::/32 ~a:legacy.example.com include:_spf.google.com -all", spf.String())
+	assert.NoError(t, spf.Validate())
+
+	assert.True(t, spf.RemoveMechanism("A:legacy.example.com"))
+	assert.False(t, spf.RemoveMechanism("ptr"))
+	assert.Equal(t, []string{"mx", "ip4:192.0.2.0/24", "ip6:2001:db8::/32", "include:_spf.google.com"}, spf.Mechanisms)
+
+	assert.ErrorContains(t, SPF{Mechanisms: []string{"ip4:2001:db8::1"}}.Validate(), "invalid network")
+	assert.ErrorContains(t, SPF{Mechanisms: []string{"ip6:192.0.2.1"}}.Validate(), "invalid network")
+	assert.ErrorContains(t, SPF{Mechanisms: []string{"ip4:192.0.2.0/33"}}.Validate(), "invalid network")
+}
+
+// startTXTNameserver starts a local nameserver answering TXT queries from
+// records and returns a resolver using it.
+func startTXTNameserver(t *testing.T, records map[string]string) *net.Resolver {
+	t.Helper()
+
+	addr := startDNSServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		q := req.Question[0]
+		if txt, ok := records[q.Name]; ok && q.Qtype == dns.TypeTXT {
+			m.Answer = append(m.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 300},
+				Txt: []string{txt},
+			})
+		} else if !ok {
+			m.Rcode = dns.RcodeNameError
+		}
+		_ = w.WriteMsg(m)
+	})
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", addr)
+		},
+	}
+}
+
+func TestSPF_ResolveLookups(t *testing.T) {
+	resolver := startTXTNameserver(t, map[string]string{
+		"_spf.example.net.":  "v=spf1 include:_spf1.example.net include:_spf2.example.net ~all",
+		"_spf1.example.net.": "v=spf1 ip4:192.0.2.0/24 ~all",
+		"_spf2.example.net.": "v=spf1 a mx ~all",
+		"_spf.example.org.":  "v=spf1 redirect=_spf.example.net",
+		"nospf.example.net.": "verification=abc",
+	})
+
+	spf := SPF{Mechanisms: []string{"mx", "include:_spf.example.net"}, All: "-all"}
+	n, err := spf.ResolveLookups(context.Background(), resolver)
+	require.NoError(t, err)
+	assert.Equal(t, 2+2+0+2, n)
+
+	spf = SPF{Redirect: "_spf.example.org"}
+	n, err = spf.ResolveLookups(context.Background(), resolver)
+	require.NoError(t, err)
+	assert.Equal(t, 1+1+4, n)
+
+	_, err = SPF{Mechanisms: []string{"include:nospf.example.net"}}.ResolveLookups(context.Background(), resolver)
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+
+	_, err = SPF{Mechanisms: []string{"include:missing.example.net"}}.ResolveLookups(context.Background(), resolver)
+	assert.Error(t, err)
+}
+
+func TestDKIM(t *testing.T) {
+	dkim := DKIM{Selector: "mail", PublicKey: "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC="}
+	assert.Equal(t, "mail._domainkey", dkim.Name())
+	assert.Equal(t, "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC=", dkim.String())
+	assert.NoError(t, dkim.Validate())
+
+	assert.ErrorIs(t, DKIM{Selector: "mail", PublicKey: "not base64!"}.Validate(), ErrInvalidEmailAuth)
+	assert.ErrorIs(t, DKIM{PublicKey: "AAAA"}.Validate(), ErrInvalidEmailAuth)
+	assert.ErrorIs(t, DKIM{Selector: "mail", KeyType: "dsa", PublicKey: "AAAA"}.Validate(), ErrInvalidEmailAuth)
+}
+
+func TestDMARC(t *testing.T) {
+	dmarc := DMARC{
+		Policy:           DMARCPolicyQuarantine,
+		Percent:          50,
+		AggregateReports: []string{"dmarc@example.com"},
+		DKIMAlignment:    "s",
+	}
+	assert.NoError(t, dmarc.Validate())
+	assert.Equal(t, "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com; adkim=s", dmarc.String())
+
+	parsed, err := ParseDMARC(dmarc.String())
+	require.NoError(t, err)
+	assert.Equal(t, DMARCPolicyQuarantine, parsed.Policy)
+	assert.Equal(t, 50, parsed.Percent)
+	assert.Equal(t, []string{"mailto:dmarc@example.com"}, parsed.AggregateReports)
+
+	assert.ErrorIs(t, DMARC{Policy: "block"}.Validate(), ErrInvalidEmailAuth)
+	assert.ErrorIs(t, DMARC{Policy: DMARCPolicyNone, Percent: 101}.Validate(), ErrInvalidEmailAuth)
+	assert.ErrorIs(t, DMARC{Policy: DMARCPolicyNone, SPFAlignment: "x"}.Validate(), ErrInvalidEmailAuth)
+
+	_, err = ParseDMARC("v=spf1 -all")
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestClient_UpsertEmailAuth(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "v=spf1 mx ~all"},
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "google-site-verification=abc"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.UpsertEmailAuth(context.Background(), "example.com", EmailAuthPolicy{
+		SPF:   &SPF{Mechanisms: []string{"include:_spf.google.com"}, All: "-all"},
+		DKIM:  []DKIM{{Selector: "google", PublicKey: "AAAA"}},
+		DMARC: &DMARC{Policy: DMARCPolicyReject},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, EnsureActionReplaced, results[0].Action)
+	assert.Equal(t, "v=spf1 mx include:_spf.google.com -all", results[0].Record.Content)
+	require.Len(t, results[0].Replaced, 1, "other TXT records at the apex are kept")
+	assert.Equal(t, "v=spf1 mx ~all", results[0].Replaced[0].Content)
+
+	assert.Equal(t, "google._domainkey", results[1].Record.Name)
+	assert.Equal(t, "_dmarc", results[2].Record.Name)
+	assert.Equal(t, "v=DMARC1; p=reject", results[2].Record.Content)
+
+	for _, call := range *calls {
+		if call.Path == "zone/remove_record" {
+			assert.NotEqual(t, "google-site-verification=abc", call.Input["content"])
+		}
+	}
+}
+
+func TestClient_SetAndGetSPF(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "v=spf1 mx include:_spf.mail.ru ~all"},
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "google-site-verification=abc"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	spf, err := client.GetSPF(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, SPF{Mechanisms: []string{"mx", "include:_spf.mail.ru"}, All: "~all"}, spf)
+
+	spf.RemoveMechanism("include:_spf.mail.ru")
+	spf.AddInclude("_spf.yandex.net")
+	result, err := client.SetSPF(context.Background(), "example.com", spf)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionReplaced, result.Action)
+	assert.Equal(t, "v=spf1 mx include:_spf.yandex.net ~all", result.Record.Content)
+	require.Len(t, result.Replaced, 1)
+	assert.Equal(t, "v=spf1 mx include:_spf.mail.ru ~all", result.Replaced[0].Content)
+	assert.Equal(t, 1, countCalls(*calls, "zone/remove_record"))
+
+	_, err = client.SetSPF(context.Background(), "example.com", SPF{Mechanisms: []string{"ip4:bogus"}})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestClient_GetSPF_NotFound(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.GetSPF(context.Background(), "example.com")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestDKIM_FlagsAndChunking(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 294))
+	dkim := DKIM{Selector: "mail", PublicKey: key, Flags: []string{DKIMFlagTesting, DKIMFlagStrict}}
+	require.NoError(t, dkim.Validate())
+	assert.Equal(t, "v=DKIM1; k=rsa; t=y:s; p="+key, dkim.String())
+
+	chunked := dkim.Chunked()
+	assert.True(t, strings.HasPrefix(chunked, `"v=DKIM1; k=rsa; t=y:s; p=`))
+	assert.Equal(t, dkim.String(), canonicalTXT(chunked))
+
+	parsed, err := ParseDKIM(chunked)
+	require.NoError(t, err)
+	assert.Equal(t, DKIM{KeyType: "rsa", PublicKey: key, Flags: []string{"y", "s"}}, parsed)
+
+	parsed, err = ParseDKIM("v=DKIM1; k=ed25519; p=11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo= ")
+	require.NoError(t, err)
+	assert.Equal(t, "ed25519", parsed.KeyType)
+
+	_, err = ParseDKIM("v=DKIM1; k=rsa")
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+	_, err = ParseDKIM("k=rsa; v=DKIM1; p=abc")
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+	assert.ErrorContains(t, DKIM{Selector: "mail", PublicKey: key, Flags: []string{"x"}}.Validate(), "unknown flag")
+}
+
+// setupTXTZoneServer creates a test HTTP server serving the records of
+// testZoneRecords and the TXT records added through zone/add_txt.
+func setupTXTZoneServer(t *testing.T, store func(subdomain, text string) bool) *httptest.Server {
+	t.Helper()
+
+	records := testZoneRecords()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case "zone/get_resource_records":
+			require.NoError(t, json.NewEncoder(w).Encode(records))
+		case "zone/add_txt":
+			var input map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("input_data")), &input))
+			subdomain, text := input["subdomain"].(string), input["text"].(string)
+			if store(subdomain, text) {
+				domain := &records.Answer.Domains[0]
+				domain.RRList = append(domain.RRList, ResourceRecord{Subname: subdomain, Rectype: "TXT", Content: text})
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"result": "success"}))
+		default:
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"result": "success"}))
+		}
+	}))
+}
+
+func TestClient_PublishDKIM(t *testing.T) {
+	var added []string
+	server := setupTXTZoneServer(t, func(subdomain, text string) bool {
+		added = append(added, text)
+		return true
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	key := base64.StdEncoding.EncodeToString(make([]byte, 294))
+	dkim := DKIM{Selector: "mail", PublicKey: key}
+
+	result, err := client.PublishDKIM(context.Background(), "example.com", dkim)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionCreated, result.Action)
+	require.Len(t, added, 1)
+	assert.Equal(t, dkim.Chunked(), added[0])
+
+	result, err = client.PublishDKIM(context.Background(), "example.com", dkim)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionNone, result.Action)
+	assert.Len(t, added, 1)
+
+	err = client.VerifyDKIM(context.Background(), "example.com", DKIM{Selector: "mail", PublicKey: "c3RhbGU="})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+	err = client.VerifyDKIM(context.Background(), "example.com", DKIM{Selector: "other", PublicKey: key})
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	_, err = client.PublishDKIM(context.Background(), "example.com", DKIM{Selector: "mail"})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestClient_PublishDKIM_NotVisible(t *testing.T) {
+	server := setupTXTZoneServer(t, func(string, string) bool { return false })
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.PublishDKIM(context.Background(), "example.com", DKIM{Selector: "mail", PublicKey: "c3RhbGU="})
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestBIMI(t *testing.T) {
+	bimi := BIMI{Logo: "https://example.com/logo.svg", Authority: "https://example.com/vmc.pem"}
+	assert.Equal(t, "default._bimi", bimi.Name())
+	assert.Equal(t, "brand._bimi", BIMI{Selector: "brand"}.Name())
+	assert.Equal(t, "v=BIMI1; l=https://example.com/logo.svg; a=https://example.com/vmc.pem", bimi.String())
+	assert.NoError(t, bimi.Validate())
+
+	parsed, err := ParseBIMI(`"v=BIMI1; l=https://example.com/logo.svg; a=https://example.com/vmc.pem;"`)
+	require.NoError(t, err)
+	assert.Equal(t, bimi, parsed)
+
+	parsed, err = ParseBIMI("v=BIMI1; l=https://example.com/logo.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "v=BIMI1; l=https://example.com/logo.svg", parsed.String())
+
+	_, err = ParseBIMI("v=DMARC1; p=none")
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+
+	assert.ErrorContains(t, BIMI{Logo: "http://example.com/logo.svg"}.Validate(), "not an HTTPS URL")
+	assert.ErrorContains(t, BIMI{Logo: "https://example.com/logo.png"}.Validate(), "does not point at a .svg file")
+	assert.ErrorContains(t, BIMI{Logo: "logo.svg"}.Validate(), "not a valid URL")
+	assert.ErrorContains(t, BIMI{Logo: "https://example.com/logo.svg", Authority: "https://example.com/vmc.crt"}.Validate(), "authority")
+	assert.ErrorIs(t, BIMI{Selector: "a.b", Logo: "https://example.com/logo.svg"}.Validate(), ErrInvalidEmailAuth)
+}
+
+func TestClient_SetAndGetBIMI(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "default._bimi", Rectype: "TXT", Content: "v=BIMI1; l=https://example.com/old.svg"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	current, err := client.GetBIMI(context.Background(), "example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old.svg", current.Logo)
+
+	_, err = client.GetBIMI(context.Background(), "example.com", "brand")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	result, err := client.SetBIMI(context.Background(), "example.com", BIMI{Logo: "https://example.com/new.svg"})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionReplaced, result.Action)
+	assert.Equal(t, "v=BIMI1; l=https://example.com/new.svg", result.Record.Content)
+	assert.Equal(t, 1, countCalls(*calls, "zone/remove_record"))
+
+	_, err = client.SetBIMI(context.Background(), "example.com", BIMI{Logo: "https://example.com/new.png"})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestClient_UpsertEmailAuth_LongDKIMKey(t *testing.T) {
+	var added []string
+	server := setupTXTZoneServer(t, func(subdomain, text string) bool {
+		added = append(added, text)
+		return true
+	})
+	defer server.Close()
+
+	// The content is chunked even if automatic TXT chunking is disabled
+	client := setupTestClient(t, server, WithTXTChunking(false))
+	// 294 bytes is the size of a 2048-bit RSA public key
+	dkim := DKIM{Selector: "mail", PublicKey: base64.StdEncoding.EncodeToString(make([]byte, 294))}
+	policy := EmailAuthPolicy{DKIM: []DKIM{dkim}}
+
+	results, err := client.UpsertEmailAuth(context.Background(), "example.com", policy)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, EnsureActionCreated, results[0].Action)
+	require.Len(t, added, 1)
+	assert.Equal(t, dkim.Chunked(), added[0])
+	assert.Greater(t, len(dkim.String()), MaxTXTStringLength)
+
+	results, err = client.UpsertEmailAuth(context.Background(), "example.com", policy)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionNone, results[0].Action)
+	assert.Len(t, added, 1)
+}
+
+func TestClient_UpsertEmailAuth_BIMIRequiresEnforcement(t *testing.T) {
+	client := NewClient("user", "pass")
+
+	_, err := client.UpsertEmailAuth(context.Background(), "example.com", EmailAuthPolicy{
+		DMARC: &DMARC{Policy: DMARCPolicyNone},
+		BIMI:  &BIMI{Logo: "https://example.com/logo.svg"},
+	})
+	assert.ErrorContains(t, err, "quarantine or reject")
+}
+
+func TestClient_SetAndGetDMARC(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "_dmarc", Rectype: "TXT", Content: "v=DMARC1; p=none; rua=mailto:old@example.com"},
+		ResourceRecord{Subname: "_dmarc", Rectype: "TXT", Content: "verification=abc"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	current, err := client.GetDMARC(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, DMARC{Policy: DMARCPolicyNone, AggregateReports: []string{"mailto:old@example.com"}}, current)
+
+	current.Policy = DMARCPolicyQuarantine
+	current.Percent = 25
+	result, err := client.SetDMARC(context.Background(), "example.com", current)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureActionReplaced, result.Action)
+	assert.Equal(t, "v=DMARC1; p=quarantine; pct=25; rua=mailto:old@example.com", result.Record.Content)
+	require.Len(t, result.Replaced, 1)
+	assert.Equal(t, "_dmarc", result.Replaced[0].Name)
+	assert.Equal(t, 1, countCalls(*calls, "zone/remove_record"))
+
+	_, err = client.SetDMARC(context.Background(), "example.com", DMARC{Policy: "block"})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+}
+
+func TestClient_GetDMARC_NotFound(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.GetDMARC(context.Background(), "example.com")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+}
+
+func TestClient_UpsertEmailAuth_Invalid(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.UpsertEmailAuth(context.Background(), "example.com", EmailAuthPolicy{
+		DMARC: &DMARC{Policy: "block"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidEmailAuth)
+	assert.Empty(t, *calls)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"sort"
+)
+
+// MailPreset is the DNS setup of a hosted mail service: MX records, the SPF
+// mechanisms the service sends from, and fixed records such as DKIM CNAMEs and
+// autodiscover. Record names and contents may reference variables as in
+// ZoneTemplate.
+type MailPreset struct {
+	Name        string
+	Description string
+	// Variables lists the variables that must be set in Vars.
+	Variables []string
+	// Vars holds the values of Variables.
+	Vars map[string]string
+	// Records are the records the service needs. The record sets they belong
+	// to, such as the apex MX records, are replaced by ApplyMailPreset.
+	Records []DNSRecord
+	// SPF holds the mechanisms merged into the SPF record at the zone apex.
+	SPF SPF
+}
+
+// Built-in mail presets.
+var (
+	// MailPresetGoogleWorkspace sets up Google Workspace mail. The DKIM key is
+	// generated in the Admin console; publish it with PublishDKIM.
+	MailPresetGoogleWorkspace = MailPreset{
+		Name:        "google-workspace",
+		Description: "Google Workspace (MX and SPF)",
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "1 smtp.google.com"},
+		},
+		SPF: SPF{Mechanisms: []string{"include:_spf.google.com"}, All: "~all"},
+	}
+
+	// MailPresetYandex360 sets up Yandex 360 mail. The DKIM key is shown in the
+	// admin panel; publish it with PublishDKIM.
+	MailPresetYandex360 = MailPreset{
+		Name:        "yandex-360",
+		Description: "Yandex 360 (MX and SPF)",
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "10 mx.yandex.net"},
+		},
+		SPF: SPF{Mechanisms: []string{"include:_spf.yandex.net"}, All: "~all"},
+	}
+
+	// MailPresetMicrosoft365 sets up Microsoft 365 mail. The tenant variable is
+	// the onmicrosoft.com tenant name the DKIM CNAMEs point into.
+	MailPresetMicrosoft365 = MailPreset{
+		Name:        "microsoft-365",
+		Description: "Microsoft 365 (MX, SPF, DKIM CNAMEs and autodiscover)",
+		Variables:   []string{"tenant"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "0 ${zone_dashed}.mail.protection.outlook.com"},
+			{Name: "autodiscover", Type: RecordTypeCNAME, Content: "autodiscover.outlook.com"},
+			{Name: "selector1._domainkey", Type: RecordTypeCNAME, Content: "selector1-${zone_dashed}._domainkey.${tenant}.onmicrosoft.com"},
+			{Name: "selector2._domainkey", Type: RecordTypeCNAME, Content: "selector2-${zone_dashed}._domainkey.${tenant}.onmicrosoft.com"},
+		},
+		SPF: SPF{Mechanisms: []string{"include:spf.protection.outlook.com"}, All: "-all"},
+	}
+)
+
+// builtinMailPresets holds the built-in mail presets by name.
+var builtinMailPresets = map[string]MailPreset{
+	MailPresetGoogleWorkspace.Name: MailPresetGoogleWorkspace,
+	MailPresetYandex360.Name:       MailPresetYandex360,
+	MailPresetMicrosoft365.Name:    MailPresetMicrosoft365,
+}
+
+// MailPresets returns the built-in mail presets sorted by name.
+func MailPresets() []MailPreset {
+	presets := make([]MailPreset, 0, len(builtinMailPresets))
+	for _, p := range builtinMailPresets {
+		presets = append(presets, p)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets
+}
+
+// LookupMailPreset returns the built-in mail preset with the name.
+func LookupMailPreset(name string) (MailPreset, bool) {
+	p, ok := builtinMailPresets[name]
+	return p, ok
+}
+
+// ApplyMailPreset makes the zone use the mail service of the preset. The record
+// sets of the preset records are synced with SyncZone, so previous MX records
+// are removed, and the preset SPF mechanisms are merged into the existing SPF
+// record, keeping its "all" if set. Other records are left alone and applying
+// a preset again makes no changes. WithDryRun previews the changes.
+func (c *Client) ApplyMailPreset(ctx context.Context, zone string, preset MailPreset, opts ...BulkOption) (BatchResults, error) {
+	tmpl := ZoneTemplate{Name: preset.Name, Variables: preset.Variables, Records: preset.Records}
+	desired, err := tmpl.Render(zone, preset.Vars)
+	if err != nil {
+		return nil, err
+	}
+
+	spf := preset.SPF
+	existing, err := c.findSPF(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		spf = *existing
+		spf.Merge(preset.SPF)
+	}
+	if err := spf.Validate(); err != nil {
+		return nil, err
+	}
+	desired = append(desired, DNSRecord{Name: "@", Type: RecordTypeTXT, Content: spf.String()})
+
+	type rrset struct {
+		name  string
+		rtype RecordType
+	}
+	managed := make(map[rrset]bool, len(desired))
+	for _, rr := range desired {
+		key := keyOf(rr)
+		managed[rrset{name: key.name, rtype: key.recordType}] = true
+	}
+	filter := func(rr DNSRecord) bool {
+		key := keyOf(rr)
+		if key.recordType == RecordTypeTXT {
+			return key.name == "@" && hasTXTPrefix(rr, "v=spf1")
+		}
+		return managed[rrset{name: key.name, rtype: key.recordType}]
+	}
+
+	return c.SyncZone(ctx, zone, desired, append(opts, WithRecordFilter(filter))...)
+}
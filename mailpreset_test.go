@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailPresets(t *testing.T) {
+	presets := MailPresets()
+	require.Len(t, presets, 3)
+	for i, preset := range presets {
+		if i > 0 {
+			assert.Less(t, presets[i-1].Name, preset.Name)
+		}
+		assert.NoError(t, preset.SPF.Validate(), preset.Name)
+	}
+
+	preset, ok := LookupMailPreset("yandex-360")
+	require.True(t, ok)
+	assert.Equal(t, MailPresetYandex360.Description, preset.Description)
+	_, ok = LookupMailPreset("unknown")
+	assert.False(t, ok)
+}
+
+func TestClient_ApplyMailPreset(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "v=spf1 mx ~all"},
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "google-site-verification=abc"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.ApplyMailPreset(context.Background(), "example.com", MailPresetGoogleWorkspace)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var created, deleted []string
+	for _, r := range results {
+		switch r.Operation {
+		case BatchOperationCreate:
+			created = append(created, r.Item.Type.String()+" "+r.Item.Content)
+		case BatchOperationDelete:
+			deleted = append(deleted, r.Item.Type.String()+" "+r.Item.Content)
+		}
+	}
+	assert.ElementsMatch(t, []string{"MX 1 smtp.google.com", "TXT v=spf1 mx include:_spf.google.com ~all"}, created)
+	assert.ElementsMatch(t, []string{"MX 10 mail.example.com", "TXT v=spf1 mx ~all"}, deleted)
+	assert.Equal(t, 2, countCalls(*calls, "zone/remove_record"))
+}
+
+func TestClient_ApplyMailPreset_Idempotent(t *testing.T) {
+	records := testZoneRecords()
+	domain := &records.Answer.Domains[0]
+	domain.RRList = append(domain.RRList[:3],
+		ResourceRecord{Subname: "@", Rectype: "MX", Content: "0 example-com.mail.protection.outlook.com"},
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "v=spf1 include:spf.protection.outlook.com -all"},
+		ResourceRecord{Subname: "autodiscover", Rectype: "CNAME", Content: "autodiscover.outlook.com."},
+		ResourceRecord{Subname: "selector1._domainkey", Rectype: "CNAME", Content: "selector1-example-com._domainkey.contoso.onmicrosoft.com"},
+		ResourceRecord{Subname: "selector2._domainkey", Rectype: "CNAME", Content: "selector2-example-com._domainkey.contoso.onmicrosoft.com"},
+	)
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	preset := MailPresetMicrosoft365
+	preset.Vars = map[string]string{"tenant": "contoso"}
+
+	results, err := client.ApplyMailPreset(context.Background(), "example.com", preset)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	for _, call := range *calls {
+		assert.Equal(t, "zone/get_resource_records", call.Path)
+	}
+
+	_, err = client.ApplyMailPreset(context.Background(), "example.com", MailPresetMicrosoft365)
+	assert.ErrorIs(t, err, ErrMissingTemplateVariable)
+}
@@ -17,58 +17,260 @@ limitations under the License.
 // Package regru provides types for DNS zones and records.
 package regru
 
+import (
+	"strings"
+	"time"
+)
+
+// RecordType represents a DNS record type.
+type RecordType string
+
 // DNS record types
 const (
-	RecordTypeA     = "A"
-	RecordTypeAAAA  = "AAAA"
-	RecordTypeCNAME = "CNAME"
-	RecordTypeMX    = "MX"
-	RecordTypeNS    = "NS"
-	RecordTypeSRV   = "SRV"
-	RecordTypeTXT   = "TXT"
+	RecordTypeA     RecordType = "A"
+	RecordTypeAAAA  RecordType = "AAAA"
+	RecordTypeCNAME RecordType = "CNAME"
+	RecordTypeMX    RecordType = "MX"
+	RecordTypeNS    RecordType = "NS"
+	RecordTypeSRV   RecordType = "SRV"
+	RecordTypeTXT   RecordType = "TXT"
+	RecordTypeCAA   RecordType = "CAA"
+	RecordTypePTR   RecordType = "PTR"
+	RecordTypeSSHFP RecordType = "SSHFP"
+	RecordTypeSPF   RecordType = "SPF"
+	RecordTypeLOC   RecordType = "LOC"
 )
 
+// supportedRecordTypes lists the record types that can be managed through the client.
+var supportedRecordTypes = []RecordType{
+	RecordTypeA,
+	RecordTypeAAAA,
+	RecordTypeCNAME,
+	RecordTypeMX,
+	RecordTypeNS,
+	RecordTypeSRV,
+	RecordTypeTXT,
+	RecordTypeCAA,
+	RecordTypePTR,
+	RecordTypeSSHFP,
+	RecordTypeSPF,
+	RecordTypeLOC,
+}
+
+// SupportedRecordTypes returns the record types that can be managed through the client.
+func SupportedRecordTypes() []RecordType {
+	types := make([]RecordType, len(supportedRecordTypes))
+	copy(types, supportedRecordTypes)
+	return types
+}
+
+// String returns the record type as a string.
+func (t RecordType) String() string {
+	return string(t)
+}
+
+// Canonical returns the record type in canonical form ("a" -> "A").
+func (t RecordType) Canonical() RecordType {
+	return RecordType(strings.ToUpper(strings.TrimSpace(string(t))))
+}
+
+// IsValid reports whether the record type is a syntactically valid type mnemonic:
+// a letter followed by letters or digits, in any case.
+func (t RecordType) IsValid() bool {
+	value := t.Canonical()
+	if value == "" {
+		return false
+	}
+	for i, c := range value {
+		switch {
+		case c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Supported reports whether records of this type can be managed through the client.
+// The check is case-insensitive.
+func (t RecordType) Supported() bool {
+	value := t.Canonical()
+	for _, supported := range supportedRecordTypes {
+		if value == supported {
+			return true
+		}
+	}
+	return false
+}
+
 // DNSRecord represents a DNS record in a zone.
 type DNSRecord struct {
-	Content string `json:"content,omitempty"`
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Proxied bool   `json:"proxied,omitempty"`
-	TTL     int    `json:"ttl,omitempty"`
-	Type    string `json:"type,omitempty"`
+	Content string     `json:"content,omitempty"`
+	ID      string     `json:"id,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	Proxied bool       `json:"proxied,omitempty"`
+	TTL     int        `json:"ttl,omitempty"`
+	Type    RecordType `json:"type,omitempty"`
+	// TTLDuration is the TTL as a duration. When set, it takes precedence over TTL.
+	// Records returned by the client only set TTL; GetTTL returns it as a duration.
+	TTLDuration time.Duration `json:"-"`
+	// Priority is the MX priority reported by the API. Content may carry the
+	// priority as well ("10 mail.example.com"), in which case it takes precedence.
+	Priority int `json:"priority,omitempty"`
+	// Duplicate is set on records that repeat an earlier record with the same
+	// name, type and content in a ListRecords result (see ListDNSRecordsParams.IncludeDuplicates).
+	Duplicate bool `json:"duplicate,omitempty"`
+	// TTLInherited is set on records listed without their own TTL, whose TTL is
+	// the zone TTL from the SOA. Use WithRecordIDs to list the real TTLs.
+	TTLInherited bool `json:"ttl_inherited,omitempty"`
 }
 
 // CreateDNSRecordParams params for creating DNS record.
 type CreateDNSRecordParams struct {
-	Content  string `json:"content,omitempty"`
-	ID       string `json:"id,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Proxied  bool   `json:"proxied,omitempty"`
-	TTL      int    `json:"ttl,omitempty"`
-	Type     string `json:"type,omitempty"`
-	ZoneID   string `json:"zone_id,omitempty"`
-	ZoneName string `json:"zone_name,omitempty"`
-	// SRV record specific fields
-	Priority int `json:"priority,omitempty"` // For SRV records
+	Content  string     `json:"content,omitempty"`
+	ID       string     `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Proxied  bool       `json:"proxied,omitempty"`
+	TTL      int        `json:"ttl,omitempty"`
+	Type     RecordType `json:"type,omitempty"`
+	ZoneID   string     `json:"zone_id,omitempty"`
+	ZoneName string     `json:"zone_name,omitempty"`
+	// TTLDuration is the TTL as a duration. When set, it takes precedence over TTL.
+	TTLDuration time.Duration `json:"-"`
+	// Data is the typed record data (MXData, SRVData, CAAData, TXTData). It is
+	// an alternative to Content and sets Type when Type is empty.
+	Data RecordData `json:"-"`
+	// MX and SRV record specific fields
+	Priority int `json:"priority,omitempty"` // For MX and SRV records
 	Port     int `json:"port,omitempty"`     // For SRV records
+	// LOC record specific fields, used when Content is empty
+	Latitude  float64 `json:"latitude,omitempty"`  // For LOC records, in degrees; negative is south
+	Longitude float64 `json:"longitude,omitempty"` // For LOC records, in degrees; negative is west
+	Altitude  float64 `json:"altitude,omitempty"`  // For LOC records, in meters
 }
 
 // ListDNSRecordsParams params for list DNS records.
 type ListDNSRecordsParams struct {
-	Content  string `json:"content,omitempty"`
-	ID       string `json:"id,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Proxied  bool   `json:"proxied,omitempty"`
-	TTL      int    `json:"ttl,omitempty"`
-	Type     string `json:"type,omitempty"`
-	ZoneID   string `json:"zone_id,omitempty"`
-	ZoneName string `json:"zone_name,omitempty"`
+	Content  string     `json:"content,omitempty"`
+	ID       string     `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Proxied  bool       `json:"proxied,omitempty"`
+	TTL      int        `json:"ttl,omitempty"`
+	Type     RecordType `json:"type,omitempty"`
+	ZoneID   string     `json:"zone_id,omitempty"`
+	ZoneName string     `json:"zone_name,omitempty"`
+	// IncludeDuplicates keeps records that repeat an earlier record with the same
+	// name, type and content, marking them as Duplicate. By default they are dropped.
+	IncludeDuplicates bool `json:"include_duplicates,omitempty"`
 }
 
+// Zone statuses derived from the domain service state.
+const (
+	ZoneStatusActive    = "active"
+	ZoneStatusExpired   = "expired"
+	ZoneStatusSuspended = "suspended"
+	ZoneStatusInactive  = "inactive"
+)
+
 // Zone describes a DNS zone.
 type Zone struct {
 	ID          string   `json:"id,omitempty"`
 	Name        string   `json:"name,omitempty"`
 	NameServers []string `json:"name_servers,omitempty"`
-	Status      string   `json:"status,omitempty"`
+	// Status is one of the ZoneStatus constants, or empty if the API reported no state.
+	Status string `json:"status,omitempty"`
+	// State is the reg.ru service state ("A" for active, "S" for suspended, ...).
+	State          string    `json:"state,omitempty"`
+	ExpirationDate time.Time `json:"expiration_date,omitempty"`
+}
+
+// GetTTL returns the record TTL as a duration.
+func (r *DNSRecord) GetTTL() time.Duration {
+	return time.Duration(effectiveTTL(r.TTL, r.TTLDuration)) * time.Second
+}
+
+// GetTTL returns the requested TTL as a duration.
+func (p *CreateDNSRecordParams) GetTTL() time.Duration {
+	return time.Duration(effectiveTTL(p.TTL, p.TTLDuration)) * time.Second
+}
+
+// Domain describes a registered domain in the account.
+type Domain struct {
+	Name      string `json:"name,omitempty"`
+	ServiceID string `json:"service_id,omitempty"`
+	// State is the reg.ru service state ("A" for active, "N" for not activated, ...).
+	State          string    `json:"state,omitempty"`
+	CreationDate   time.Time `json:"creation_date,omitempty"`
+	ExpirationDate time.Time `json:"expiration_date,omitempty"`
+}
+
+// Balance describes the funds of the account.
+type Balance struct {
+	// Prepay is the available prepaid balance.
+	Prepay float64 `json:"prepay"`
+	// Blocked is the amount reserved for pending operations.
+	Blocked float64 `json:"blocked"`
+	// Credit is the available credit.
+	Credit   float64 `json:"credit"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// Bill describes an invoice issued for the account.
+type Bill struct {
+	ID       string  `json:"id,omitempty"`
+	Date     string  `json:"date,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	PayType  string  `json:"pay_type,omitempty"`
+	Status   string  `json:"status,omitempty"`
+	Total    float64 `json:"total"`
+}
+
+// DomainOrder describes a domain registration order.
+type DomainOrder struct {
+	Name string `json:"name"`
+	// Period is the registration period in years (1 if zero).
+	Period int `json:"period,omitempty"`
+	// Nameservers to delegate the domain to.
+	Nameservers []string `json:"nameservers,omitempty"`
+	// Contacts holds the registrant contact fields required by the registry,
+	// such as "person", "p_addr", "phone" and "e_mail".
+	Contacts map[string]string `json:"contacts,omitempty"`
+}
+
+// Order describes a registration or renewal order and its bill.
+type Order struct {
+	Domain    string `json:"domain,omitempty"`
+	ServiceID string `json:"service_id,omitempty"`
+	// BillID is the bill issued for the order, or "" if it was paid at once.
+	BillID   string  `json:"bill_id,omitempty"`
+	Payment  float64 `json:"payment"`
+	Currency string  `json:"currency,omitempty"`
+	Status   string  `json:"status,omitempty"`
+}
+
+// DomainPrice describes the yearly prices of a TLD.
+type DomainPrice struct {
+	TLD      string  `json:"tld"`
+	Register float64 `json:"register"`
+	Renew    float64 `json:"renew"`
+}
+
+// PriceList holds the domain prices by TLD (lowercase, without a leading dot).
+type PriceList struct {
+	Currency string                 `json:"currency,omitempty"`
+	Prices   map[string]DomainPrice `json:"prices"`
+}
+
+// RenewalPrice returns the renewal price for a domain, matching the longest
+// priced suffix (so "example.msk.ru" uses "msk.ru" before "ru").
+func (p PriceList) RenewalPrice(domain string) (DomainPrice, bool) {
+	_, suffix, found := strings.Cut(canonicalHost(domain), ".")
+	for found {
+		if price, ok := p.Prices[suffix]; ok {
+			return price, true
+		}
+		_, suffix, found = strings.Cut(suffix, ".")
+	}
+	return DomainPrice{}, false
 }
@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordType(t *testing.T) {
+	tests := []struct {
+		name          string
+		recordType    RecordType
+		wantCanonical RecordType
+		wantValid     bool
+		wantSupported bool
+	}{
+		{name: "canonical A", recordType: "A", wantCanonical: RecordTypeA, wantValid: true, wantSupported: true},
+		{name: "lower case a", recordType: "a", wantCanonical: RecordTypeA, wantValid: true, wantSupported: true},
+		{name: "mixed case cname", recordType: "CName", wantCanonical: RecordTypeCNAME, wantValid: true, wantSupported: true},
+		{name: "valid but unsupported", recordType: "hinfo", wantCanonical: "HINFO", wantValid: true, wantSupported: false},
+		{name: "type with digits", recordType: "NSEC3", wantCanonical: "NSEC3", wantValid: true, wantSupported: false},
+		{name: "empty", recordType: "", wantCanonical: "", wantValid: false, wantSupported: false},
+		{name: "leading digit", recordType: "3A", wantCanonical: "3A", wantValid: false, wantSupported: false},
+		{name: "punctuation", recordType: "A-B", wantCanonical: "A-B", wantValid: false, wantSupported: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCanonical, tt.recordType.Canonical())
+			assert.Equal(t, tt.wantValid, tt.recordType.IsValid())
+			assert.Equal(t, tt.wantSupported, tt.recordType.Supported())
+		})
+	}
+}
+
+func TestSupportedRecordTypes(t *testing.T) {
+	types := SupportedRecordTypes()
+	assert.Contains(t, types, RecordTypeA)
+	assert.Contains(t, types, RecordTypeTXT)
+
+	// Modifying the returned slice must not affect the package state
+	types[0] = "BOGUS"
+	assert.True(t, RecordTypeA.Supported())
+}
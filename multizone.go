@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ZoneResult is the outcome of a multi-zone change for one zone.
+type ZoneResult struct {
+	// Zone is the zone name as passed by the caller.
+	Zone string
+	// Record is the created or deleted record; for created records ID holds
+	// the dns_id reported by the API.
+	Record DNSRecord
+	// Success reports whether the API applied the change to the zone.
+	Success bool
+	// Err is the error reported for the zone, if any.
+	Err error
+}
+
+// multiZoneRequest sends a single-record request for several zones at once
+// by replacing its domains list.
+type multiZoneRequest struct {
+	APIRequest
+	zones []string
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *multiZoneRequest) MarshalJSON() ([]byte, error) {
+	fields, err := requestFields(r.APIRequest)
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]map[string]string, 0, len(r.zones))
+	for _, zone := range r.zones {
+		domains = append(domains, map[string]string{"dname": zone})
+	}
+	fields["domains"] = domains
+	return json.Marshal(fields)
+}
+
+// AddRRMulti creates the same record in several zones with a single API call.
+// The results follow the order of zones; a zone rejected by the API does not
+// stop the others.
+func (c *Client) AddRRMulti(ctx context.Context, zones []string, params CreateDNSRecordParams) ([]ZoneResult, error) {
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	path, apiReq, params, err := c.Records.addRequest(zones[0], params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.multiZone(ctx, zones, path, apiReq, addedRecord(params))
+}
+
+// DeleteRRMulti deletes the same record from several zones with a single API
+// call. The results follow the order of zones; a zone rejected by the API
+// does not stop the others.
+func (c *Client) DeleteRRMulti(ctx context.Context, zones []string, rr DNSRecord) ([]ZoneResult, error) {
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	path, apiReq, err := c.Records.removeRequest(zones[0], rr)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.multiZone(ctx, zones, path, apiReq, rr)
+}
+
+// multiZone sends the request for all zones and maps the per-domain answer to results.
+func (c *Client) multiZone(ctx context.Context, zones []string, path string, apiReq APIRequest, record DNSRecord) ([]ZoneResult, error) {
+	apiZones := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		apiZones = append(apiZones, c.asciiName(zone))
+	}
+
+	resp, err := Call[AddNSResponse](ctx, c, path, &multiZoneRequest{APIRequest: apiReq, zones: apiZones})
+	for _, zone := range zones {
+		c.InvalidateRecordsCache(zone)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make(map[string]DomainResult, len(resp.Answer.Domains))
+	for _, domain := range resp.Answer.Domains {
+		domains[domain.DName] = domain
+	}
+
+	results := make([]ZoneResult, 0, len(zones))
+	for i, zone := range zones {
+		result := ZoneResult{Zone: zone, Record: record}
+		domain, ok := domains[apiZones[i]]
+		switch {
+		case !ok:
+			result.Err = fmt.Errorf("no %s result for zone %s", path, zone)
+		case domain.Result != "success":
+			result.Err = newAPIError(domain.ErrorCode, domain.ErrorText)
+		default:
+			result.Success = true
+			if domain.DNSID != "" {
+				result.Record.ID = domain.DNSID
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AddRRMulti(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_alias": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{
+					{DName: "example.com", Result: "success", DNSID: "101"},
+					{DName: "example.net", Result: "error", ErrorCode: "DOMAIN_NOT_FOUND", ErrorText: "Domain not found"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.AddRRMulti(context.Background(), []string{"example.com", "example.net", "example.org"}, CreateDNSRecordParams{
+		Name:    "www",
+		Type:    "a",
+		Content: "192.0.2.1",
+		TTL:     300,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/add_alias", (*calls)[0].Path)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"dname": "example.com"},
+		map[string]interface{}{"dname": "example.net"},
+		map[string]interface{}{"dname": "example.org"},
+	}, (*calls)[0].Input["domains"])
+	assert.Equal(t, "www", (*calls)[0].Input["subdomain"])
+	assert.Equal(t, "192.0.2.1", (*calls)[0].Input["ipaddr"])
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "example.com", results[0].Zone)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, "101", results[0].Record.ID)
+	assert.Equal(t, RecordTypeA, results[0].Record.Type)
+
+	assert.False(t, results[1].Success)
+	var apiErr *APIError
+	require.ErrorAs(t, results[1].Err, &apiErr)
+	assert.Equal(t, "DOMAIN_NOT_FOUND", apiErr.Code)
+
+	assert.False(t, results[2].Success)
+	assert.Error(t, results[2].Err)
+}
+
+func TestClient_DeleteRRMulti(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/remove_record": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{
+					{DName: "example.com", Result: "success"},
+					{DName: "example.net", Result: "success"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	rr := DNSRecord{Name: "old", Type: RecordTypeA, Content: "192.0.2.9"}
+	results, err := client.DeleteRRMulti(context.Background(), []string{"example.com", "example.net"}, rr)
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/remove_record", (*calls)[0].Path)
+	assert.Len(t, (*calls)[0].Input["domains"], 2)
+	assert.Equal(t, "A", (*calls)[0].Input["record_type"])
+
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.True(t, result.Success)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, rr, result.Record)
+	}
+}
+
+func TestClient_AddRRMulti_RecordMatchesAdd(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_mx": AddNSResponse{
+			Answer: AddNSAnswer{
+				Domains: []DomainResult{{DName: "example.com", Result: "success"}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	params := CreateDNSRecordParams{Name: "@", Type: RecordTypeMX, Content: "mail.example.com", Priority: 10, TTLDuration: 5 * time.Minute}
+
+	added, err := client.AddRR(context.Background(), "example.com", params)
+	require.NoError(t, err)
+
+	results, err := client.AddRRMulti(context.Background(), []string{"example.com"}, params)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, added, results[0].Record)
+	assert.Equal(t, 300, results[0].Record.TTL)
+	assert.Equal(t, 10, results[0].Record.Priority)
+}
+
+func TestClient_AddRRMulti_NoZones(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.AddRRMulti(context.Background(), nil, CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, *calls)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// OwnerRecordPrefix starts the names of the TXT records marking record ownership.
+const OwnerRecordPrefix = "_regru-owner-"
+
+// ownerHeritage identifies ownership TXT records created by this package.
+const ownerHeritage = "heritage=regru-go"
+
+// WithOwner makes SyncZone track the records it manages with TXT ownership
+// markers and only delete records owned by id. See OwnerRecordName.
+func WithOwner(id string) BulkOption {
+	return func(o *bulkOptions) {
+		o.owner = id
+	}
+}
+
+// OwnerRecordName returns the name of the TXT record marking the owner of the
+// records with the name and type, for example "_regru-owner-a.www" for www A
+// records and "_regru-owner-a" for apex A records. A leading wildcard label is
+// replaced with "_wildcard", as "*" may only appear first in a name.
+func OwnerRecordName(name string, recordType RecordType) string {
+	label := OwnerRecordPrefix + strings.ToLower(string(recordType.Canonical()))
+	name = canonicalName(name)
+	switch {
+	case name == "@":
+		return label
+	case name == "*":
+		return label + "._wildcard"
+	case strings.HasPrefix(name, "*."):
+		return label + "._wildcard." + strings.TrimPrefix(name, "*.")
+	default:
+		return label + "." + name
+	}
+}
+
+// OwnerRecordContent returns the content of the TXT ownership marker for id.
+func OwnerRecordContent(id string) string {
+	return ownerHeritage + ",regru-go/owner=" + id
+}
+
+// ownerSet identifies the records of one name and type.
+type ownerSet struct {
+	name       string
+	recordType RecordType
+}
+
+// setOf returns the owner set of a record.
+func setOf(rr DNSRecord) ownerSet {
+	c := Canonicalize(rr)
+	return ownerSet{name: c.Name, recordType: c.Type}
+}
+
+// parseOwnerRecord returns the owned set and owner of a TXT ownership marker.
+func parseOwnerRecord(rr DNSRecord) (ownerSet, string, bool) {
+	c := Canonicalize(rr)
+	if c.Type != RecordTypeTXT || !strings.HasPrefix(c.Name, OwnerRecordPrefix) || !strings.HasPrefix(c.Content, ownerHeritage+",") {
+		return ownerSet{}, "", false
+	}
+
+	label, name, _ := strings.Cut(strings.TrimPrefix(c.Name, OwnerRecordPrefix), ".")
+	switch {
+	case name == "":
+		name = "@"
+	case name == "_wildcard":
+		name = "*"
+	case strings.HasPrefix(name, "_wildcard."):
+		name = "*." + strings.TrimPrefix(name, "_wildcard.")
+	}
+
+	var owner string
+	for _, field := range strings.Split(c.Content, ",") {
+		if value, ok := strings.CutPrefix(field, "regru-go/owner="); ok {
+			owner = value
+		}
+	}
+	if owner == "" {
+		return ownerSet{}, "", false
+	}
+	return ownerSet{name: name, recordType: RecordType(strings.ToUpper(label)).Canonical()}, owner, true
+}
+
+// syncOwned implements SyncZone with WithOwner. A name and type is claimed with
+// a marker when SyncZone creates its first records; sets that already hold
+// records without a marker stay unowned, so records created by people or other
+// tools are never deleted. Sets owned by another owner are reported with an
+// OwnershipError and left unchanged. Markers of sets whose records were all
+// deleted are removed.
+func (c *Client) syncOwned(ctx context.Context, zone string, current, desired []DNSRecord, o bulkOptions) BatchResults {
+	owners := make(map[ownerSet]string)
+	markers := make(map[ownerSet]DNSRecord)
+	var records []DNSRecord
+	for _, rr := range current {
+		if set, owner, ok := parseOwnerRecord(rr); ok {
+			owners[set] = owner
+			if owner == o.owner {
+				markers[set] = rr
+			}
+			continue
+		}
+		records = append(records, rr)
+	}
+
+	present := make(map[recordKey]DNSRecord, len(records))
+	populated := make(map[ownerSet]int)
+	for _, rr := range records {
+		present[keyOf(rr)] = rr
+		populated[setOf(rr)]++
+	}
+
+	wanted := make(map[recordKey]bool, len(desired))
+	wantedSets := make(map[ownerSet]bool)
+	for _, rr := range desired {
+		if _, _, ok := parseOwnerRecord(rr); !ok {
+			wanted[keyOf(rr)] = true
+			wantedSets[setOf(rr)] = true
+		}
+	}
+	stale := func(rr DNSRecord) bool {
+		return owners[setOf(rr)] == o.owner && !wanted[keyOf(rr)] && syncDeletable(rr) && o.filter.match(rr)
+	}
+	remove := func(rr DNSRecord) BatchResult {
+		result := c.batchDelete(ctx, zone, rr, o.dryRun)
+		if result.Err == nil {
+			populated[setOf(rr)]--
+		}
+		return result
+	}
+
+	var results BatchResults
+	for _, rr := range records {
+		if deleteFirst(rr) && stale(rr) {
+			results = append(results, remove(rr))
+		}
+	}
+
+	seen := make(map[recordKey]bool, len(desired))
+	for _, rr := range desired {
+		if _, _, ok := parseOwnerRecord(rr); ok {
+			continue
+		}
+		key, set := keyOf(rr), setOf(rr)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if owner, ok := owners[set]; ok && owner != o.owner {
+			results = append(results, BatchResult{
+				Item:      rr,
+				Operation: BatchOperationCreate,
+				Err:       &OwnershipError{Name: set.name, Type: set.recordType, Owner: owner},
+			})
+			continue
+		}
+		if existing, ok := present[key]; ok {
+			if o.syncTTL && owners[set] == o.owner && ttlDiffers(existing, rr) && o.filter.match(existing) {
+				results = append(results, c.batchUpdate(ctx, zone, existing, rr, o.dryRun))
+			}
+			continue
+		}
+		if _, ok := owners[set]; !ok && populated[set] == 0 {
+			marker := CreateDNSRecordParams{
+				Name:    OwnerRecordName(set.name, set.recordType),
+				Type:    RecordTypeTXT,
+				Content: OwnerRecordContent(o.owner),
+			}
+			result := c.batchCreate(ctx, zone, marker, o.dryRun)
+			results = append(results, result)
+			if result.Err != nil {
+				continue
+			}
+			owners[set] = o.owner
+		}
+		results = append(results, c.batchCreate(ctx, zone, paramsFromRecord(rr), o.dryRun))
+	}
+
+	for _, rr := range records {
+		if !deleteFirst(rr) && stale(rr) {
+			results = append(results, remove(rr))
+		}
+	}
+
+	var obsolete []DNSRecord
+	for set, marker := range markers {
+		if !wantedSets[set] && populated[set] == 0 {
+			obsolete = append(obsolete, marker)
+		}
+	}
+	sort.Slice(obsolete, func(i, j int) bool { return canonicalName(obsolete[i].Name) < canonicalName(obsolete[j].Name) })
+	for _, marker := range obsolete {
+		results = append(results, c.batchDelete(ctx, zone, marker, o.dryRun))
+	}
+
+	return results
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnerRecordName(t *testing.T) {
+	assert.Equal(t, "_regru-owner-a.www", OwnerRecordName("WWW", RecordTypeA))
+	assert.Equal(t, "_regru-owner-txt", OwnerRecordName("@", "txt"))
+	assert.Equal(t, "_regru-owner-cname._wildcard.dev", OwnerRecordName("*.dev", RecordTypeCNAME))
+	assert.Equal(t, "heritage=regru-go,regru-go/owner=ci", OwnerRecordContent("ci"))
+
+	for _, name := range []string{"www", "@", "*", "*.dev"} {
+		set, owner, ok := parseOwnerRecord(DNSRecord{
+			Name:    OwnerRecordName(name, RecordTypeAAAA),
+			Type:    RecordTypeTXT,
+			Content: `"` + OwnerRecordContent("ci") + `"`,
+		})
+		require.True(t, ok, name)
+		assert.Equal(t, ownerSet{name: name, recordType: RecordTypeAAAA}, set)
+		assert.Equal(t, "ci", owner)
+	}
+
+	_, _, ok := parseOwnerRecord(DNSRecord{Name: "_regru-owner-a", Type: RecordTypeTXT, Content: "unrelated"})
+	assert.False(t, ok)
+}
+
+func TestClient_SyncZone_WithOwner(t *testing.T) {
+	records := ZoneGetResourceRecordsResponse{
+		Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+			DName: "example.com",
+			RRList: []ResourceRecord{
+				// Owned by us
+				{Subname: "_regru-owner-a.app", Rectype: "TXT", Content: "heritage=regru-go,regru-go/owner=ci"},
+				{Subname: "app", Rectype: "A", Content: "192.0.2.10"},
+				{Subname: "app", Rectype: "A", Content: "192.0.2.11"},
+				// Owned by us, no longer desired
+				{Subname: "_regru-owner-a.old", Rectype: "TXT", Content: "heritage=regru-go,regru-go/owner=ci"},
+				{Subname: "old", Rectype: "A", Content: "192.0.2.20"},
+				// Owned by another tool
+				{Subname: "_regru-owner-a.api", Rectype: "TXT", Content: "heritage=regru-go,regru-go/owner=other"},
+				{Subname: "api", Rectype: "A", Content: "192.0.2.30"},
+				// Managed by hand
+				{Subname: "www", Rectype: "A", Content: "192.0.2.2"},
+			},
+		}}},
+	}
+
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.SyncZone(context.Background(), "example.com", []DNSRecord{
+		{Name: "app", Type: RecordTypeA, Content: "192.0.2.10"},
+		{Name: "api", Type: RecordTypeA, Content: "192.0.2.31"},
+		{Name: "new", Type: RecordTypeA, Content: "192.0.2.40"},
+	}, WithOwner("ci"))
+	require.NoError(t, err)
+
+	var summary []string
+	for _, r := range results {
+		summary = append(summary, string(r.Operation)+" "+r.Item.Name+" "+r.Item.Content)
+	}
+	assert.Equal(t, []string{
+		"create api 192.0.2.31",
+		"create _regru-owner-a.new heritage=regru-go,regru-go/owner=ci",
+		"create new 192.0.2.40",
+		"delete app 192.0.2.11",
+		"delete old 192.0.2.20",
+		"delete _regru-owner-a.old heritage=regru-go,regru-go/owner=ci",
+	}, summary)
+
+	assert.True(t, errors.Is(results[0].Err, ErrOwnedByOther))
+	var ownErr *OwnershipError
+	require.True(t, errors.As(results[0].Err, &ownErr))
+	assert.Equal(t, "other", ownErr.Owner)
+
+	// The hand-managed www record is untouched
+	for _, call := range *calls {
+		if call.Path == "zone/remove_record" {
+			assert.NotEqual(t, "www", call.Input["subdomain"])
+		}
+	}
+	assert.Len(t, *calls, 6)
+}
+
+func TestClient_SyncZone_WithOwner_UnownedSet(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	// www A already holds a record without a marker, so it is not claimed
+	results, err := client.SyncZone(context.Background(), "example.com", []DNSRecord{
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.3"},
+	}, WithOwner("ci"), WithDryRun())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, BatchOperationCreate, results[0].Operation)
+	assert.Equal(t, "www", results[0].Item.Name)
+	assert.Len(t, *calls, 1)
+}
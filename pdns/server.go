@@ -0,0 +1,344 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pdns exposes a subset of the PowerDNS Authoritative HTTP API backed
+// by a reg.ru client, so tools that speak the PowerDNS API can manage reg.ru zones.
+//
+// Supported endpoints:
+//
+//	GET   /api/v1/servers
+//	GET   /api/v1/servers/{server_id}
+//	GET   /api/v1/servers/{server_id}/zones
+//	GET   /api/v1/servers/{server_id}/zones/{zone_id}
+//	PATCH /api/v1/servers/{server_id}/zones/{zone_id}
+//
+// PATCH supports the REPLACE and DELETE change types for RRsets. TTLs are
+// applied to created records only, since reg.ru does not return record TTLs.
+package pdns
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mixanemca/regru-go"
+)
+
+const (
+	// DefaultServerID is the server identifier reported by the API.
+	DefaultServerID = "localhost"
+	// DefaultTTL is the TTL reported for records whose TTL is unknown.
+	DefaultTTL = 3600
+)
+
+// Client is the subset of regru.Client methods used by the server.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// Server is an http.Handler implementing the PowerDNS API subset.
+type Server struct {
+	client   Client
+	apiKey   string
+	serverID string
+	mux      *http.ServeMux
+}
+
+// Option represents an option for configuring the server.
+type Option func(*Server)
+
+// WithAPIKey requires clients to send the key in the X-API-Key header.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithServerID sets the server identifier used in URLs (default "localhost").
+func WithServerID(id string) Option {
+	return func(s *Server) {
+		s.serverID = id
+	}
+}
+
+// NewServer creates a new PowerDNS API server backed by the client.
+// Without WithAPIKey all requests are rejected.
+func NewServer(client Client, opts ...Option) *Server {
+	s := &Server{
+		client:   client,
+		serverID: DefaultServerID,
+		mux:      http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /api/v1/servers", s.handleListServers)
+	s.mux.HandleFunc("GET /api/v1/servers/{server_id}", s.handleGetServer)
+	s.mux.HandleFunc("GET /api/v1/servers/{server_id}/zones", s.handleListZones)
+	s.mux.HandleFunc("GET /api/v1/servers/{server_id}/zones/{zone_id}", s.handleGetZone)
+	s.mux.HandleFunc("PATCH /api/v1/servers/{server_id}/zones/{zone_id}", s.handlePatchZone)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("X-API-Key")
+	if s.apiKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(s.apiKey)) != 1 {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleListServers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []serverInfo{s.info()})
+}
+
+func (s *Server) handleGetServer(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.info())
+}
+
+func (s *Server) handleListZones(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	zones, err := s.client.ListZones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	result := make([]zone, 0, len(zones))
+	for _, z := range zones {
+		result = append(result, s.zone(z.Name))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleGetZone(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	name, ok := s.findZone(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := s.client.ListRecords(r.Context(), regru.ListDNSRecordsParams{ZoneName: name})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	result := s.zone(name)
+	result.RRSets = toRRSets(name, records)
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handlePatchZone(w http.ResponseWriter, r *http.Request) {
+	if !s.checkServer(w, r) {
+		return
+	}
+
+	name, ok := s.findZone(w, r)
+	if !ok {
+		return
+	}
+
+	var patch zonePatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+
+	for _, set := range patch.RRSets {
+		switch strings.ToUpper(set.ChangeType) {
+		case "REPLACE", "DELETE":
+		default:
+			writeError(w, http.StatusUnprocessableEntity, "unsupported changetype "+set.ChangeType)
+			return
+		}
+	}
+
+	current, err := s.client.ListRecords(r.Context(), regru.ListDNSRecordsParams{ZoneName: name})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	for _, set := range patch.RRSets {
+		if err := s.applyRRSet(r.Context(), name, current, set); err != nil {
+			writeClientError(w, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyRRSet replaces or deletes the records of one RRset.
+func (s *Server) applyRRSet(ctx context.Context, zoneName string, current []regru.DNSRecord, set rrset) error {
+	name := relativeName(set.Name, zoneName)
+	recordType := regru.RecordType(set.Type).Canonical()
+
+	var desired []regru.DNSRecord
+	if strings.EqualFold(set.ChangeType, "REPLACE") {
+		for _, rec := range set.Records {
+			if rec.Disabled {
+				continue
+			}
+			desired = append(desired, regru.DNSRecord{
+				Name:    name,
+				Type:    recordType,
+				Content: fromPDNSContent(recordType, rec.Content),
+				TTL:     set.TTL,
+			})
+		}
+	}
+
+	var existing []regru.DNSRecord
+	key := regru.Canonicalize(regru.DNSRecord{Name: name, Type: recordType})
+	for _, rr := range current {
+		if c := regru.Canonicalize(rr); c.Name == key.Name && c.Type == key.Type {
+			existing = append(existing, rr)
+		}
+	}
+
+	// Create missing records first, so the name keeps resolving if a create fails
+	for _, rr := range desired {
+		if containsRecord(existing, rr) {
+			continue
+		}
+		params := regru.CreateDNSRecordParams{
+			Name:    rr.Name,
+			Type:    rr.Type,
+			Content: rr.Content,
+			TTL:     rr.TTL,
+		}
+		if _, err := s.client.AddRR(ctx, zoneName, params); err != nil {
+			return err
+		}
+	}
+
+	// Delete records that are no longer wanted
+	for _, rr := range existing {
+		if !containsRecord(desired, rr) {
+			if err := s.client.DeleteRR(ctx, zoneName, rr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkServer verifies the server_id path value.
+func (s *Server) checkServer(w http.ResponseWriter, r *http.Request) bool {
+	if r.PathValue("server_id") != s.serverID {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return false
+	}
+	return true
+}
+
+// findZone resolves the zone_id path value to a zone in the account.
+func (s *Server) findZone(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := canonicalZone(r.PathValue("zone_id"))
+
+	zones, err := s.client.ListZones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return "", false
+	}
+
+	for _, z := range zones {
+		if canonicalZone(z.Name) == id {
+			return z.Name, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Could not find domain '"+r.PathValue("zone_id")+"'")
+	return "", false
+}
+
+// info returns the server description.
+func (s *Server) info() serverInfo {
+	return serverInfo{
+		Type:       "Server",
+		ID:         s.serverID,
+		DaemonType: "authoritative",
+		Version:    "regru-go",
+		URL:        "/api/v1/servers/" + s.serverID,
+		ZonesURL:   "/api/v1/servers/" + s.serverID + "/zones{/zone}",
+	}
+}
+
+// zone returns the description of a zone without RRsets.
+func (s *Server) zone(name string) zone {
+	id := canonicalZone(name) + "."
+	return zone{
+		ID:     id,
+		Name:   id,
+		Type:   "Zone",
+		URL:    "/api/v1/servers/" + s.serverID + "/zones/" + id,
+		Kind:   "Native",
+		RRSets: []rrset{},
+	}
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in PowerDNS format.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeClientError maps client errors to HTTP status codes.
+func writeClientError(w http.ResponseWriter, err error) {
+	var (
+		apiErr  *regru.APIError
+		httpErr *regru.HTTPError
+	)
+	switch {
+	case errors.Is(err, regru.ErrZoneNotFound), errors.Is(err, regru.ErrRecordNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, regru.ErrUnsupportedRecordType), errors.As(err, &apiErr):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.As(err, &httpErr):
+		writeError(w, http.StatusBadGateway, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
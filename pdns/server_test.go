@@ -0,0 +1,205 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is an in-memory Client implementation.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	deleted []regru.DNSRecord
+	// addErr is returned by AddRR if set.
+	addErr error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		zones: []regru.Zone{{ID: "1", Name: "example.com"}},
+		records: map[string][]regru.DNSRecord{
+			"example.com": {
+				{Name: "@", Type: regru.RecordTypeA, Content: "192.0.2.1"},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1"},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.2"},
+				{Name: "@", Type: regru.RecordTypeTXT, Content: "v=spf1 -all"},
+				{Name: "@", Type: regru.RecordTypeMX, Content: "10 mail.example.com"},
+			},
+		},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	return f.records[params.ZoneName], nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	if f.addErr != nil {
+		return regru.DNSRecord{}, f.addErr
+	}
+	f.added = append(f.added, params)
+	return regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content}, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-API-Key", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer(newFakeClient(), WithAPIKey("secret"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.Header.Set("X-API-Key", "wrong")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_NoAPIKey(t *testing.T) {
+	server := NewServer(newFakeClient())
+
+	rec := doRequest(t, server, http.MethodGet, "/api/v1/servers", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_ListZones(t *testing.T) {
+	server := NewServer(newFakeClient(), WithAPIKey("secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/api/v1/servers/localhost/zones", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var zones []zone
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&zones))
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com.", zones[0].ID)
+	assert.Equal(t, "Native", zones[0].Kind)
+}
+
+func TestServer_UnknownServer(t *testing.T) {
+	server := NewServer(newFakeClient(), WithAPIKey("secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/api/v1/servers/other/zones", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_GetZone(t *testing.T) {
+	server := NewServer(newFakeClient(), WithAPIKey("secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/api/v1/servers/localhost/zones/example.com.", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var z zone
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&z))
+	require.Len(t, z.RRSets, 4)
+
+	assert.Equal(t, rrset{Name: "example.com.", Type: "A", TTL: DefaultTTL, Records: []record{{Content: "192.0.2.1"}}}, z.RRSets[0])
+	assert.Equal(t, "10 mail.example.com.", z.RRSets[1].Records[0].Content)
+	assert.Equal(t, `"v=spf1 -all"`, z.RRSets[2].Records[0].Content)
+	assert.Equal(t, "www.example.com.", z.RRSets[3].Name)
+	assert.Len(t, z.RRSets[3].Records, 2)
+}
+
+func TestServer_GetZone_NotFound(t *testing.T) {
+	server := NewServer(newFakeClient(), WithAPIKey("secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/api/v1/servers/localhost/zones/other.com.", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_PatchZone(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client, WithAPIKey("secret"))
+
+	body := `{"rrsets": [
+		{"name": "www.example.com.", "type": "A", "ttl": 300, "changetype": "REPLACE",
+		 "records": [{"content": "192.0.2.2", "disabled": false}, {"content": "192.0.2.3", "disabled": false}]},
+		{"name": "example.com.", "type": "TXT", "changetype": "DELETE", "records": []},
+		{"name": "_acme-challenge.example.com.", "type": "TXT", "ttl": 60, "changetype": "REPLACE",
+		 "records": [{"content": "\"token\"", "disabled": false}]}
+	]}`
+
+	rec := doRequest(t, server, http.MethodPatch, "/api/v1/servers/localhost/zones/example.com", body)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	require.Len(t, client.deleted, 2)
+	assert.Equal(t, "192.0.2.1", client.deleted[0].Content)
+	assert.Equal(t, regru.RecordTypeTXT, client.deleted[1].Type)
+
+	require.Len(t, client.added, 2)
+	assert.Equal(t, regru.CreateDNSRecordParams{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.3", TTL: 300}, client.added[0])
+	assert.Equal(t, regru.CreateDNSRecordParams{Name: "_acme-challenge", Type: regru.RecordTypeTXT, Content: "token", TTL: 60}, client.added[1])
+}
+
+func TestServer_PatchZone_CreateFails(t *testing.T) {
+	client := newFakeClient()
+	client.addErr = &regru.APIError{Code: "INVALID_IP", Message: "invalid IP"}
+	server := NewServer(client, WithAPIKey("secret"))
+
+	body := `{"rrsets": [{"name": "www.example.com.", "type": "A", "ttl": 300, "changetype": "REPLACE",
+		"records": [{"content": "192.0.2.9", "disabled": false}]}]}`
+
+	rec := doRequest(t, server, http.MethodPatch, "/api/v1/servers/localhost/zones/example.com", body)
+	assert.NotEqual(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, client.deleted, "existing records are kept when a create fails")
+}
+
+func TestServer_PatchZone_UnsupportedChangeType(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client, WithAPIKey("secret"))
+
+	body := `{"rrsets": [{"name": "www.example.com.", "type": "A", "changetype": "EXTEND", "records": []}]}`
+
+	rec := doRequest(t, server, http.MethodPatch, "/api/v1/servers/localhost/zones/example.com.", body)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Empty(t, client.added)
+	assert.Empty(t, client.deleted)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdns
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// serverInfo represents a PowerDNS server object.
+type serverInfo struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	DaemonType string `json:"daemon_type"`
+	Version    string `json:"version"`
+	URL        string `json:"url"`
+	ZonesURL   string `json:"zones_url"`
+}
+
+// zone represents a PowerDNS zone object.
+type zone struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	URL    string  `json:"url"`
+	Kind   string  `json:"kind"`
+	Serial int     `json:"serial"`
+	RRSets []rrset `json:"rrsets"`
+}
+
+// rrset represents a PowerDNS resource record set.
+type rrset struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	TTL        int      `json:"ttl"`
+	ChangeType string   `json:"changetype,omitempty"`
+	Records    []record `json:"records"`
+}
+
+// record represents a single PowerDNS record.
+type record struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+// zonePatch represents the body of a PATCH zone request.
+type zonePatch struct {
+	RRSets []rrset `json:"rrsets"`
+}
+
+// errorResponse represents a PowerDNS error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// toRRSets groups records by name and type into RRsets with absolute names.
+func toRRSets(zoneName string, records []regru.DNSRecord) []rrset {
+	index := make(map[string]int)
+	sets := []rrset{}
+
+	for _, rr := range records {
+		name := absoluteName(rr.Name, zoneName)
+		key := name + " " + string(rr.Type)
+
+		i, ok := index[key]
+		if !ok {
+			ttl := rr.TTL
+			if ttl == 0 {
+				ttl = DefaultTTL
+			}
+			sets = append(sets, rrset{Name: name, Type: string(rr.Type), TTL: ttl})
+			i = len(sets) - 1
+			index[key] = i
+		}
+		sets[i].Records = append(sets[i].Records, record{Content: toPDNSContent(rr.Type, rr.Content)})
+	}
+
+	sort.SliceStable(sets, func(a, b int) bool {
+		if sets[a].Name != sets[b].Name {
+			return sets[a].Name < sets[b].Name
+		}
+		return sets[a].Type < sets[b].Type
+	})
+
+	return sets
+}
+
+// canonicalZone returns the zone name in lower case without a trailing dot.
+func canonicalZone(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// absoluteName converts a name relative to the zone into an absolute name with a trailing dot.
+func absoluteName(name, zoneName string) string {
+	zoneName = canonicalZone(zoneName)
+	switch {
+	case name == "" || name == "@":
+		return zoneName + "."
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name)
+	default:
+		return strings.ToLower(name) + "." + zoneName + "."
+	}
+}
+
+// relativeName converts an absolute name into a name relative to the zone.
+func relativeName(name, zoneName string) string {
+	name = canonicalZone(name)
+	zoneName = canonicalZone(zoneName)
+	switch {
+	case name == zoneName:
+		return "@"
+	case strings.HasSuffix(name, "."+zoneName):
+		return strings.TrimSuffix(name, "."+zoneName)
+	default:
+		return name
+	}
+}
+
+// fqdn appends a trailing dot to a hostname if missing.
+func fqdn(host string) string {
+	if host == "" || strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+// toPDNSContent converts reg.ru record content into PowerDNS presentation format:
+// hostname targets are absolute and TXT and SPF content is quoted.
+func toPDNSContent(recordType regru.RecordType, content string) string {
+	switch recordType {
+	case regru.RecordTypeCNAME, regru.RecordTypeNS, regru.RecordTypePTR:
+		return fqdn(content)
+	case regru.RecordTypeMX, regru.RecordTypeSRV:
+		fields := strings.Fields(content)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = fqdn(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case regru.RecordTypeTXT, regru.RecordTypeSPF:
+		return strconv.Quote(content)
+	default:
+		return content
+	}
+}
+
+// fromPDNSContent converts PowerDNS record content into reg.ru content.
+func fromPDNSContent(recordType regru.RecordType, content string) string {
+	if recordType == regru.RecordTypeTXT || recordType == regru.RecordTypeSPF {
+		return regru.Canonicalize(regru.DNSRecord{Type: recordType, Content: content}).Content
+	}
+	return content
+}
+
+// containsRecord reports whether records contain a record equal to rr.
+func containsRecord(records []regru.DNSRecord, rr regru.DNSRecord) bool {
+	for _, candidate := range records {
+		if candidate.Equal(rr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeAction is the kind of change in a plan.
+type ChangeAction string
+
+const (
+	// ChangeCreate adds a record that is missing from the zone.
+	ChangeCreate ChangeAction = "create"
+	// ChangeUpdate replaces the content of a record with the same name and type.
+	ChangeUpdate ChangeAction = "update"
+	// ChangeDelete removes a record that is not among the desired records.
+	ChangeDelete ChangeAction = "delete"
+)
+
+// Change is a single planned change. Before is nil for creates and After is nil for deletes.
+type Change struct {
+	Action ChangeAction `json:"action"`
+	Before *DNSRecord   `json:"before,omitempty"`
+	After  *DNSRecord   `json:"after,omitempty"`
+	// Reason explains why the change is needed.
+	Reason string `json:"reason"`
+}
+
+// String renders the change as a single line, prefixed with "+", "~" or "-".
+func (c Change) String() string {
+	switch c.Action {
+	case ChangeCreate:
+		return fmt.Sprintf("+ %s (%s)", formatPlanRecord(*c.After), c.Reason)
+	case ChangeUpdate:
+		return fmt.Sprintf("~ %s -> %s (%s)", formatPlanRecord(*c.Before), c.After.Content, c.Reason)
+	default:
+		return fmt.Sprintf("- %s (%s)", formatPlanRecord(*c.Before), c.Reason)
+	}
+}
+
+// Plan is a preview of the changes needed to turn one set of records into another.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the plan contains no changes.
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the action.
+func (p Plan) Count(action ChangeAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// String renders the plan one change per line, followed by a summary line.
+func (p Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.",
+		p.Count(ChangeCreate), p.Count(ChangeUpdate), p.Count(ChangeDelete))
+	return b.String()
+}
+
+// PlanChanges compares the current zone records with the desired records without
+// calling the API. Records are matched by canonical name, type and content, as
+// in SyncZone. Where records of the same name and type are both missing and
+// superfluous, they are paired into updates. Apex NS records and record types
+// the client cannot manage are never deleted. Changes are sorted by name and type.
+func PlanChanges(current, desired []DNSRecord) Plan {
+	type rrset struct {
+		name  string
+		rtype RecordType
+	}
+
+	present := make(map[recordKey]bool, len(current))
+	for _, rr := range current {
+		present[keyOf(rr)] = true
+	}
+	wanted := make(map[recordKey]bool, len(desired))
+
+	missing := make(map[rrset][]DNSRecord)
+	for _, rr := range desired {
+		key := keyOf(rr)
+		seen := wanted[key]
+		wanted[key] = true
+		if seen || present[key] {
+			continue
+		}
+		set := rrset{name: key.name, rtype: key.recordType}
+		missing[set] = append(missing[set], rr)
+	}
+
+	extra := make(map[rrset][]DNSRecord)
+	for _, rr := range current {
+		key := keyOf(rr)
+		if wanted[key] || !syncDeletable(rr) {
+			continue
+		}
+		set := rrset{name: key.name, rtype: key.recordType}
+		extra[set] = append(extra[set], rr)
+	}
+
+	sets := make(map[rrset]bool)
+	for set := range missing {
+		sets[set] = true
+	}
+	for set := range extra {
+		sets[set] = true
+	}
+	ordered := make([]rrset, 0, len(sets))
+	for set := range sets {
+		ordered = append(ordered, set)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].name != ordered[j].name {
+			return ordered[i].name < ordered[j].name
+		}
+		return ordered[i].rtype < ordered[j].rtype
+	})
+
+	var plan Plan
+	for _, set := range ordered {
+		adds, removes := missing[set], extra[set]
+		sortByContent(adds)
+		sortByContent(removes)
+
+		n := min(len(adds), len(removes))
+		for i := 0; i < n; i++ {
+			before, after := removes[i], adds[i]
+			plan.Changes = append(plan.Changes, Change{Action: ChangeUpdate, Before: &before, After: &after, Reason: "content changed"})
+		}
+		for i := n; i < len(adds); i++ {
+			after := adds[i]
+			plan.Changes = append(plan.Changes, Change{Action: ChangeCreate, After: &after, Reason: "missing from zone"})
+		}
+		for i := n; i < len(removes); i++ {
+			before := removes[i]
+			plan.Changes = append(plan.Changes, Change{Action: ChangeDelete, Before: &before, Reason: "not in desired records"})
+		}
+	}
+
+	return plan
+}
+
+// PlanStep reports the outcome of one change applied by ApplyPlan.
+type PlanStep struct {
+	// Index is the zero-based position of the change in the plan.
+	Index int
+	// Total is the number of changes in the plan.
+	Total  int
+	Change Change
+	// Err is the error of the step, if any.
+	Err error
+}
+
+// ApplyPlan executes the changes of a plan in the zone. If confirm is not nil it
+// is called with the plan first, and ErrPlanRejected is returned unless it
+// approves. Updates create the new record before deleting the old one, and the
+// old record is kept if the creation fails; a CNAME is deleted first, since a
+// name can hold only one CNAME record. A failing change does not stop the
+// remaining ones; the per-item results report every API operation. If the
+// context is cancelled, no further changes are started and the context error is
+// returned with the results so far. Use WithProgress for per-step reporting and
+// WithDryRun to go through the plan without changing the zone.
+func (c *Client) ApplyPlan(ctx context.Context, zone string, plan Plan, confirm func(Plan) bool, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	if plan.Empty() {
+		return nil, nil
+	}
+	if confirm != nil && !confirm(plan) {
+		return nil, ErrPlanRejected
+	}
+
+	var results BatchResults
+	for i, change := range plan.Changes {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		var step BatchResults
+		switch change.Action {
+		case ChangeCreate:
+			step = append(step, c.batchCreate(ctx, zone, paramsFromRecord(*change.After), o.dryRun))
+		case ChangeUpdate:
+			if deleteFirst(*change.Before) {
+				step = append(step, c.batchDelete(ctx, zone, *change.Before, o.dryRun))
+				if step[0].Err == nil {
+					step = append(step, c.batchCreate(ctx, zone, paramsFromRecord(*change.After), o.dryRun))
+				}
+				break
+			}
+			step = append(step, c.batchCreate(ctx, zone, paramsFromRecord(*change.After), o.dryRun))
+			if step[0].Err == nil {
+				step = append(step, c.batchDelete(ctx, zone, *change.Before, o.dryRun))
+			}
+		case ChangeDelete:
+			step = append(step, c.batchDelete(ctx, zone, *change.Before, o.dryRun))
+		default:
+			return results, fmt.Errorf("unknown plan action %q", change.Action)
+		}
+		results = append(results, step...)
+
+		if o.progress != nil {
+			o.progress(PlanStep{Index: i, Total: len(plan.Changes), Change: change, Err: step.Err()})
+		}
+	}
+
+	return results, nil
+}
+
+// sortByContent orders records by canonical content.
+func sortByContent(records []DNSRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		return keyOf(records[i]).content < keyOf(records[j]).content
+	})
+}
+
+// formatPlanRecord renders a record as "name TYPE content", with the TTL if set.
+func formatPlanRecord(rr DNSRecord) string {
+	s := fmt.Sprintf("%s %s %s", rr.Name, rr.Type.Canonical(), rr.Content)
+	if ttl := effectiveTTL(rr.TTL, rr.TTLDuration); ttl > 0 {
+		s += fmt.Sprintf(" ttl=%d", ttl)
+	}
+	return s
+}
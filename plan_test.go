@@ -0,0 +1,191 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanChanges(t *testing.T) {
+	current := []DNSRecord{
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"},
+		{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+		{Name: "www", Type: RecordTypeAAAA, Content: "2001:db8::1"},
+		{Name: "@", Type: RecordTypeNS, Content: "ns1.reg.ru"},
+		{Name: "@", Type: "SOA", Content: "ns1.reg.ru. hostmaster.reg.ru. 1 2 3 4 5"},
+	}
+	desired := []DNSRecord{
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1"},
+		{Name: "WWW.", Type: "a", Content: "192.0.2.5"},
+		{Name: "api", Type: RecordTypeA, Content: "192.0.2.3", TTL: 300},
+		{Name: "api", Type: RecordTypeA, Content: "192.0.2.3", TTL: 300},
+	}
+
+	plan := PlanChanges(current, desired)
+	require.Len(t, plan.Changes, 3)
+
+	assert.Equal(t, ChangeCreate, plan.Changes[0].Action)
+	assert.Nil(t, plan.Changes[0].Before)
+	assert.Equal(t, "api", plan.Changes[0].After.Name)
+
+	assert.Equal(t, ChangeUpdate, plan.Changes[1].Action)
+	assert.Equal(t, "192.0.2.2", plan.Changes[1].Before.Content)
+	assert.Equal(t, "192.0.2.5", plan.Changes[1].After.Content)
+
+	assert.Equal(t, ChangeDelete, plan.Changes[2].Action)
+	assert.Equal(t, RecordTypeAAAA, plan.Changes[2].Before.Type)
+	assert.Nil(t, plan.Changes[2].After)
+
+	assert.Equal(t, 1, plan.Count(ChangeUpdate))
+	assert.False(t, plan.Empty())
+	assert.Equal(t, "+ api A 192.0.2.3 ttl=300 (missing from zone)\n"+
+		"~ www A 192.0.2.2 -> 192.0.2.5 (content changed)\n"+
+		"- www AAAA 2001:db8::1 (not in desired records)\n"+
+		"Plan: 1 to create, 1 to update, 1 to delete.", plan.String())
+
+	data, err := json.Marshal(plan.Changes[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"action":"create","after":{"name":"api","type":"A","content":"192.0.2.3","ttl":300},"reason":"missing from zone"}`, string(data))
+}
+
+func TestPlanChanges_NoChanges(t *testing.T) {
+	records := []DNSRecord{{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"}}
+
+	plan := PlanChanges(records, records)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, "Plan: 0 to create, 0 to update, 0 to delete.", plan.String())
+}
+
+func testPlan() Plan {
+	return PlanChanges(
+		[]DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+			{Name: "old", Type: RecordTypeA, Content: "192.0.2.9"},
+		},
+		[]DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.5"},
+			{Name: "api", Type: RecordTypeA, Content: "192.0.2.3"},
+		},
+	)
+}
+
+func TestClient_ApplyPlan(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	var confirmed Plan
+	var steps []PlanStep
+	results, err := client.ApplyPlan(context.Background(), "example.com", testPlan(),
+		func(p Plan) bool {
+			confirmed = p
+			return true
+		},
+		WithProgress(func(step PlanStep) { steps = append(steps, step) }),
+	)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+	assert.Len(t, confirmed.Changes, 3)
+
+	require.Len(t, steps, 3)
+	assert.Equal(t, 2, steps[2].Index)
+	assert.Equal(t, 3, steps[2].Total)
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path+" "+call.Input["subdomain"].(string))
+	}
+	// api is created, old is deleted, www is updated by creating the new record first
+	assert.Equal(t, []string{
+		"zone/add_alias api",
+		"zone/remove_record old",
+		"zone/add_alias www",
+		"zone/remove_record www",
+	}, paths)
+	assert.Len(t, results, 4)
+}
+
+func TestClient_ApplyPlan_Rejected(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.ApplyPlan(context.Background(), "example.com", testPlan(), func(Plan) bool { return false })
+	assert.ErrorIs(t, err, ErrPlanRejected)
+	assert.Empty(t, *calls)
+}
+
+func TestClient_ApplyPlan_UpdateKeepsOldOnFailure(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/add_alias": map[string]interface{}{"result": "error", "error_text": "invalid"},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	plan := PlanChanges(
+		[]DNSRecord{{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"}},
+		[]DNSRecord{{Name: "www", Type: RecordTypeA, Content: "192.0.2.5"}},
+	)
+	results, err := client.ApplyPlan(context.Background(), "example.com", plan, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Error(t, results.Err())
+	assert.Len(t, *calls, 1, "the old record is not deleted")
+}
+
+func TestClient_ApplyPlan_CNAMEDeletedFirst(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	plan := PlanChanges(
+		[]DNSRecord{{Name: "blog", Type: RecordTypeCNAME, Content: "old.example.net"}},
+		[]DNSRecord{{Name: "blog", Type: RecordTypeCNAME, Content: "new.example.net"}},
+	)
+	results, err := client.ApplyPlan(context.Background(), "example.com", plan, nil)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	assert.Equal(t, []string{"zone/remove_record", "zone/add_cname"}, paths)
+}
+
+func TestClient_ApplyPlan_Cancelled(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := client.ApplyPlan(ctx, "example.com", testPlan(), nil,
+		WithProgress(func(PlanStep) { cancel() }))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, results, 1)
+	assert.Len(t, *calls, 1)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+)
+
+// WriteOperation describes an API call that would change data.
+type WriteOperation struct {
+	// Profile is the name of the configuration profile the client was created from.
+	Profile string
+	// Method is the API method path, such as "zone/remove_record".
+	Method string
+	// Params are the request parameters without credentials.
+	Params map[string]interface{}
+}
+
+// WritePolicy decides whether a write operation may be executed. Returning an
+// error blocks the operation; the error is returned to the caller wrapped in a
+// WriteDeniedError.
+type WritePolicy func(ctx context.Context, op WriteOperation) error
+
+// WithWritePolicy sets a policy consulted before every API call that changes
+// data, for example to enforce change freeze windows or require approval.
+// Bulk helpers are covered as well, since every mutation goes through it.
+func WithWritePolicy(policy WritePolicy) ClientOption {
+	return func(c *Client) {
+		c.writePolicy = policy
+	}
+}
+
+// checkWritePolicy consults the write policy for a mutating API method.
+func (c *Client) checkWritePolicy(ctx context.Context, path string, input []byte) error {
+	if c.writePolicy == nil || isReadPath(path) {
+		return nil
+	}
+
+	op := WriteOperation{Profile: c.profile, Method: path, Params: requestParams(input)}
+	if err := c.writePolicy(ctx, op); err != nil {
+		return &WriteDeniedError{Method: path, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WritePolicy(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	freeze := errors.New("change freeze")
+	var ops []WriteOperation
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithProfileName("prod"),
+		WithWritePolicy(func(ctx context.Context, op WriteOperation) error {
+			ops = append(ops, op)
+			return freeze
+		}),
+	)
+
+	_, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err, "reads are not subject to the policy")
+	assert.Empty(t, ops)
+
+	_, err = client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrWriteDenied))
+	assert.True(t, errors.Is(err, freeze))
+
+	var denied *WriteDeniedError
+	require.True(t, errors.As(err, &denied))
+	assert.Equal(t, "zone/add_alias", denied.Method)
+
+	require.Len(t, ops, 1)
+	assert.Equal(t, "prod", ops[0].Profile)
+	assert.Equal(t, "192.0.2.1", ops[0].Params["ipaddr"])
+	assert.NotContains(t, ops[0].Params, "password")
+
+	for _, call := range *calls {
+		assert.NotEqual(t, "zone/add_alias", call.Path, "blocked writes are not sent")
+	}
+}
+
+func TestClient_WritePolicy_IsDelegatedPassesFreeze(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/is_delegated": ZoneIsDelegatedResponse{
+			Answer: ZoneIsDelegatedAnswer{
+				Domains: []ZoneIsDelegatedResult{{DName: "example.com", Result: "success", IsDelegated: 1}},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithWritePolicy(func(ctx context.Context, op WriteOperation) error {
+			return errors.New("change freeze")
+		}),
+	)
+
+	delegated, err := client.IsDelegated(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.True(t, delegated)
+	require.Len(t, *calls, 1)
+}
+
+func TestClient_WritePolicyAllows(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithWritePolicy(func(ctx context.Context, op WriteOperation) error { return nil }),
+	)
+
+	_, err := client.AddRR(context.Background(), "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"})
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "zone/add_alias", (*calls)[0].Path)
+}
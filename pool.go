@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// PoolReport describes the outcome of a pool operation.
+type PoolReport struct {
+	// DryRun is true if no changes were made.
+	DryRun bool
+	// Added contains the records created for new addresses.
+	Added []DNSRecord
+	// Removed contains the records removed from the pool.
+	Removed []DNSRecord
+	// Unchanged contains the records that were already in the desired state.
+	Unchanged []DNSRecord
+}
+
+// SetPool makes the A and AAAA records of name contain exactly the given
+// addresses: missing addresses are added and all other A/AAAA records of the
+// name are removed. New records are created before old ones are removed so
+// the name always resolves. Use WithTTL to set the TTL of new records and
+// WithDryRun to preview the changes.
+func (c *Client) SetPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error) {
+	return c.updatePool(ctx, zone, name, ips, nil, true, opts)
+}
+
+// ReplacePool swaps addresses in the pool of name: the addresses in newIPs
+// are added and those in oldIPs are removed, leaving the rest of the pool in
+// place, for example to roll one backend out and another in. As with SetPool,
+// new records are created before old ones are removed. Addresses listed in
+// both are kept.
+func (c *Client) ReplacePool(ctx context.Context, zone, name string, oldIPs, newIPs []string, opts ...BulkOption) (PoolReport, error) {
+	return c.updatePool(ctx, zone, name, newIPs, oldIPs, false, opts)
+}
+
+// AddToPool adds the given addresses to the A/AAAA records of name, leaving
+// other addresses in place. Addresses already in the pool are reported as unchanged.
+func (c *Client) AddToPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error) {
+	return c.updatePool(ctx, zone, name, ips, nil, false, opts)
+}
+
+// RemoveFromPool removes the given addresses from the A/AAAA records of name.
+// Addresses that are not in the pool are ignored.
+func (c *Client) RemoveFromPool(ctx context.Context, zone, name string, ips []string, opts ...BulkOption) (PoolReport, error) {
+	return c.updatePool(ctx, zone, name, nil, ips, false, opts)
+}
+
+// updatePool adds the addresses in add and removes those in remove (or, if
+// exclusive is set, every address not in add) from the pool of name.
+func (c *Client) updatePool(ctx context.Context, zone, name string, add, remove []string, exclusive bool, opts []BulkOption) (PoolReport, error) {
+	o := newBulkOptions(opts)
+	report := PoolReport{DryRun: o.dryRun}
+
+	wanted, err := poolAddresses(add)
+	if err != nil {
+		return report, err
+	}
+	unwanted, err := poolAddresses(remove)
+	if err != nil {
+		return report, err
+	}
+
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return report, err
+	}
+
+	var obsolete []DNSRecord
+	present := make(map[string]bool)
+	filter := FilterByName(name)
+	for _, record := range records {
+		if !filter.match(record) || (record.Type != RecordTypeA && record.Type != RecordTypeAAAA) {
+			continue
+		}
+		ip := canonicalIP(record.Content)
+		_, isWanted := wanted[ip]
+		_, isUnwanted := unwanted[ip]
+		switch {
+		case isUnwanted && !isWanted, exclusive && !isWanted:
+			obsolete = append(obsolete, record)
+		default:
+			present[ip] = true
+			report.Unchanged = append(report.Unchanged, record)
+		}
+	}
+
+	var missing []CreateDNSRecordParams
+	for _, ip := range add {
+		ip = canonicalIP(ip)
+		if present[ip] {
+			continue
+		}
+		present[ip] = true
+		missing = append(missing, CreateDNSRecordParams{Name: name, Type: wanted[ip], Content: ip, TTL: o.ttl})
+	}
+
+	if o.dryRun {
+		for _, params := range missing {
+			report.Added = append(report.Added, DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL})
+		}
+		report.Removed = obsolete
+		return report, nil
+	}
+
+	for _, params := range missing {
+		created, err := c.AddRR(ctx, zone, params)
+		if err != nil {
+			return report, err
+		}
+		report.Added = append(report.Added, created)
+	}
+
+	for _, record := range obsolete {
+		if err := c.DeleteRR(ctx, zone, record); err != nil {
+			return report, err
+		}
+		report.Removed = append(report.Removed, record)
+	}
+
+	return report, nil
+}
+
+// poolAddresses validates the addresses and maps their canonical form to the
+// record type (A or AAAA) that holds them.
+func poolAddresses(ips []string) (map[string]RecordType, error) {
+	addresses := make(map[string]RecordType, len(ips))
+	for _, value := range ips {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid pool address %q", value)
+		}
+		if ip.To4() != nil {
+			addresses[ip.String()] = RecordTypeA
+		} else {
+			addresses[ip.String()] = RecordTypeAAAA
+		}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SetPool(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.SetPool(context.Background(), "example.com", "www", []string{"192.0.2.2", "192.0.2.3"}, WithTTL(60))
+	require.NoError(t, err)
+	require.Len(t, report.Added, 1)
+	assert.Equal(t, "192.0.2.3", report.Added[0].Content)
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, RecordTypeAAAA, report.Removed[0].Type)
+	assert.Len(t, report.Unchanged, 1)
+
+	// List, add the new address, then remove the old one
+	require.Len(t, *calls, 3)
+	assert.Equal(t, "zone/add_alias", (*calls)[1].Path)
+	assert.EqualValues(t, 60, (*calls)[1].Input["ttl"])
+	assert.Equal(t, "zone/remove_record", (*calls)[2].Path)
+	assert.Equal(t, "2001:db8::1", (*calls)[2].Input["content"])
+}
+
+func TestClient_ReplacePool(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.ReplacePool(context.Background(), "example.com", "www",
+		[]string{"192.0.2.2", "192.0.2.9"}, []string{"192.0.2.4"}, WithDryRun())
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Added, 1)
+	assert.Equal(t, "192.0.2.4", report.Added[0].Content)
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, "192.0.2.2", report.Removed[0].Content)
+	require.Len(t, report.Unchanged, 1, "other addresses stay in the pool")
+	assert.Equal(t, "2001:db8::1", report.Unchanged[0].Content)
+	assert.Len(t, *calls, 1)
+
+	// An address listed as old and new is kept
+	report, err = client.ReplacePool(context.Background(), "example.com", "www",
+		[]string{"192.0.2.2"}, []string{"192.0.2.2"})
+	require.NoError(t, err)
+	assert.Empty(t, report.Added)
+	assert.Empty(t, report.Removed)
+	assert.Len(t, report.Unchanged, 2)
+}
+
+func TestClient_AddToPool(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.AddToPool(context.Background(), "example.com", "www", []string{"2001:DB8::2", "192.0.2.2"})
+	require.NoError(t, err)
+	require.Len(t, report.Added, 1)
+	assert.Equal(t, RecordTypeAAAA, report.Added[0].Type)
+	assert.Empty(t, report.Removed)
+	assert.Len(t, report.Unchanged, 2)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/add_aaaa", (*calls)[1].Path)
+	assert.Equal(t, "2001:db8::2", (*calls)[1].Input["ipaddr"])
+}
+
+func TestClient_RemoveFromPool_DryRun(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	report, err := client.RemoveFromPool(context.Background(), "example.com", "www", []string{"192.0.2.2"}, WithDryRun())
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Removed, 1)
+	assert.Equal(t, "192.0.2.2", report.Removed[0].Content)
+	assert.Len(t, *calls, 1, "dry run should only list records")
+}
+
+func TestClient_SetPool_InvalidAddress(t *testing.T) {
+	client := NewClient("user", "pass")
+
+	_, err := client.SetPool(context.Background(), "example.com", "www", []string{"not-an-ip"})
+	assert.Error(t, err)
+}
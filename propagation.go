@@ -0,0 +1,185 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultWaitInterval is the initial delay between propagation checks.
+	DefaultWaitInterval = 2 * time.Second
+	// DefaultWaitMaxInterval is the maximum delay between propagation checks.
+	DefaultWaitMaxInterval = 30 * time.Second
+)
+
+// WaitOptions configures WaitForRecord.
+type WaitOptions struct {
+	// Nameservers to poll as "host" or "host:port". If empty, the authoritative
+	// nameservers of the zone are looked up via the system resolver.
+	Nameservers []string
+	// Interval is the initial delay between checks (DefaultWaitInterval if zero).
+	// It doubles after every unsuccessful check up to MaxInterval.
+	Interval time.Duration
+	// MaxInterval caps the delay between checks (DefaultWaitMaxInterval if zero).
+	MaxInterval time.Duration
+	// Resolvers are recursive resolvers, such as PublicResolvers, that must
+	// also serve the record. They are queried with recursion desired, so a
+	// negative answer they cached earlier can delay the wait.
+	Resolvers []string
+}
+
+// PublicResolvers lists widely used public recursive resolvers for WaitOptions.Resolvers.
+var PublicResolvers = []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}
+
+// WaitResult contains timing information about a propagation wait.
+type WaitResult struct {
+	// Attempts is the number of polling rounds performed.
+	Attempts int
+	// Elapsed is the time from the start of the wait until the record was
+	// visible on all nameservers (or until the wait was aborted).
+	Elapsed time.Duration
+	// SeenAt maps each nameserver to the time since the start of the wait at
+	// which it first served the expected record.
+	SeenAt map[string]time.Duration
+}
+
+// WaitForRecord polls the authoritative nameservers of the zone, and any
+// resolvers in opts, until all of them serve a record with the given name,
+// type and content (any content if expectedContent is empty), or until the
+// context is done. Checks are repeated with exponential backoff. Use it after
+// AddRR to gate ACME validation or traffic cutover on propagation.
+func (c *Client) WaitForRecord(ctx context.Context, zone, name string, rtype RecordType, expectedContent string, opts WaitOptions) (WaitResult, error) {
+	start := time.Now()
+	result := WaitResult{SeenAt: make(map[string]time.Duration)}
+
+	servers, err := waitNameservers(ctx, zone, opts.Nameservers)
+	if err != nil {
+		return result, err
+	}
+	recursive := make(map[string]bool, len(opts.Resolvers))
+	for _, resolver := range hostPorts(opts.Resolvers) {
+		if !recursive[resolver] && !slices.Contains(servers, resolver) {
+			recursive[resolver] = true
+			servers = append(servers, resolver)
+		}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaitMaxInterval
+	}
+
+	expected := DNSRecord{Name: name, Type: rtype, Content: expectedContent}
+	fqdn := dns.Fqdn(absoluteName(canonicalName(name), canonicalHost(zone)))
+	qtype := dns.StringToType[string(rtype.Canonical())]
+
+	for {
+		result.Attempts++
+		for _, server := range servers {
+			if _, ok := result.SeenAt[server]; ok {
+				continue
+			}
+			records, err := queryNameserver(ctx, server, fqdn, qtype, zone, recursive[server])
+			if err != nil {
+				continue
+			}
+			for _, record := range records {
+				if record.Type == expected.Type.Canonical() && (expectedContent == "" || record.Equal(expected)) {
+					result.SeenAt[server] = time.Since(start)
+					break
+				}
+			}
+		}
+
+		result.Elapsed = time.Since(start)
+		if len(result.SeenAt) == len(servers) {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// waitNameservers returns the nameserver addresses to poll.
+func waitNameservers(ctx context.Context, zone string, configured []string) ([]string, error) {
+	hosts := configured
+	if len(hosts) == 0 {
+		nss, err := net.DefaultResolver.LookupNS(ctx, canonicalHost(zone))
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			hosts = append(hosts, strings.TrimSuffix(ns.Host, "."))
+		}
+	}
+
+	return hostPorts(hosts), nil
+}
+
+// hostPorts adds the default DNS port to addresses without one.
+func hostPorts(hosts []string) []string {
+	servers := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, "53")
+		}
+		servers = append(servers, host)
+	}
+	return servers
+}
+
+// queryNameserver sends a query to the server, non-recursive unless recursive
+// is set, and returns the answer records that can be represented as DNSRecord.
+func queryNameserver(ctx context.Context, server, fqdn string, qtype uint16, zone string, recursive bool) ([]DNSRecord, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(fqdn, qtype)
+	m.RecursionDesired = recursive
+
+	client := new(dns.Client)
+	resp, _, err := client.ExchangeContext(ctx, m, server)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, rr := range resp.Answer {
+		if record, err := FromDNSRR(rr, zone); err == nil {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestNameserver starts a UDP nameserver on localhost answering A queries
+// for www.example.com with the given address once ready returns true.
+func startTestNameserver(t *testing.T, address string, ready func() bool) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Authoritative = true
+		q := req.Question[0]
+		if ready() && q.Name == "www.example.com." && q.Qtype == dns.TypeA {
+			rr, _ := dns.NewRR("www.example.com. 300 IN A " + address)
+			m.Answer = append(m.Answer, rr)
+		}
+		_ = w.WriteMsg(m)
+	})
+
+	server := &dns.Server{PacketConn: conn, Handler: handler}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+func TestWaitForRecord(t *testing.T) {
+	var queries atomic.Int32
+	addr := startTestNameserver(t, "192.0.2.1", func() bool {
+		return queries.Add(1) > 2
+	})
+
+	client := NewClient("user", "pass")
+	result, err := client.WaitForRecord(context.Background(), "example.com", "www", RecordTypeA, "192.0.2.1", WaitOptions{
+		Nameservers: []string{addr},
+		Interval:    10 * time.Millisecond,
+		MaxInterval: 20 * time.Millisecond,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Attempts)
+	assert.Contains(t, result.SeenAt, addr)
+	assert.LessOrEqual(t, result.SeenAt[addr], result.Elapsed)
+}
+
+func TestWaitForRecord_AnyContent(t *testing.T) {
+	addr := startTestNameserver(t, "192.0.2.7", func() bool { return true })
+
+	client := NewClient("user", "pass")
+	result, err := client.WaitForRecord(context.Background(), "example.com.", "WWW", RecordTypeA, "", WaitOptions{
+		Nameservers: []string{addr},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempts)
+}
+
+func TestWaitForRecord_ContextExpired(t *testing.T) {
+	addr := startTestNameserver(t, "192.0.2.1", func() bool { return true })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	client := NewClient("user", "pass")
+	result, err := client.WaitForRecord(ctx, "example.com", "www", RecordTypeA, "192.0.2.2", WaitOptions{
+		Nameservers: []string{addr},
+		Interval:    10 * time.Millisecond,
+	})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Greater(t, result.Attempts, 1)
+	assert.Empty(t, result.SeenAt)
+}
+
+func TestWaitForRecord_Resolvers(t *testing.T) {
+	authoritative := startTestNameserver(t, "192.0.2.1", func() bool { return true })
+
+	var recursionDesired atomic.Bool
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		recursionDesired.Store(req.RecursionDesired)
+		m := new(dns.Msg)
+		m.SetReply(req)
+		rr, _ := dns.NewRR("www.example.com. 300 IN A 192.0.2.1")
+		m.Answer = append(m.Answer, rr)
+		_ = w.WriteMsg(m)
+	})}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+	resolver := conn.LocalAddr().String()
+
+	client := NewClient("user", "pass")
+	result, err := client.WaitForRecord(context.Background(), "example.com", "www", RecordTypeA, "192.0.2.1", WaitOptions{
+		Nameservers: []string{authoritative},
+		Resolvers:   []string{resolver},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.SeenAt, authoritative)
+	assert.Contains(t, result.SeenAt, resolver)
+	assert.True(t, recursionDesired.Load())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecordData is the typed data of a DNS record. It can be set as
+// CreateDNSRecordParams.Data instead of composing Content by hand.
+type RecordData interface {
+	// RecordType returns the type of record the data belongs to.
+	RecordType() RecordType
+	// Content returns the data in the Content format of DNSRecord.
+	Content() string
+}
+
+// MXData is the data of an MX record.
+type MXData struct {
+	Priority int
+	Host     string
+}
+
+// RecordType implements RecordData.
+func (d MXData) RecordType() RecordType { return RecordTypeMX }
+
+// Content implements RecordData.
+func (d MXData) Content() string { return fmt.Sprintf("%d %s", d.Priority, d.Host) }
+
+// SRVData is the data of an SRV record.
+type SRVData struct {
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// RecordType implements RecordData.
+func (d SRVData) RecordType() RecordType { return RecordTypeSRV }
+
+// Content implements RecordData.
+func (d SRVData) Content() string {
+	return fmt.Sprintf("%d %d %d %s", d.Priority, d.Weight, d.Port, d.Target)
+}
+
+// CAAData is the data of a CAA record.
+type CAAData struct {
+	Flags int
+	Tag   string
+	Value string
+}
+
+// RecordType implements RecordData.
+func (d CAAData) RecordType() RecordType { return RecordTypeCAA }
+
+// Content implements RecordData.
+func (d CAAData) Content() string { return CAAContent(d.Flags, d.Tag, d.Value) }
+
+// TXTData is the data of a TXT record.
+type TXTData struct {
+	Text string
+}
+
+// RecordType implements RecordData.
+func (d TXTData) RecordType() RecordType { return RecordTypeTXT }
+
+// Content implements RecordData.
+func (d TXTData) Content() string { return d.Text }
+
+// ParseRecordData returns the typed data of an MX, SRV, CAA or TXT record.
+func ParseRecordData(rr DNSRecord) (RecordData, error) {
+	switch rr.Type.Canonical() {
+	case RecordTypeMX:
+		if priority, host, ok := splitMXContent(rr.Content); ok {
+			return MXData{Priority: priority, Host: host}, nil
+		}
+		if fields := strings.Fields(rr.Content); len(fields) == 1 {
+			return MXData{Priority: rr.Priority, Host: fields[0]}, nil
+		}
+	case RecordTypeSRV:
+		if priority, weight, port, target, ok := splitSRVContent(rr.Content); ok {
+			return SRVData{Priority: priority, Weight: weight, Port: port, Target: target}, nil
+		}
+	case RecordTypeCAA:
+		if flags, tag, value, ok := splitCAAContent(rr.Content); ok {
+			return CAAData{Flags: flags, Tag: tag, Value: value}, nil
+		}
+	case RecordTypeTXT:
+		return TXTData{Text: canonicalTXT(rr.Content)}, nil
+	default:
+		return nil, &UnsupportedRecordTypeError{RecordType: string(rr.Type)}
+	}
+	return nil, fmt.Errorf("invalid %s content %q", rr.Type.Canonical(), rr.Content)
+}
+
+// applyRecordData sets the type and content of params from params.Data.
+func applyRecordData(params CreateDNSRecordParams) (CreateDNSRecordParams, error) {
+	if params.Data == nil {
+		return params, nil
+	}
+	if params.Content != "" {
+		return params, fmt.Errorf("both Content and Data are set for %s record %q", params.Type, params.Name)
+	}
+	dataType := params.Data.RecordType()
+	if params.Type == "" {
+		params.Type = dataType
+	}
+	if params.Type.Canonical() != dataType {
+		return params, fmt.Errorf("record type %s does not match %s data", params.Type, dataType)
+	}
+	params.Content = params.Data.Content()
+	return params, nil
+}
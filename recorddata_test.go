@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordData_Content(t *testing.T) {
+	assert.Equal(t, "10 mail.example.com", MXData{Priority: 10, Host: "mail.example.com"}.Content())
+	assert.Equal(t, "10 5 5060 sip.example.com", SRVData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}.Content())
+	assert.Equal(t, `0 issue "letsencrypt.org"`, CAAData{Tag: "issue", Value: "letsencrypt.org"}.Content())
+	assert.Equal(t, "v=spf1 -all", TXTData{Text: "v=spf1 -all"}.Content())
+}
+
+func TestParseRecordData(t *testing.T) {
+	tests := []struct {
+		rr   DNSRecord
+		want RecordData
+	}{
+		{DNSRecord{Type: RecordTypeMX, Content: "10 mail.example.com"}, MXData{Priority: 10, Host: "mail.example.com"}},
+		{DNSRecord{Type: RecordTypeMX, Content: "mail.example.com", Priority: 20}, MXData{Priority: 20, Host: "mail.example.com"}},
+		{DNSRecord{Type: "srv", Content: "10 5 5060 sip.example.com"}, SRVData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}},
+		{DNSRecord{Type: RecordTypeCAA, Content: `128 ISSUE "letsencrypt.org"`}, CAAData{Flags: 128, Tag: "issue", Value: "letsencrypt.org"}},
+		{DNSRecord{Type: RecordTypeTXT, Content: `"part1" "part2"`}, TXTData{Text: "part1part2"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRecordData(tt.rr)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := ParseRecordData(DNSRecord{Type: RecordTypeSRV, Content: "sip.example.com"})
+	assert.ErrorContains(t, err, "invalid SRV content")
+	_, err = ParseRecordData(DNSRecord{Type: RecordTypeA, Content: "192.0.2.1"})
+	assert.ErrorIs(t, err, ErrUnsupportedRecordType)
+}
+
+func TestClient_AddRR_RecordData(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	record, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Data: MXData{Priority: 10, Host: "mail.example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com", Priority: 10}, record)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "_sip._tcp", Type: RecordTypeSRV, Data: SRVData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Data: CAAData{Tag: "issue", Value: "letsencrypt.org"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Data: TXTData{Text: "v=spf1 -all"},
+	})
+	require.NoError(t, err)
+
+	// Legacy SRV parameters: Content is the target
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "_sip._udp", Type: RecordTypeSRV, Content: "sip.example.com", Priority: 20, Port: 5060,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 5)
+	assert.Equal(t, "zone/add_mx", (*calls)[0].Path)
+	assert.Equal(t, "mail.example.com", (*calls)[0].Input["mail_server"])
+	assert.Equal(t, float64(10), (*calls)[0].Input["priority"])
+
+	assert.Equal(t, "zone/add_srv", (*calls)[1].Path)
+	assert.Equal(t, "10", (*calls)[1].Input["priority"])
+	assert.Equal(t, "5", (*calls)[1].Input["weight"])
+	assert.Equal(t, "5060", (*calls)[1].Input["port"])
+	assert.Equal(t, "sip.example.com", (*calls)[1].Input["target"])
+
+	assert.Equal(t, "zone/add_caa", (*calls)[2].Path)
+	assert.Equal(t, "issue", (*calls)[2].Input["tag"])
+
+	assert.Equal(t, "zone/add_txt", (*calls)[3].Path)
+	assert.Equal(t, "v=spf1 -all", (*calls)[3].Input["text"])
+
+	assert.Equal(t, "20", (*calls)[4].Input["priority"])
+	assert.Equal(t, "5060", (*calls)[4].Input["port"])
+	assert.Equal(t, "sip.example.com", (*calls)[4].Input["target"])
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Type: RecordTypeA, Data: TXTData{Text: "x"},
+	})
+	assert.ErrorContains(t, err, "does not match")
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "@", Content: "x", Data: TXTData{Text: "x"},
+	})
+	assert.ErrorContains(t, err, "both Content and Data")
+}
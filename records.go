@@ -0,0 +1,477 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// RecordsService groups the DNS record methods of the API.
+type RecordsService struct {
+	client *Client
+}
+
+// Add creates a new DNS record for the specified zone.
+func (s *RecordsService) Add(ctx context.Context, zone string, params CreateDNSRecordParams) (DNSRecord, error) {
+	path, apiReq, params, err := s.addRequest(zone, params)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	// Execute API request
+	resp, err := Call[AddNSResponse](ctx, s.client, path, apiReq)
+	s.client.InvalidateRecordsCache(zone)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	record := addedRecord(params)
+
+	// A successful envelope may still carry an error for the domain
+	if len(resp.Answer.Domains) > 0 {
+		domain := resp.Answer.Domains[0]
+		if domain.Result != "" && domain.Result != "success" {
+			return DNSRecord{}, newAPIError(domain.ErrorCode, domain.ErrorText)
+		}
+		record.ID = domain.DNSID
+	}
+
+	return record, nil
+}
+
+// addedRecord returns the record created by the add request for params.
+func addedRecord(params CreateDNSRecordParams) DNSRecord {
+	record := DNSRecord{
+		Name:    params.Name,
+		Type:    params.Type,
+		Content: params.Content,
+		TTL:     effectiveTTL(params.TTL, params.TTLDuration),
+	}
+	if params.Type == RecordTypeMX {
+		_, record.Priority = mxParams(params)
+	}
+	return record
+}
+
+// Delete deletes a DNS record from the specified zone.
+func (s *RecordsService) Delete(ctx context.Context, zone string, rr DNSRecord) error {
+	path, apiReq, err := s.removeRequest(zone, rr)
+	if err != nil {
+		return err
+	}
+
+	// Execute API request
+	_, err = s.client.apiRequest(ctx, path, apiReq)
+	s.client.InvalidateRecordsCache(zone)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// addRequest validates the parameters of a record to add and returns the API
+// path and request creating it, along with the normalized parameters.
+func (s *RecordsService) addRequest(zone string, params CreateDNSRecordParams) (string, APIRequest, CreateDNSRecordParams, error) {
+	params, err := applyRecordData(params)
+	if err != nil {
+		return "", nil, params, err
+	}
+
+	// Validate and canonicalize the record type before building the request
+	recordType, err := checkRecordType(params.Type)
+	if err != nil {
+		return "", nil, params, err
+	}
+	params.Type = recordType
+	if s.client.validate {
+		if err := ValidateRecord(params); err != nil {
+			return "", nil, params, err
+		}
+	}
+
+	// Get the appropriate API path for this record type
+	path, err := getAddRecordPath(params.Type)
+	if err != nil {
+		return "", nil, params, err
+	}
+
+	// Create the appropriate request structure
+	reqParams := params
+	reqParams.Name = s.client.asciiName(params.Name)
+	if isTXTType(params.Type) && !s.client.noTXTChunking {
+		reqParams.Content = chunkTXT(params.Content)
+	}
+	apiReq, err := createAddRecordRequest(s.client.asciiName(zone), reqParams)
+	if err != nil {
+		return "", nil, params, err
+	}
+	if params.Type == RecordTypeLOC && params.Content == "" {
+		params.Content = LOCContent(params.Latitude, params.Longitude, params.Altitude)
+	}
+
+	return path, apiReq, params, nil
+}
+
+// removeRequest returns the API path and request deleting the record.
+func (s *RecordsService) removeRequest(zone string, rr DNSRecord) (string, APIRequest, error) {
+	// Validate and canonicalize the record type before building the request
+	recordType, err := checkRecordType(rr.Type)
+	if err != nil {
+		return "", nil, err
+	}
+	rr.Type = recordType
+	if isTXTType(rr.Type) && !s.client.noTXTChunking {
+		rr.Content = chunkTXT(rr.Content)
+	}
+
+	// Get the appropriate API path for this record type
+	path, err := getRemoveRecordPath(rr.Type)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Create the appropriate request structure
+	rr.Name = s.client.asciiName(rr.Name)
+	apiReq, err := createRemoveRecordRequest(s.client.asciiName(zone), rr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return path, apiReq, nil
+}
+
+// DeleteByID deletes the DNS record with the dns_id from the specified zone.
+// Unlike Delete, it removes exactly that record even when other records share
+// its name, type and content.
+func (s *RecordsService) DeleteByID(ctx context.Context, zone, dnsID string) error {
+	apiReq := &RemoveRecordByNumberRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []RemoveRecordDomain{
+			{DName: s.client.asciiName(zone)},
+		},
+		RecordNumber: dnsID,
+	}
+
+	_, err := s.client.apiRequest(ctx, "zone/remove_record", apiReq)
+	s.client.InvalidateRecordsCache(zone)
+	return err
+}
+
+// GetByName returns a DNS record by name in the specified zone.
+func (s *RecordsService) GetByName(ctx context.Context, zone, name string) (DNSRecord, error) {
+	// Get all zone records
+	params := ListDNSRecordsParams{
+		ZoneName: zone,
+	}
+
+	records, err := s.List(ctx, params)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	// Search for record by name
+	name = canonicalName(name)
+	for _, record := range records {
+		if canonicalName(record.Name) == name {
+			return record, nil
+		}
+	}
+
+	return DNSRecord{}, &RecordNotFoundError{RecordName: name}
+}
+
+// GetByID returns the DNS record with the dns_id in the specified zone. The
+// dns_id is returned when a record is created and is the stable key for later
+// updates. IDs are looked up with zone/get_ns unless WithRecordIDs already
+// provides them.
+func (s *RecordsService) GetByID(ctx context.Context, zone, dnsID string) (DNSRecord, error) {
+	all, err := s.fetch(ctx, zone)
+	if err != nil {
+		return DNSRecord{}, err
+	}
+
+	records := append([]DNSRecord(nil), all...)
+	if !s.client.recordIDs {
+		if err := s.fetchRecordIDs(ctx, s.client.asciiName(zone), records); err != nil {
+			return DNSRecord{}, err
+		}
+	}
+
+	for _, record := range records {
+		if record.ID == dnsID {
+			return record, nil
+		}
+	}
+
+	return DNSRecord{}, &RecordNotFoundError{RecordID: dnsID}
+}
+
+// List returns a list of DNS records for the specified zone.
+func (s *RecordsService) List(ctx context.Context, params ListDNSRecordsParams) ([]DNSRecord, error) {
+	zoneName := params.ZoneName
+	if zoneName == "" {
+		zoneName = params.ZoneID // Fallback to ZoneID if ZoneName is not set
+	}
+
+	all, err := s.fetch(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, record := range all {
+		// Apply filters if specified
+		if params.Name != "" && record.Name != params.Name {
+			continue
+		}
+		if params.Type != "" && record.Type != params.Type.Canonical() {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return dedupeRecords(records, params.IncludeDuplicates), nil
+}
+
+// fetch returns all records of the zone, from the records cache if enabled.
+func (s *RecordsService) fetch(ctx context.Context, zoneName string) ([]DNSRecord, error) {
+	cache := s.client.recordsCache
+	revalidate := cache != nil && s.client.cacheRevalidation
+	if cache != nil {
+		if records, ok := cache.get(zoneName, time.Now()); ok {
+			return records, nil
+		}
+	}
+
+	// Take the serial before downloading, so a change made meanwhile is not missed
+	var (
+		serial    uint32
+		hasSerial bool
+	)
+	if revalidate {
+		current, err := s.client.ZoneSerial(ctx, zoneName)
+		if err == nil {
+			if records, ok := cache.renew(zoneName, current, time.Now()); ok {
+				return records, nil
+			}
+			serial, hasSerial = current, true
+		}
+	}
+
+	// Prepare API request
+	apiZone := s.client.asciiName(zoneName)
+	apiReq := ZoneGetResourceRecordsRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{
+				DName: apiZone,
+			},
+		},
+	}
+
+	resp, err := Call[ZoneGetResourceRecordsResponse](ctx, s.client, "zone/get_resource_records", &apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []DNSRecord
+	for _, domain := range resp.Answer.Domains {
+		if domain.DName == apiZone {
+			// Records have no individual TTL in this response; the zone TTL from the SOA applies
+			zoneTTL := 0
+			if domain.SOA != nil {
+				zoneTTL, _ = domain.SOA.GetTTL()
+			}
+			for _, rr := range domain.RRList {
+				priority, _ := strconv.Atoi(rr.GetPrio())
+				recordType := RecordType(rr.Rectype).Canonical()
+				content := rr.Content
+				if isTXTType(recordType) && !s.client.noTXTChunking {
+					content = joinTXTChunks(content)
+				}
+				records = append(records, DNSRecord{
+					Name:         s.client.unicodeName(rr.Subname),
+					Type:         recordType,
+					Content:      content,
+					Priority:     priority,
+					TTL:          zoneTTL,
+					TTLInherited: zoneTTL > 0,
+				})
+			}
+		}
+	}
+
+	if s.client.recordIDs {
+		if err := s.fetchRecordIDs(ctx, apiZone, records); err != nil {
+			return nil, err
+		}
+	}
+
+	if cache != nil {
+		cache.putSerial(zoneName, records, serial, hasSerial, time.Now())
+	}
+	return records, nil
+}
+
+// WithRecordIDs makes ListRecords also call zone/get_ns, which reports the
+// dns_id and TTL of each record, to fill DNSRecord.ID and the per-record TTL.
+// It costs one extra API call per zone listing.
+func WithRecordIDs() ClientOption {
+	return func(c *Client) {
+		c.recordIDs = true
+	}
+}
+
+// fetchRecordIDs sets the ID and TTL of the records from the zone/get_ns response.
+func (s *RecordsService) fetchRecordIDs(ctx context.Context, apiZone string, records []DNSRecord) error {
+	apiReq := ZoneGetNSRequest{
+		BaseRequest: BaseRequest{},
+		Domains:     []string{apiZone},
+	}
+
+	resp, err := Call[ZoneListResponse](ctx, s.client, "zone/get_ns", &apiReq)
+	if err != nil {
+		return err
+	}
+
+	for _, domain := range resp.Answer.Domains {
+		if domain.DName != apiZone {
+			continue
+		}
+		for _, ns := range domain.NSList {
+			listed := DNSRecord{Name: s.client.unicodeName(ns.Subdomain), Type: RecordType(ns.Type), Content: ns.Content}
+			for i := range records {
+				if records[i].ID != "" || !records[i].Equal(listed) {
+					continue
+				}
+				records[i].ID = ns.DNSID
+				if ns.TTL > 0 {
+					records[i].TTL = ns.TTL
+					records[i].TTLInherited = false
+				}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListByZoneID returns a list of DNS records by zone identifier.
+func (s *RecordsService) ListByZoneID(ctx context.Context, id string, params ListDNSRecordsParams) ([]DNSRecord, error) {
+	// In reg.ru API, zone identifier usually matches zone name
+	// Get zone by ID and use its name
+	zones, err := s.client.Zones.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var zoneName string
+	for _, zone := range zones {
+		if zone.ID == id {
+			zoneName = zone.Name
+			break
+		}
+	}
+
+	if zoneName == "" {
+		return nil, &ZoneNotFoundError{ZoneID: id}
+	}
+
+	params.ZoneName = zoneName
+	return s.List(ctx, params)
+}
+
+// UpdateResult describes the outcome of a record update.
+type UpdateResult struct {
+	// Before is the record state removed from the zone.
+	Before DNSRecord
+	// After is the record state created in the zone.
+	After DNSRecord
+	// Calls lists the API methods invoked, in order, including a failed last call.
+	Calls []string
+}
+
+// Update updates an existing DNS record in the specified zone. The record is
+// removed and created again with the same name, type and content, so only
+// attributes such as the TTL change; use UpdateFrom to change the content.
+func (s *RecordsService) Update(ctx context.Context, zone string, rr DNSRecord) (DNSRecord, error) {
+	result, err := s.UpdateWithResult(ctx, zone, rr)
+	return result.After, err
+}
+
+// UpdateWithResult updates an existing DNS record like Update and also reports
+// the previous record state and the API calls made. On failure the result
+// describes the calls performed so far, so a partially applied update can be undone.
+func (s *RecordsService) UpdateWithResult(ctx context.Context, zone string, rr DNSRecord) (UpdateResult, error) {
+	return s.UpdateFromWithResult(ctx, zone, rr, rr)
+}
+
+// UpdateFrom replaces the existing record oldRR with newRR in the specified
+// zone: oldRR identifies the record to remove and newRR is created in its place.
+func (s *RecordsService) UpdateFrom(ctx context.Context, zone string, oldRR, newRR DNSRecord) (DNSRecord, error) {
+	result, err := s.UpdateFromWithResult(ctx, zone, oldRR, newRR)
+	return result.After, err
+}
+
+// UpdateFromWithResult replaces a record like UpdateFrom and reports the
+// outcome like UpdateWithResult.
+func (s *RecordsService) UpdateFromWithResult(ctx context.Context, zone string, oldRR, newRR DNSRecord) (UpdateResult, error) {
+	var result UpdateResult
+
+	// In reg.ru API, record update is usually performed through delete and create
+	oldType, err := checkRecordType(oldRR.Type)
+	if err != nil {
+		return result, err
+	}
+	newType, err := checkRecordType(newRR.Type)
+	if err != nil {
+		return result, err
+	}
+	before := oldRR
+	before.Type = oldType
+	createParams := paramsFromRecord(newRR)
+	createParams.Type = newType
+	if s.client.validate {
+		if err := ValidateRecord(createParams); err != nil {
+			return result, err
+		}
+	}
+
+	// First, delete the old record
+	removePath, _ := getRemoveRecordPath(oldType)
+	result.Calls = append(result.Calls, removePath)
+	if err := s.Delete(ctx, zone, before); err != nil {
+		return result, err
+	}
+	result.Before = before
+
+	// Create a new record with updated data
+	addPath, _ := getAddRecordPath(newType)
+	result.Calls = append(result.Calls, addPath)
+	after, err := s.Add(ctx, zone, createParams)
+	if err != nil {
+		return result, err
+	}
+	result.After = after
+
+	return result, nil
+}
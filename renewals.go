@@ -0,0 +1,163 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// RenewalItem is a domain included in a renewal estimate.
+type RenewalItem struct {
+	Domain         string    `json:"domain"`
+	TLD            string    `json:"tld,omitempty"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	// Price is the yearly renewal price, zero if the TLD has no price.
+	Price float64 `json:"price"`
+	// Folders lists the estimate folders containing the domain.
+	Folders []string `json:"folders,omitempty"`
+}
+
+// RenewalEstimate is a cost forecast for renewing the domains that expire
+// within a period.
+type RenewalEstimate struct {
+	Until    time.Time     `json:"until"`
+	Currency string        `json:"currency,omitempty"`
+	Total    float64       `json:"total"`
+	Domains  []RenewalItem `json:"domains,omitempty"`
+	// ByTLD sums the renewal prices by priced TLD.
+	ByTLD map[string]float64 `json:"by_tld"`
+	// ByFolder sums the renewal prices by folder. Domains outside the
+	// requested folders are counted under "", and a domain in several folders
+	// counts toward each of them.
+	ByFolder map[string]float64 `json:"by_folder,omitempty"`
+	// Unpriced lists the domains whose TLD has no renewal price; they are not
+	// included in the totals.
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+// EstimateOption represents an option for configuring EstimateRenewals.
+type EstimateOption func(*estimateOptions)
+
+// estimateOptions holds EstimateRenewals settings.
+type estimateOptions struct {
+	folders []string
+	now     func() time.Time
+}
+
+// WithEstimateFolders breaks the estimate down by the given account folders.
+// The API has no folder listing, so the folder names must be provided.
+func WithEstimateFolders(folders ...string) EstimateOption {
+	return func(o *estimateOptions) {
+		o.folders = append(o.folders, folders...)
+	}
+}
+
+// ListExpiringDomains returns the domains that expire within the period, including
+// ones that have already expired, ordered by expiration date.
+func (c *Client) ListExpiringDomains(ctx context.Context, within time.Duration) ([]Domain, error) {
+	return c.listExpiringDomains(ctx, time.Now().Add(within))
+}
+
+func (c *Client) listExpiringDomains(ctx context.Context, until time.Time) ([]Domain, error) {
+	domains, err := c.Domains.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiring []Domain
+	for _, domain := range domains {
+		if !domain.ExpirationDate.IsZero() && !domain.ExpirationDate.After(until) {
+			expiring = append(expiring, domain)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpirationDate.Before(expiring[j].ExpirationDate)
+	})
+	return expiring, nil
+}
+
+// EstimateRenewals forecasts the cost of renewing, for one year, every domain
+// that expires within the period, using the current renewal prices. Totals are
+// broken down by TLD and, with WithEstimateFolders, by folder.
+func (c *Client) EstimateRenewals(ctx context.Context, within time.Duration, opts ...EstimateOption) (RenewalEstimate, error) {
+	o := estimateOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	estimate := RenewalEstimate{
+		Until: o.now().Add(within),
+		ByTLD: make(map[string]float64),
+	}
+
+	domains, err := c.listExpiringDomains(ctx, estimate.Until)
+	if err != nil {
+		return estimate, err
+	}
+	prices, err := c.Domains.GetPrices(ctx)
+	if err != nil {
+		return estimate, err
+	}
+	estimate.Currency = prices.Currency
+
+	folders := make(map[string][]string)
+	for _, folder := range o.folders {
+		members, err := c.Domains.ListByFolder(ctx, folder)
+		if err != nil {
+			return estimate, err
+		}
+		for _, member := range members {
+			name := canonicalHost(member.Name)
+			folders[name] = append(folders[name], folder)
+		}
+	}
+	if len(o.folders) > 0 {
+		estimate.ByFolder = make(map[string]float64)
+	}
+
+	for _, domain := range domains {
+		item := RenewalItem{
+			Domain:         domain.Name,
+			ExpirationDate: domain.ExpirationDate,
+			Folders:        folders[canonicalHost(domain.Name)],
+		}
+		price, ok := prices.RenewalPrice(domain.Name)
+		if !ok {
+			estimate.Unpriced = append(estimate.Unpriced, domain.Name)
+			estimate.Domains = append(estimate.Domains, item)
+			continue
+		}
+		item.TLD = price.TLD
+		item.Price = price.Renew
+		estimate.Domains = append(estimate.Domains, item)
+
+		estimate.Total += price.Renew
+		estimate.ByTLD[price.TLD] += price.Renew
+		if estimate.ByFolder != nil {
+			if len(item.Folders) == 0 {
+				estimate.ByFolder[""] += price.Renew
+			}
+			for _, folder := range item.Folders {
+				estimate.ByFolder[folder] += price.Renew
+			}
+		}
+	}
+
+	return estimate, nil
+}
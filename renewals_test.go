@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceList_RenewalPrice(t *testing.T) {
+	prices := PriceList{Prices: map[string]DomainPrice{
+		"ru":     {TLD: "ru", Renew: 790},
+		"msk.ru": {TLD: "msk.ru", Renew: 390},
+	}}
+
+	price, ok := prices.RenewalPrice("Example.RU.")
+	require.True(t, ok)
+	assert.Equal(t, 790.0, price.Renew)
+
+	price, ok = prices.RenewalPrice("shop.msk.ru")
+	require.True(t, ok)
+	assert.Equal(t, "msk.ru", price.TLD)
+
+	_, ok = prices.RenewalPrice("example.com")
+	assert.False(t, ok)
+	_, ok = prices.RenewalPrice("ru")
+	assert.False(t, ok)
+}
+
+func TestClient_EstimateRenewals(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	service := func(name, expiration string) map[string]interface{} {
+		return map[string]interface{}{"dname": name, "servtype": "domain", "state": "A", "expiration_date": expiration}
+	}
+
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"service/get_list": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"services": []map[string]interface{}{
+					service("example.ru", "2025-01-20"),
+					service("shop.msk.ru", "2024-12-25"),
+					service("example.com", "2025-01-10"),
+					service("example.io", "2025-01-15"),
+					service("later.ru", "2025-06-01"),
+				},
+			},
+		},
+		"domain/get_prices": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"currency": "RUR",
+				"prices": map[string]interface{}{
+					"ru":     map[string]interface{}{"reg_price": "290.00", "renew_price": "790.00"},
+					"msk.ru": map[string]interface{}{"reg_price": 190, "renew_price": 390},
+					"com":    map[string]interface{}{"reg_price": "990.00", "renew_price": "1490.00"},
+				},
+			},
+		},
+		"folder/get_services": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"services": []map[string]interface{}{
+					service("example.ru", ""),
+					service("example.com", ""),
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	estimate, err := client.EstimateRenewals(context.Background(), 30*24*time.Hour,
+		WithEstimateFolders("clients"),
+		func(o *estimateOptions) { o.now = func() time.Time { return now } })
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(30*24*time.Hour), estimate.Until)
+	assert.Equal(t, "RUR", estimate.Currency)
+	assert.Equal(t, 2670.0, estimate.Total)
+	assert.Equal(t, map[string]float64{"ru": 790, "msk.ru": 390, "com": 1490}, estimate.ByTLD)
+	assert.Equal(t, map[string]float64{"clients": 2280, "": 390}, estimate.ByFolder)
+	assert.Equal(t, []string{"example.io"}, estimate.Unpriced)
+
+	require.Len(t, estimate.Domains, 4)
+	assert.Equal(t, "shop.msk.ru", estimate.Domains[0].Domain)
+	assert.Equal(t, "example.com", estimate.Domains[1].Domain)
+	assert.Equal(t, []string{"clients"}, estimate.Domains[1].Folders)
+	assert.Equal(t, 1490.0, estimate.Domains[1].Price)
+
+	require.Len(t, *calls, 3)
+	assert.Equal(t, float64(1), (*calls)[1].Input["show_renew_data"])
+	assert.Equal(t, "clients", (*calls)[2].Input["folder_name"])
+}
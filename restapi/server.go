@@ -0,0 +1,327 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restapi provides an http.Handler exposing a small authenticated REST
+// API for reg.ru DNS backed by a client, so internal tools and non-Go services
+// can manage records through one audited entry point.
+//
+// Endpoints:
+//
+//	GET    /zones
+//	GET    /zones/{zone}/records[?name=...&type=...]
+//	POST   /zones/{zone}/records
+//	DELETE /zones/{zone}/records
+//
+// POST and DELETE take a record as a JSON body, such as
+// {"name": "www", "type": "A", "content": "192.0.2.1", "ttl": 300}.
+// Requests must carry one of the configured tokens as "Authorization: Bearer <token>".
+package restapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// maxBodySize limits the size of JSON request bodies.
+const maxBodySize = 64 << 10
+
+// Client is the subset of regru.Client methods used by the server.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// AuditEntry describes a request that changed or tried to change records.
+type AuditEntry struct {
+	Time time.Time
+	// Caller is the name of the token the request was authenticated with.
+	Caller string
+	// Method is the HTTP method, POST or DELETE.
+	Method string
+	Zone   string
+	Record regru.DNSRecord
+	// Status is the HTTP status code of the response.
+	Status int
+	// Err is the error returned by the client, if any.
+	Err error
+}
+
+// Server is an http.Handler implementing the REST API.
+type Server struct {
+	client Client
+	tokens map[string]string
+	audit  func(ctx context.Context, entry AuditEntry)
+	mux    *http.ServeMux
+}
+
+// Option represents an option for configuring the server.
+type Option func(*Server)
+
+// WithToken accepts the bearer token and reports requests made with it as
+// caller in audit entries and CallerFromContext.
+func WithToken(caller, token string) Option {
+	return func(s *Server) {
+		s.tokens[token] = caller
+	}
+}
+
+// WithAuditHook calls fn after every POST or DELETE request, whether it
+// succeeded or not. The hook runs synchronously and should not block.
+func WithAuditHook(fn func(ctx context.Context, entry AuditEntry)) Option {
+	return func(s *Server) {
+		s.audit = fn
+	}
+}
+
+// NewServer creates a new REST API server backed by the client.
+// Without tokens all requests are rejected.
+func NewServer(client Client, opts ...Option) *Server {
+	s := &Server{
+		client: client,
+		tokens: make(map[string]string),
+		mux:    http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /zones", s.handleListZones)
+	s.mux.HandleFunc("GET /zones/{zone}/records", s.handleListRecords)
+	s.mux.HandleFunc("POST /zones/{zone}/records", s.handleAddRecord)
+	s.mux.HandleFunc("DELETE /zones/{zone}/records", s.handleDeleteRecord)
+
+	return s
+}
+
+// callerKey is the context key of the authenticated caller.
+type callerKey struct{}
+
+// CallerFromContext returns the name of the token a request was authenticated
+// with. The request context is passed to the client, so client hooks such as
+// regru.WithAuditHook can attribute API calls to callers.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	caller, ok := s.authenticate(r.Header.Get("Authorization"))
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="regru"`)
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	s.mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, caller)))
+}
+
+// authenticate returns the caller of the bearer token in the Authorization header.
+func (s *Server) authenticate(header string) (string, bool) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	for known, caller := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+			return caller, true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) handleListZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := s.client.ListZones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	if zones == nil {
+		zones = []regru.Zone{}
+	}
+	writeJSON(w, http.StatusOK, zones)
+}
+
+func (s *Server) handleListRecords(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.findZone(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	records, err := s.client.ListRecords(r.Context(), regru.ListDNSRecordsParams{
+		ZoneName: zone,
+		Name:     query.Get("name"),
+		Type:     regru.RecordType(query.Get("type")),
+	})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	if records == nil {
+		records = []regru.DNSRecord{}
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+func (s *Server) handleAddRecord(w http.ResponseWriter, r *http.Request) {
+	zone, rr, ok := s.parseWrite(w, r)
+	if !ok {
+		return
+	}
+
+	created, err := s.client.AddRR(r.Context(), zone, regru.CreateDNSRecordParams{
+		Name:     rr.Name,
+		Type:     rr.Type,
+		Content:  rr.Content,
+		TTL:      rr.TTL,
+		Priority: rr.Priority,
+	})
+	if err != nil {
+		s.report(r, zone, rr, writeClientError(w, err), err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+	s.report(r, zone, rr, http.StatusCreated, nil)
+}
+
+func (s *Server) handleDeleteRecord(w http.ResponseWriter, r *http.Request) {
+	zone, rr, ok := s.parseWrite(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.client.DeleteRR(r.Context(), zone, rr); err != nil {
+		s.report(r, zone, rr, writeClientError(w, err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	s.report(r, zone, rr, http.StatusNoContent, nil)
+}
+
+// parseWrite resolves the zone and decodes the record of a POST or DELETE request.
+func (s *Server) parseWrite(w http.ResponseWriter, r *http.Request) (string, regru.DNSRecord, bool) {
+	var rr regru.DNSRecord
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&rr); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return "", rr, false
+		}
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return "", rr, false
+	}
+	if rr.Name == "" || rr.Type == "" || rr.Content == "" {
+		writeError(w, http.StatusBadRequest, "name, type and content are required")
+		return "", rr, false
+	}
+
+	zone, ok := s.findZone(w, r)
+	if !ok {
+		return "", rr, false
+	}
+	return zone, rr, true
+}
+
+// report passes a write request to the audit hook.
+func (s *Server) report(r *http.Request, zone string, rr regru.DNSRecord, status int, err error) {
+	if s.audit == nil {
+		return
+	}
+	s.audit(r.Context(), AuditEntry{
+		Time:   time.Now(),
+		Caller: CallerFromContext(r.Context()),
+		Method: r.Method,
+		Zone:   zone,
+		Record: rr,
+		Status: status,
+		Err:    err,
+	})
+}
+
+// findZone resolves the zone path value to a zone in the account.
+func (s *Server) findZone(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := canonicalZone(r.PathValue("zone"))
+
+	zones, err := s.client.ListZones(r.Context())
+	if err != nil {
+		writeClientError(w, err)
+		return "", false
+	}
+
+	for _, z := range zones {
+		if canonicalZone(z.Name) == name {
+			return z.Name, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "zone not found: "+r.PathValue("zone"))
+	return "", false
+}
+
+// canonicalZone returns the zone name in lower case without a trailing dot.
+func canonicalZone(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// errorResponse is the body of error responses.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+// writeClientError maps client errors to HTTP status codes and returns the status written.
+func writeClientError(w http.ResponseWriter, err error) int {
+	var (
+		apiErr  *regru.APIError
+		httpErr *regru.HTTPError
+	)
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, regru.ErrZoneNotFound), errors.Is(err, regru.ErrRecordNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, regru.ErrWriteDenied):
+		status = http.StatusForbidden
+	case errors.Is(err, regru.ErrIPRestricted), errors.As(err, &httpErr):
+		status = http.StatusBadGateway
+	case errors.Is(err, regru.ErrUnsupportedRecordType), errors.As(err, &apiErr):
+		status = http.StatusUnprocessableEntity
+	}
+	writeError(w, status, err.Error())
+	return status
+}
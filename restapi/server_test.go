@@ -0,0 +1,216 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is an in-memory Client implementation.
+type fakeClient struct {
+	zones   []regru.Zone
+	records map[string][]regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	deleted []regru.DNSRecord
+	err     error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		zones: []regru.Zone{{ID: "1", Name: "example.com"}},
+		records: map[string][]regru.DNSRecord{
+			"example.com": {
+				{Name: "@", Type: regru.RecordTypeA, Content: "192.0.2.1"},
+				{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.2"},
+			},
+		},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	var records []regru.DNSRecord
+	for _, rr := range f.records[params.ZoneName] {
+		if params.Name == "" || rr.Name == params.Name {
+			records = append(records, rr)
+		}
+	}
+	return records, nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	if f.err != nil {
+		return regru.DNSRecord{}, f.err
+	}
+	f.added = append(f.added, params)
+	return regru.DNSRecord{ID: "42", Name: params.Name, Type: params.Type, Content: params.Content, TTL: params.TTL}, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := NewServer(newFakeClient(), WithToken("ci", "secret"))
+
+	for _, header := range []string{"", "Bearer", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest(http.MethodGet, "/zones", nil)
+		req.Header.Set("Authorization", header)
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+	}
+
+	rec := doRequest(t, NewServer(newFakeClient()), http.MethodGet, "/zones", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_ListZones(t *testing.T) {
+	server := NewServer(newFakeClient(), WithToken("ci", "secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/zones", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var zones []regru.Zone
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &zones))
+	assert.Equal(t, []regru.Zone{{ID: "1", Name: "example.com"}}, zones)
+}
+
+func TestServer_ListRecords(t *testing.T) {
+	server := NewServer(newFakeClient(), WithToken("ci", "secret"))
+
+	rec := doRequest(t, server, http.MethodGet, "/zones/Example.com./records?name=www", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var records []regru.DNSRecord
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &records))
+	require.Len(t, records, 1)
+	assert.Equal(t, "192.0.2.2", records[0].Content)
+
+	rec = doRequest(t, server, http.MethodGet, "/zones/unknown.com/records", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_AddAndDeleteRecord(t *testing.T) {
+	client := newFakeClient()
+	var entries []AuditEntry
+	server := NewServer(client,
+		WithToken("ci", "secret"),
+		WithAuditHook(func(ctx context.Context, entry AuditEntry) {
+			assert.Equal(t, "ci", CallerFromContext(ctx))
+			entries = append(entries, entry)
+		}),
+	)
+
+	rec := doRequest(t, server, http.MethodPost, "/zones/example.com/records",
+		`{"name":"api","type":"A","content":"192.0.2.3","ttl":300}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id":"42","name":"api","type":"A","content":"192.0.2.3","ttl":300}`, rec.Body.String())
+	require.Len(t, client.added, 1)
+	assert.Equal(t, 300, client.added[0].TTL)
+
+	rec = doRequest(t, server, http.MethodDelete, "/zones/example.com/records",
+		`{"name":"www","type":"A","content":"192.0.2.2"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, client.deleted, 1)
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ci", entries[0].Caller)
+	assert.Equal(t, http.MethodPost, entries[0].Method)
+	assert.Equal(t, "example.com", entries[0].Zone)
+	assert.Equal(t, http.StatusCreated, entries[0].Status)
+	assert.Equal(t, http.MethodDelete, entries[1].Method)
+	assert.Equal(t, "www", entries[1].Record.Name)
+}
+
+func TestServer_AddRecord_Priority(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client, WithToken("ci", "secret"))
+
+	rec := doRequest(t, server, http.MethodPost, "/zones/example.com/records",
+		`{"name":"@","type":"MX","content":"mail.example.com","priority":10}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, client.added, 1)
+	assert.Equal(t, 10, client.added[0].Priority)
+}
+
+func TestServer_WriteErrors(t *testing.T) {
+	client := newFakeClient()
+	var entries []AuditEntry
+	server := NewServer(client,
+		WithToken("ci", "secret"),
+		WithAuditHook(func(ctx context.Context, entry AuditEntry) { entries = append(entries, entry) }),
+	)
+
+	rec := doRequest(t, server, http.MethodPost, "/zones/example.com/records", `{"name":"api"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = doRequest(t, server, http.MethodPost, "/zones/example.com/records", `not json`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, entries)
+
+	client.err = &regru.WriteDeniedError{Method: "zone/add_alias", Err: regru.ErrPlanRejected}
+	rec = doRequest(t, server, http.MethodPost, "/zones/example.com/records",
+		`{"name":"api","type":"A","content":"192.0.2.3"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	client.err = &regru.APIError{Message: "invalid content"}
+	rec = doRequest(t, server, http.MethodDelete, "/zones/example.com/records",
+		`{"name":"www","type":"A","content":"192.0.2.2"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid content")
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, http.StatusForbidden, entries[0].Status)
+	assert.Error(t, entries[0].Err)
+	assert.Equal(t, http.StatusUnprocessableEntity, entries[1].Status)
+}
+
+func TestServer_BodyTooLarge(t *testing.T) {
+	client := newFakeClient()
+	server := NewServer(client, WithToken("ci", "secret"))
+
+	body := `{"name":"api","type":"TXT","content":"` + strings.Repeat("a", maxBodySize) + `"}`
+	rec := doRequest(t, server, http.MethodPost, "/zones/example.com/records", body)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Empty(t, client.added)
+}
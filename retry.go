@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WithMaxRetries sets how many times a failed request is retried. Reads are
+// retried on network errors and on HTTP 429, 502, 503 and 504 responses;
+// writes only on HTTP 429 and 503, which guarantee the change was not applied.
+// Retries are disabled by default.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithMaxElapsedTime limits the total time spent on a request including all
+// retries. No retry is started that would end after the limit.
+func WithMaxElapsedTime(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxElapsedTime = d
+	}
+}
+
+// WithRetryBudget limits retries client-wide to at most n within any window,
+// so an API outage does not multiply the request volume. Once the budget is
+// spent, failed requests return their error without retrying.
+func WithRetryBudget(n int, window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = &retryBudget{max: n, window: window}
+	}
+}
+
+// retryBudget is a sliding-window limit on the number of retries.
+type retryBudget struct {
+	mu     sync.Mutex
+	max    int
+	window time.Duration
+	spent  []time.Time
+}
+
+// allow reports whether a retry may be made now and records it if so.
+func (b *retryBudget) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Forget retries that left the window
+	cutoff := now.Add(-b.window)
+	i := 0
+	for i < len(b.spent) && !b.spent[i].After(cutoff) {
+		i++
+	}
+	b.spent = b.spent[i:]
+
+	if len(b.spent) >= b.max {
+		return false
+	}
+	b.spent = append(b.spent, now)
+	return true
+}
+
+// withRetry calls fn and retries it with the backoff strategy while the error
+// is retryable and neither the retry limits nor the context are exhausted.
+func (c *Client) withRetry(ctx context.Context, read bool, fn func() ([]byte, error)) ([]byte, error) {
+	start := time.Now()
+	backoff := c.backoff
+	if backoff == nil {
+		backoff = defaultBackoff
+	}
+
+	var wait time.Duration
+	for attempt := 0; ; attempt++ {
+		body, err := fn()
+		if err == nil || attempt >= c.maxRetries || ctx.Err() != nil || !isRetryable(err, read) {
+			return body, err
+		}
+
+		wait = backoff.Delay(attempt, wait)
+		if c.maxElapsedTime > 0 && time.Since(start)+wait > c.maxElapsedTime {
+			return body, err
+		}
+		if c.retryBudget != nil && !c.retryBudget.allow(time.Now()) {
+			return body, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return body, err
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryable reports whether a failed request may be repeated.
+func isRetryable(err error, read bool) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return read
+		default:
+			return false
+		}
+	}
+
+	// Network errors: a write may have been applied before the connection failed
+	var urlErr *url.Error
+	return read && errors.As(err, &urlErr)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupFlakyServer returns a server that fails the first failures requests with the status.
+func setupFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":"success"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient_Retry(t *testing.T) {
+	server, requests := setupFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	client := NewClient("username", "password", WithBaseURL(server.URL), WithMaxRetries(2), WithBackoff(ConstantBackoff{Interval: time.Millisecond}))
+
+	_, err := client.apiRequest(context.Background(), "zone/add_alias", &AddAliasRequest{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestClient_Retry_Disabled(t *testing.T) {
+	server, requests := setupFlakyServer(t, 1, http.StatusServiceUnavailable)
+
+	client := NewClient("username", "password", WithBaseURL(server.URL))
+
+	_, err := client.apiRequest(context.Background(), "zone/get_resource_records", &ZoneGetResourceRecordsRequest{})
+	require.Error(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestClient_Retry_MaxElapsedTime(t *testing.T) {
+	server, requests := setupFlakyServer(t, 5, http.StatusServiceUnavailable)
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithMaxRetries(5),
+		WithMaxElapsedTime(100*time.Millisecond),
+	)
+
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.EqualValues(t, 1, requests.Load(), "retry would exceed the elapsed time limit")
+}
+
+func TestClient_Retry_Budget(t *testing.T) {
+	server, requests := setupFlakyServer(t, 10, http.StatusTooManyRequests)
+
+	client := NewClient("username", "password",
+		WithBaseURL(server.URL),
+		WithMaxRetries(1),
+		WithRetryBudget(1, time.Minute),
+		WithBackoff(ConstantBackoff{Interval: time.Millisecond}),
+	)
+
+	// The first request spends the budget, the second is not retried
+	_, err := client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	require.Error(t, err)
+	_, err = client.apiRequest(context.Background(), "service/get_list", &ServiceListRequest{})
+	require.Error(t, err)
+	assert.EqualValues(t, 3, requests.Load())
+}
+
+func TestRetryBudget_Window(t *testing.T) {
+	budget := &retryBudget{max: 2, window: time.Minute}
+	now := time.Now()
+
+	assert.True(t, budget.allow(now))
+	assert.True(t, budget.allow(now.Add(time.Second)))
+	assert.False(t, budget.allow(now.Add(2*time.Second)))
+	assert.True(t, budget.allow(now.Add(61*time.Second)))
+}
+
+func TestIsRetryable(t *testing.T) {
+	netErr := &url.Error{Op: "Post", URL: "https://api.reg.ru", Err: errors.New("connection reset")}
+
+	assert.True(t, isRetryable(&HTTPError{StatusCode: http.StatusTooManyRequests}, false))
+	assert.True(t, isRetryable(&HTTPError{StatusCode: http.StatusBadGateway}, true))
+	assert.False(t, isRetryable(&HTTPError{StatusCode: http.StatusBadGateway}, false))
+	assert.False(t, isRetryable(&HTTPError{StatusCode: http.StatusBadRequest}, true))
+	assert.True(t, isRetryable(netErr, true))
+	assert.False(t, isRetryable(netErr, false))
+	assert.False(t, isRetryable(&APIError{Message: "invalid"}, true))
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// MaxPTRRecords limits the number of addresses PTRRecordsForPrefix expands a prefix into.
+const MaxPTRRecords = 65536
+
+// PTRRecord maps an IP address to the hostname its reverse record points at.
+type PTRRecord struct {
+	IP       string
+	Hostname string
+	TTL      int
+}
+
+// ReverseName returns the reverse DNS name of an IP address, such as
+// "1.2.0.192.in-addr.arpa" for 192.0.2.1 or a nibble name under ip6.arpa for IPv6.
+func ReverseName(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q: %w", ip, err)
+	}
+	addr = addr.Unmap()
+
+	if addr.Is4() {
+		b := addr.As4()
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", b[3], b[2], b[1], b[0]), nil
+	}
+
+	b := addr.As16()
+	labels := make([]string, 0, 32)
+	for i := len(b) - 1; i >= 0; i-- {
+		labels = append(labels, fmt.Sprintf("%x", b[i]&0x0f), fmt.Sprintf("%x", b[i]>>4))
+	}
+	return strings.Join(labels, ".") + ".ip6.arpa", nil
+}
+
+// ReverseZone returns the conventional reverse zone of an IP address: the
+// in-addr.arpa zone of its /24 network for IPv4, such as "2.0.192.in-addr.arpa"
+// for 192.0.2.1, or the ip6.arpa zone of its /64 network for IPv6.
+func ReverseZone(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address %q: %w", ip, err)
+	}
+	addr = addr.Unmap()
+
+	bits := 24
+	if addr.Is6() {
+		bits = 64
+	}
+	zones, err := ReverseZones(netip.PrefixFrom(addr, bits).String())
+	if err != nil {
+		return "", err
+	}
+	return zones[0], nil
+}
+
+// ReverseZones returns the reverse zones covering a CIDR prefix. Zones are cut
+// at octet boundaries for IPv4 and nibble boundaries for IPv6, so a /22 yields
+// four /24 zones and a /24 yields one. Prefixes longer than /24 (or /124 for
+// IPv6) return the enclosing zone, which requires RFC 2317 delegation for
+// classless blocks.
+func ReverseZones(cidr string) ([]string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	step, labels := 8, 4
+	if prefix.Addr().Is6() {
+		step, labels = 4, 32
+	}
+	total := labels * step
+
+	// Round the prefix length up to the next label boundary
+	bits := (prefix.Bits() + step - 1) / step * step
+	if bits == 0 {
+		bits = step
+	}
+	if bits >= total {
+		bits = total - step
+	}
+	if bits < prefix.Bits() {
+		prefix = netip.PrefixFrom(prefix.Addr(), bits).Masked()
+	}
+	if extra := bits - prefix.Bits(); extra > 12 {
+		return nil, fmt.Errorf("prefix %q spans too many reverse zones", cidr)
+	}
+
+	var zones []string
+	for addr, i := prefix.Addr(), 0; i < 1<<(bits-prefix.Bits()); i++ {
+		name, err := ReverseName(addr.String())
+		if err != nil {
+			return nil, err
+		}
+		// Drop the labels below the zone cut
+		parts := strings.SplitN(name, ".", (total-bits)/step+1)
+		zones = append(zones, parts[len(parts)-1])
+		addr = nextSubnet(addr, bits)
+	}
+	return zones, nil
+}
+
+// nextSubnet returns the first address of the next subnet with the prefix length.
+func nextSubnet(addr netip.Addr, bits int) netip.Addr {
+	b := addr.AsSlice()
+	bit := bits - 1
+	for i := bit / 8; i >= 0; i-- {
+		inc := byte(1)
+		if i == bit/8 {
+			inc = 1 << (7 - bit%8)
+		}
+		sum := b[i] + inc
+		carry := sum < b[i]
+		b[i] = sum
+		if !carry {
+			break
+		}
+		bit = i*8 - 1
+	}
+	next, _ := netip.AddrFromSlice(b)
+	return next
+}
+
+// PTRRecordsForPrefix returns one PTR record for every address in the CIDR
+// prefix, using hostname to derive the target name of each address.
+func PTRRecordsForPrefix(cidr string, hostname func(ip netip.Addr) string) ([]PTRRecord, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	if hostBits := prefix.Addr().BitLen() - prefix.Bits(); hostBits > 16 {
+		return nil, fmt.Errorf("prefix %q has more than %d addresses", cidr, MaxPTRRecords)
+	}
+
+	var records []PTRRecord
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		records = append(records, PTRRecord{IP: addr.String(), Hostname: hostname(addr)})
+	}
+	return records, nil
+}
+
+// AddPTRRecords creates PTR records for the addresses in the reverse zones of
+// the account. Each address is placed in the longest matching reverse zone;
+// addresses without one fail with ErrZoneNotFound in their result. A failing
+// record does not stop the batch. With WithDryRun no records are created.
+func (c *Client) AddPTRRecords(ctx context.Context, records []PTRRecord, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(BatchResults, 0, len(records))
+	for _, ptr := range records {
+		name, err := ReverseName(ptr.IP)
+		if err != nil {
+			results = append(results, BatchResult{
+				Item:      DNSRecord{Type: RecordTypePTR, Content: ptr.Hostname},
+				Operation: BatchOperationCreate,
+				Err:       err,
+			})
+			continue
+		}
+
+		zone := reverseZoneFor(name, zones)
+		params := CreateDNSRecordParams{
+			Name:    relativeName(name, zone),
+			Type:    RecordTypePTR,
+			Content: canonicalHost(ptr.Hostname),
+			TTL:     ptr.TTL,
+		}
+		if zone == "" {
+			results = append(results, BatchResult{
+				Item:      DNSRecord{Name: name, Type: RecordTypePTR, Content: params.Content},
+				Operation: BatchOperationCreate,
+				Err:       &ZoneNotFoundError{ZoneID: name},
+			})
+			continue
+		}
+		results = append(results, c.batchCreate(ctx, zone, params, o.dryRun))
+	}
+
+	return results, nil
+}
+
+// reverseZoneFor returns the longest account zone containing the reverse name, or "".
+func reverseZoneFor(name string, zones []Zone) string {
+	best := ""
+	for _, zone := range zones {
+		z := canonicalHost(zone.Name)
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(best) {
+			best = z
+		}
+	}
+	return best
+}
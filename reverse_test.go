@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseName(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"::ffff:192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"2001:db8::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got, err := ReverseName(tt.ip)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ReverseName("not-an-ip")
+	assert.Error(t, err)
+}
+
+func TestReverseZone(t *testing.T) {
+	zone, err := ReverseZone("192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.192.in-addr.arpa", zone)
+
+	zone, err = ReverseZone("2001:db8::1")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", zone)
+
+	_, err = ReverseZone("192.0.2.0/24")
+	assert.Error(t, err)
+}
+
+func TestClient_PTRRecords(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+				DName:  "2.0.192.in-addr.arpa",
+				RRList: []ResourceRecord{{Subname: "10", Rectype: "PTR", Content: "host.example.com."}},
+			}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := client.AddRR(ctx, "2.0.192.in-addr.arpa", CreateDNSRecordParams{
+		Name: "10", Type: RecordTypePTR, Content: "host.example.com.",
+	})
+	require.NoError(t, err)
+
+	records, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "2.0.192.in-addr.arpa"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, RecordTypePTR, records[0].Type)
+
+	require.NoError(t, client.DeleteRR(ctx, "2.0.192.in-addr.arpa", records[0]))
+
+	require.Len(t, *calls, 3)
+	assert.Equal(t, "zone/add_ptr", (*calls)[0].Path)
+	assert.Equal(t, "zone/remove_record", (*calls)[2].Path)
+	assert.Equal(t, "PTR", (*calls)[2].Input["record_type"])
+	assert.Equal(t, "10", (*calls)[2].Input["subdomain"])
+}
+
+func TestReverseZones(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want []string
+	}{
+		{"192.0.2.0/24", []string{"2.0.192.in-addr.arpa"}},
+		{"192.0.2.128/25", []string{"2.0.192.in-addr.arpa"}},
+		{"10.0.0.0/8", []string{"10.in-addr.arpa"}},
+		{"198.51.100.0/22", []string{
+			"100.51.198.in-addr.arpa",
+			"101.51.198.in-addr.arpa",
+			"102.51.198.in-addr.arpa",
+			"103.51.198.in-addr.arpa",
+		}},
+		{"2001:db8::/32", []string{"8.b.d.0.1.0.0.2.ip6.arpa"}},
+		{"2001:db8::/31", []string{"8.b.d.0.1.0.0.2.ip6.arpa", "9.b.d.0.1.0.0.2.ip6.arpa"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, err := ReverseZones(tt.cidr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ReverseZones("192.0.2.0")
+	assert.Error(t, err)
+}
+
+func TestPTRRecordsForPrefix(t *testing.T) {
+	records, err := PTRRecordsForPrefix("192.0.2.0/30", func(ip netip.Addr) string {
+		return "host-" + ip.String() + ".example.com"
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, PTRRecord{IP: "192.0.2.0", Hostname: "host-192.0.2.0.example.com"}, records[0])
+	assert.Equal(t, "192.0.2.3", records[3].IP)
+
+	_, err = PTRRecordsForPrefix("10.0.0.0/8", func(netip.Addr) string { return "" })
+	assert.Error(t, err)
+}
+
+func TestClient_AddPTRRecords(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"service/get_list": ServiceListResponse{
+			Answer: ServiceListAnswer{Services: []Service{
+				{ServiceType: "domain", Domain: "0.192.in-addr.arpa", ServiceID: 1},
+				{ServiceType: "domain", Domain: "2.0.192.in-addr.arpa", ServiceID: 2},
+			}},
+		},
+		"zone/add_ptr": AddNSResponse{
+			Answer: AddNSAnswer{Domains: []DomainResult{{DName: "2.0.192.in-addr.arpa", Result: "success", DNSID: "7"}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.AddPTRRecords(context.Background(), []PTRRecord{
+		{IP: "192.0.2.10", Hostname: "host.example.com."},
+		{IP: "198.51.100.1", Hostname: "other.example.com"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.True(t, results[0].Success)
+	assert.Equal(t, "7", results[0].DNSID)
+
+	assert.False(t, results[1].Success)
+	assert.True(t, errors.Is(results[1].Err, ErrZoneNotFound))
+
+	require.Len(t, *calls, 2)
+	call := (*calls)[1]
+	assert.Equal(t, "zone/add_ptr", call.Path)
+	assert.Equal(t, "10", call.Input["subdomain"])
+	assert.Equal(t, "host.example.com", call.Input["domain_name"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"dname": "2.0.192.in-addr.arpa"}}, call.Input["domains"])
+}
@@ -0,0 +1,368 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rfc2136 implements a gateway that accepts RFC 2136 DNS UPDATE
+// messages and applies them to reg.ru zones through the client, so existing
+// nsupdate or DHCP server integrations can target reg.ru-hosted zones.
+//
+// Updates must be signed with one of the configured TSIG keys. Prerequisites
+// (RFC 2136, section 2.4) are evaluated against the current zone records
+// before any change is made.
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/regru-go"
+)
+
+// DefaultTimeout bounds the processing time of a single update message.
+const DefaultTimeout = 60 * time.Second
+
+// Client is the subset of regru.Client methods used by the gateway.
+type Client interface {
+	ListZones(ctx context.Context) ([]regru.Zone, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+	AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error)
+	DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error
+}
+
+// Gateway is a dns.Handler translating DNS UPDATE messages into client calls.
+type Gateway struct {
+	client  Client
+	keys    map[string]string
+	tsig    *tsigProvider
+	timeout time.Duration
+}
+
+// Option represents an option for configuring the gateway.
+type Option func(*Gateway)
+
+// WithTSIGKey adds a TSIG key accepted for updates. The secret is base64-encoded,
+// as produced by tsig-keygen.
+func WithTSIGKey(name, secret string) Option {
+	return func(g *Gateway) {
+		g.keys[dns.Fqdn(strings.ToLower(name))] = secret
+	}
+}
+
+// WithTimeout sets the maximum processing time of a single update message.
+func WithTimeout(timeout time.Duration) Option {
+	return func(g *Gateway) {
+		g.timeout = timeout
+	}
+}
+
+// NewGateway creates a new update gateway backed by the client.
+// Without TSIG keys all updates are refused.
+func NewGateway(client Client, opts ...Option) *Gateway {
+	g := &Gateway{
+		client:  client,
+		keys:    make(map[string]string),
+		timeout: DefaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.tsig = newTSIGProvider(g.keys)
+
+	return g
+}
+
+// NewServer returns a DNS server for the address and network ("udp" or "tcp")
+// that verifies TSIG signatures with the gateway keys and serves updates.
+func (g *Gateway) NewServer(addr, network string) *dns.Server {
+	return &dns.Server{
+		Addr:          addr,
+		Net:           network,
+		Handler:       g,
+		TsigProvider:  g.tsig,
+		MsgAcceptFunc: acceptUpdates,
+	}
+}
+
+// acceptUpdates accepts DNS UPDATE messages, which the default accept function rejects.
+func acceptUpdates(dh dns.Header) dns.MsgAcceptAction {
+	if opcode := int(dh.Bits>>11) & 0xF; opcode == dns.OpcodeUpdate {
+		return dns.MsgAccept
+	}
+	return dns.DefaultMsgAcceptFunc(dh)
+}
+
+// TSIGProvider returns the provider that verifies and signs messages with the
+// gateway keys. A dns.Server serving the gateway must use it as TsigProvider,
+// as NewServer does: updates whose signature was not verified through it are
+// refused.
+func (g *Gateway) TSIGProvider() dns.TsigProvider {
+	return g.tsig
+}
+
+// ServeDNS implements dns.Handler.
+func (g *Gateway) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	// The TSIG status of the writer is nil if the server checks no
+	// signatures at all, so also require the gateway's own verification.
+	tsig := r.IsTsig()
+	verified := tsig != nil && g.tsig.consume(tsig) && w.TsigStatus() == nil
+	switch {
+	case r.Opcode != dns.OpcodeUpdate:
+		m.Rcode = dns.RcodeNotImplemented
+	case !verified:
+		m.Rcode = dns.RcodeRefused
+	default:
+		ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+		m.Rcode = g.update(ctx, r)
+		cancel()
+	}
+
+	if verified {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, time.Now().Unix())
+	}
+
+	_ = w.WriteMsg(m)
+}
+
+// update processes an UPDATE message and returns the response code.
+func (g *Gateway) update(ctx context.Context, r *dns.Msg) int {
+	// Zone section: exactly one SOA question
+	if len(r.Question) != 1 || r.Question[0].Qtype != dns.TypeSOA {
+		return dns.RcodeFormatError
+	}
+	origin := strings.ToLower(dns.Fqdn(r.Question[0].Name))
+
+	zone, err := g.findZone(ctx, origin)
+	if err != nil {
+		return dns.RcodeServerFailure
+	}
+	if zone == "" {
+		return dns.RcodeNotAuth
+	}
+
+	// All names must be inside the zone and all added types must be supported
+	for _, rr := range append(append([]dns.RR(nil), r.Answer...), r.Ns...) {
+		if !dns.IsSubDomain(origin, strings.ToLower(rr.Header().Name)) {
+			return dns.RcodeNotZone
+		}
+	}
+	for _, rr := range r.Ns {
+		if rr.Header().Class == dns.ClassINET {
+			if _, err := toRecord(origin, rr); err != nil {
+				return dns.RcodeNotImplemented
+			}
+		}
+	}
+
+	current, err := g.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return dns.RcodeServerFailure
+	}
+
+	if rcode := checkPrerequisites(origin, current, r.Answer); rcode != dns.RcodeSuccess {
+		return rcode
+	}
+
+	for _, rr := range r.Ns {
+		if err := g.apply(ctx, zone, origin, current, rr); err != nil {
+			return dns.RcodeServerFailure
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+// apply performs a single update section entry.
+func (g *Gateway) apply(ctx context.Context, zone, origin string, current []regru.DNSRecord, rr dns.RR) error {
+	hdr := rr.Header()
+	name := relativeName(hdr.Name, origin)
+
+	switch hdr.Class {
+	case dns.ClassINET:
+		record, err := toRecord(origin, rr)
+		if err != nil {
+			return err
+		}
+		for _, existing := range current {
+			if existing.Equal(record) {
+				return nil
+			}
+		}
+		_, err = g.client.AddRR(ctx, zone, regru.CreateDNSRecordParams{
+			Name:    record.Name,
+			Type:    record.Type,
+			Content: record.Content,
+			TTL:     record.TTL,
+		})
+		return err
+	case dns.ClassANY:
+		// Delete an RRset, or all RRsets at the name for type ANY
+		for _, existing := range matching(current, name, hdr.Rrtype) {
+			// Apex NS records are never removed by a type ANY deletion (RFC 2136, section 3.4.2.3)
+			if hdr.Rrtype == dns.TypeANY && name == "@" && existing.Type.Canonical() == regru.RecordTypeNS {
+				continue
+			}
+			if err := g.client.DeleteRR(ctx, zone, existing); err != nil {
+				return err
+			}
+		}
+		return nil
+	case dns.ClassNONE:
+		// Delete a single record
+		hdr.Class = dns.ClassINET
+		record, err := toRecord(origin, rr)
+		hdr.Class = dns.ClassNONE
+		if err != nil {
+			return nil
+		}
+		for _, existing := range current {
+			if existing.Equal(record) {
+				return g.client.DeleteRR(ctx, zone, existing)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported update class %d", hdr.Class)
+	}
+}
+
+// findZone returns the account zone matching the origin, or "" if there is none.
+func (g *Gateway) findZone(ctx context.Context, origin string) (string, error) {
+	zones, err := g.client.ListZones(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, z := range zones {
+		if dns.Fqdn(strings.ToLower(z.Name)) == origin {
+			return z.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// checkPrerequisites evaluates the prerequisite section (RFC 2136, section 3.2).
+// Value dependent prerequisites of the same name and type form an RRset that
+// must equal the RRset in the zone (section 3.2.3).
+func checkPrerequisites(origin string, current []regru.DNSRecord, prereqs []dns.RR) int {
+	type rrset struct {
+		name   string
+		rrtype uint16
+	}
+	var (
+		order []rrset
+		sets  = make(map[rrset][]regru.DNSRecord)
+	)
+
+	for _, rr := range prereqs {
+		hdr := rr.Header()
+		name := relativeName(hdr.Name, origin)
+		found := matching(current, name, hdr.Rrtype)
+
+		switch hdr.Class {
+		case dns.ClassANY:
+			if len(found) == 0 {
+				if hdr.Rrtype == dns.TypeANY {
+					return dns.RcodeNameError
+				}
+				return dns.RcodeNXRrset
+			}
+		case dns.ClassNONE:
+			if len(found) > 0 {
+				if hdr.Rrtype == dns.TypeANY {
+					return dns.RcodeYXDomain
+				}
+				return dns.RcodeYXRrset
+			}
+		case dns.ClassINET:
+			record, err := toRecord(origin, rr)
+			if err != nil {
+				return dns.RcodeNXRrset
+			}
+			set := rrset{name: name, rrtype: hdr.Rrtype}
+			if _, ok := sets[set]; !ok {
+				order = append(order, set)
+			}
+			sets[set] = append(sets[set], record)
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+
+	for _, set := range order {
+		if !sameRRset(matching(current, set.name, set.rrtype), sets[set]) {
+			return dns.RcodeNXRrset
+		}
+	}
+	return dns.RcodeSuccess
+}
+
+// sameRRset reports whether both record lists hold the same records, ignoring order and repeats.
+func sameRRset(a, b []regru.DNSRecord) bool {
+	contains := func(records []regru.DNSRecord, rr regru.DNSRecord) bool {
+		for _, r := range records {
+			if r.Equal(rr) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rr := range a {
+		if !contains(b, rr) {
+			return false
+		}
+	}
+	for _, rr := range b {
+		if !contains(a, rr) {
+			return false
+		}
+	}
+	return true
+}
+
+// matching returns the records with the name and type (any type for dns.TypeANY).
+func matching(records []regru.DNSRecord, name string, rrtype uint16) []regru.DNSRecord {
+	var result []regru.DNSRecord
+	for _, rr := range records {
+		c := regru.Canonicalize(rr)
+		if c.Name != name {
+			continue
+		}
+		if rrtype != dns.TypeANY && string(c.Type) != dns.TypeToString[rrtype] {
+			continue
+		}
+		result = append(result, rr)
+	}
+	return result
+}
+
+// relativeName converts an absolute owner name into a name relative to origin.
+func relativeName(name, origin string) string {
+	name = strings.ToLower(dns.Fqdn(name))
+	if name == origin {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+origin)
+}
+
+// toRecord converts a DNS resource record into a reg.ru record.
+func toRecord(origin string, rr dns.RR) (regru.DNSRecord, error) {
+	return regru.FromDNSRR(rr, origin)
+}
@@ -0,0 +1,330 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKeyName = "update-key."
+	testSecret  = "c2VjcmV0LWtleS1mb3ItdGVzdHM="
+)
+
+// fakeClient is an in-memory Client implementation. It is called from the
+// gateway server goroutine, so its state is guarded by mu.
+type fakeClient struct {
+	mu      sync.Mutex
+	records []regru.DNSRecord
+	added   []regru.CreateDNSRecordParams
+	deleted []regru.DNSRecord
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		records: []regru.DNSRecord{
+			{Name: "@", Type: regru.RecordTypeNS, Content: "ns1.reg.ru"},
+			{Name: "host", Type: regru.RecordTypeA, Content: "192.0.2.1"},
+			{Name: "host", Type: regru.RecordTypeTXT, Content: "dhcp-id"},
+		},
+	}
+}
+
+func (f *fakeClient) ListZones(ctx context.Context) ([]regru.Zone, error) {
+	return []regru.Zone{{ID: "1", Name: "example.com"}}, nil
+}
+
+func (f *fakeClient) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]regru.DNSRecord(nil), f.records...), nil
+}
+
+func (f *fakeClient) AddRR(ctx context.Context, zone string, params regru.CreateDNSRecordParams) (regru.DNSRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.added = append(f.added, params)
+	return regru.DNSRecord{Name: params.Name, Type: params.Type, Content: params.Content}, nil
+}
+
+func (f *fakeClient) DeleteRR(ctx context.Context, zone string, rr regru.DNSRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, rr)
+	return nil
+}
+
+// Added returns a copy of the added records.
+func (f *fakeClient) Added() []regru.CreateDNSRecordParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]regru.CreateDNSRecordParams(nil), f.added...)
+}
+
+// Deleted returns a copy of the deleted records.
+func (f *fakeClient) Deleted() []regru.DNSRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]regru.DNSRecord(nil), f.deleted...)
+}
+
+// startGateway starts a UDP gateway server on a random local port.
+func startGateway(t *testing.T, client Client) string {
+	t.Helper()
+
+	gateway := NewGateway(client, WithTSIGKey(testKeyName, testSecret))
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := gateway.NewServer("", "udp")
+	server.PacketConn = conn
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+// sendUpdate sends the message signed with the given key and returns the response code.
+func sendUpdate(t *testing.T, addr string, m *dns.Msg, secret string) int {
+	t.Helper()
+
+	client := &dns.Client{Net: "udp", TsigSecret: map[string]string{testKeyName: secret}, Timeout: 5 * time.Second}
+	if secret != "" {
+		m.SetTsig(testKeyName, dns.HmacSHA256, 300, time.Now().Unix())
+	}
+	resp, _, err := client.Exchange(m, addr)
+	if err != nil && resp == nil {
+		require.NoError(t, err)
+	}
+	return resp.Rcode
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+	return rr
+}
+
+func TestGateway_AddAndDelete(t *testing.T) {
+	client := newFakeClient()
+	addr := startGateway(t, client)
+
+	m := new(dns.Msg)
+	m.SetUpdate("example.com.")
+	m.Insert([]dns.RR{mustRR(t, "www.example.com. 300 IN A 192.0.2.10")})
+	m.RemoveRRset([]dns.RR{mustRR(t, "host.example.com. 0 IN TXT \"\"")})
+	m.Remove([]dns.RR{mustRR(t, "host.example.com. 0 IN A 192.0.2.1")})
+
+	rcode := sendUpdate(t, addr, m, testSecret)
+	require.Equal(t, dns.RcodeSuccess, rcode, dns.RcodeToString[rcode])
+
+	added := client.Added()
+	require.Len(t, added, 1)
+	assert.Equal(t, regru.CreateDNSRecordParams{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.10", TTL: 300}, added[0])
+
+	deleted := client.Deleted()
+	require.Len(t, deleted, 2)
+	assert.Equal(t, regru.RecordTypeTXT, deleted[0].Type)
+	assert.Equal(t, "192.0.2.1", deleted[1].Content)
+}
+
+func TestGateway_RemoveName_KeepsApexNS(t *testing.T) {
+	client := newFakeClient()
+	addr := startGateway(t, client)
+
+	m := new(dns.Msg)
+	m.SetUpdate("example.com.")
+	m.RemoveName([]dns.RR{mustRR(t, "example.com. 0 IN A 0.0.0.0")})
+
+	rcode := sendUpdate(t, addr, m, testSecret)
+	require.Equal(t, dns.RcodeSuccess, rcode)
+	assert.Empty(t, client.Deleted())
+}
+
+func TestGateway_Prerequisites(t *testing.T) {
+	tests := []struct {
+		name      string
+		records   []regru.DNSRecord
+		prereq    func(m *dns.Msg)
+		wantRcode int
+	}{
+		{
+			name:      "name in use",
+			prereq:    func(m *dns.Msg) { m.NameUsed([]dns.RR{mustRR(t, "host.example.com. 0 IN A 0.0.0.0")}) },
+			wantRcode: dns.RcodeSuccess,
+		},
+		{
+			name:      "name in use fails",
+			prereq:    func(m *dns.Msg) { m.NameUsed([]dns.RR{mustRR(t, "missing.example.com. 0 IN A 0.0.0.0")}) },
+			wantRcode: dns.RcodeNameError,
+		},
+		{
+			name:      "name not in use fails",
+			prereq:    func(m *dns.Msg) { m.NameNotUsed([]dns.RR{mustRR(t, "host.example.com. 0 IN A 0.0.0.0")}) },
+			wantRcode: dns.RcodeYXDomain,
+		},
+		{
+			name:      "rrset does not exist fails",
+			prereq:    func(m *dns.Msg) { m.RRsetNotUsed([]dns.RR{mustRR(t, "host.example.com. 0 IN A 0.0.0.0")}) },
+			wantRcode: dns.RcodeYXRrset,
+		},
+		{
+			name:      "value dependent rrset exists",
+			prereq:    func(m *dns.Msg) { m.Used([]dns.RR{mustRR(t, "host.example.com. 0 IN A 192.0.2.1")}) },
+			wantRcode: dns.RcodeSuccess,
+		},
+		{
+			name:      "value dependent rrset exists fails",
+			prereq:    func(m *dns.Msg) { m.Used([]dns.RR{mustRR(t, "host.example.com. 0 IN A 192.0.2.99")}) },
+			wantRcode: dns.RcodeNXRrset,
+		},
+		{
+			name: "value dependent rrset with all records",
+			records: []regru.DNSRecord{
+				{Name: "host", Type: regru.RecordTypeA, Content: "192.0.2.2"},
+			},
+			prereq: func(m *dns.Msg) {
+				m.Used([]dns.RR{
+					mustRR(t, "host.example.com. 0 IN A 192.0.2.2"),
+					mustRR(t, "host.example.com. 0 IN A 192.0.2.1"),
+				})
+			},
+			wantRcode: dns.RcodeSuccess,
+		},
+		{
+			name: "value dependent rrset with a subset fails",
+			records: []regru.DNSRecord{
+				{Name: "host", Type: regru.RecordTypeA, Content: "192.0.2.2"},
+			},
+			prereq:    func(m *dns.Msg) { m.Used([]dns.RR{mustRR(t, "host.example.com. 0 IN A 192.0.2.1")}) },
+			wantRcode: dns.RcodeNXRrset,
+		},
+		{
+			name: "value dependent rrset with a superset fails",
+			prereq: func(m *dns.Msg) {
+				m.Used([]dns.RR{
+					mustRR(t, "host.example.com. 0 IN A 192.0.2.1"),
+					mustRR(t, "host.example.com. 0 IN A 192.0.2.2"),
+				})
+			},
+			wantRcode: dns.RcodeNXRrset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			client.records = append(client.records, tt.records...)
+			addr := startGateway(t, client)
+
+			m := new(dns.Msg)
+			m.SetUpdate("example.com.")
+			tt.prereq(m)
+			m.Insert([]dns.RR{mustRR(t, "www.example.com. 300 IN A 192.0.2.10")})
+
+			rcode := sendUpdate(t, addr, m, testSecret)
+			assert.Equal(t, tt.wantRcode, rcode, dns.RcodeToString[rcode])
+			if tt.wantRcode == dns.RcodeSuccess {
+				assert.Len(t, client.Added(), 1)
+			} else {
+				assert.Empty(t, client.Added())
+			}
+		})
+	}
+}
+
+func TestGateway_Refusals(t *testing.T) {
+	client := newFakeClient()
+	addr := startGateway(t, client)
+
+	t.Run("unsigned", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetUpdate("example.com.")
+		m.Insert([]dns.RR{mustRR(t, "www.example.com. 300 IN A 192.0.2.10")})
+		assert.Equal(t, dns.RcodeRefused, sendUpdate(t, addr, m, ""))
+	})
+
+	t.Run("foreign zone", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetUpdate("other.com.")
+		m.Insert([]dns.RR{mustRR(t, "www.other.com. 300 IN A 192.0.2.10")})
+		assert.Equal(t, dns.RcodeNotAuth, sendUpdate(t, addr, m, testSecret))
+	})
+
+	t.Run("name outside zone", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetUpdate("example.com.")
+		m.Insert([]dns.RR{mustRR(t, "www.other.com. 300 IN A 192.0.2.10")})
+		assert.Equal(t, dns.RcodeNotZone, sendUpdate(t, addr, m, testSecret))
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		m := new(dns.Msg)
+		m.SetUpdate("example.com.")
+		m.Insert([]dns.RR{mustRR(t, "www.example.com. 300 IN HINFO \"cpu\" \"os\"")})
+		assert.Equal(t, dns.RcodeNotImplemented, sendUpdate(t, addr, m, testSecret))
+	})
+
+	assert.Empty(t, client.Added())
+}
+
+func TestGateway_ServerWithoutTSIG(t *testing.T) {
+	client := newFakeClient()
+	gateway := NewGateway(client, WithTSIGKey(testKeyName, testSecret))
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	// The server checks no signatures, so the gateway must do it itself
+	server := &dns.Server{PacketConn: conn, Handler: gateway, MsgAcceptFunc: acceptUpdates}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+	addr := conn.LocalAddr().String()
+
+	for name, secret := range map[string]string{
+		"forged MAC": "Zm9yZ2VkLXNlY3JldA==",
+		"valid MAC":  testSecret,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := new(dns.Msg)
+			m.SetUpdate("example.com.")
+			m.Insert([]dns.RR{mustRR(t, "www.example.com. 300 IN A 192.0.2.10")})
+			assert.Equal(t, dns.RcodeRefused, sendUpdate(t, addr, m, secret))
+		})
+	}
+
+	assert.Empty(t, client.Added())
+}
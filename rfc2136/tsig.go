@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+)
+
+// tsigProvider signs and verifies messages with the gateway keys. It records
+// the signatures it verified, so the gateway only applies updates that it
+// checked itself, whatever TSIG settings the hosting dns.Server has.
+type tsigProvider struct {
+	keys map[string]string
+
+	mu       sync.Mutex
+	verified map[string]int
+}
+
+func newTSIGProvider(keys map[string]string) *tsigProvider {
+	return &tsigProvider{keys: keys, verified: make(map[string]int)}
+}
+
+// Generate implements dns.TsigProvider.
+func (p *tsigProvider) Generate(msg []byte, t *dns.TSIG) ([]byte, error) {
+	secret, ok := p.keys[strings.ToLower(t.Hdr.Name)]
+	if !ok {
+		return nil, dns.ErrSecret
+	}
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var h hash.Hash
+	switch dns.CanonicalName(t.Algorithm) {
+	case dns.HmacSHA1:
+		h = hmac.New(sha1.New, key)
+	case dns.HmacSHA224:
+		h = hmac.New(sha256.New224, key)
+	case dns.HmacSHA256:
+		h = hmac.New(sha256.New, key)
+	case dns.HmacSHA384:
+		h = hmac.New(sha512.New384, key)
+	case dns.HmacSHA512:
+		h = hmac.New(sha512.New, key)
+	default:
+		return nil, dns.ErrKeyAlg
+	}
+	h.Write(msg)
+	return h.Sum(nil), nil
+}
+
+// Verify implements dns.TsigProvider.
+func (p *tsigProvider) Verify(msg []byte, t *dns.TSIG) error {
+	expected, err := p.Generate(msg, t)
+	if err != nil {
+		return err
+	}
+	mac, err := hex.DecodeString(t.MAC)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(expected, mac) {
+		return dns.ErrSig
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.verified[signatureKey(t)]++
+	return nil
+}
+
+// consume reports whether the signature was verified by Verify and forgets it.
+func (p *tsigProvider) consume(t *dns.TSIG) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := signatureKey(t)
+	if p.verified[key] == 0 {
+		return false
+	}
+	if p.verified[key]--; p.verified[key] == 0 {
+		delete(p.verified, key)
+	}
+	return true
+}
+
+// signatureKey identifies a signature by its key name and MAC.
+func signatureKey(t *dns.TSIG) string {
+	return strings.ToLower(t.Hdr.Name) + " " + strings.ToLower(t.MAC)
+}
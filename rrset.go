@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import "context"
+
+// RRSet is the set of records sharing a name and type, such as the addresses
+// of a round-robin name or several TXT values, managed as a unit.
+type RRSet struct {
+	// Name is the record name relative to the zone ("@" for the apex).
+	Name string
+	Type RecordType
+	// Values holds the content of each record, for example "10 mail.example.com" for MX.
+	Values []string
+	// TTL is the TTL in seconds; zero uses the zone default.
+	TTL int
+}
+
+// Records returns the records of the set.
+func (s RRSet) Records() []DNSRecord {
+	records := make([]DNSRecord, 0, len(s.Values))
+	for _, value := range s.Values {
+		records = append(records, DNSRecord{Name: s.Name, Type: s.Type, Content: value, TTL: s.TTL})
+	}
+	return records
+}
+
+// GetRRSet returns the records of the zone with the name and type as a set.
+// Values are in canonical form; a set without records has no values.
+func (c *Client) GetRRSet(ctx context.Context, zone, name string, rtype RecordType) (RRSet, error) {
+	rtype, err := checkRecordType(rtype)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	members, err := c.rrsetRecords(ctx, zone, name, rtype)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	set := RRSet{Name: name, Type: rtype}
+	for _, rr := range members {
+		set.Values = append(set.Values, Canonicalize(rr).Content)
+		if set.TTL == 0 {
+			set.TTL = effectiveTTL(rr.TTL, rr.TTLDuration)
+		}
+	}
+	return set, nil
+}
+
+// PutRRSet makes the records with the set's name and type contain exactly its
+// values: missing values are created first, then other records of the set are
+// deleted, so the name keeps resolving. An old CNAME value is deleted before
+// the new one is created, since a name can hold only one CNAME. Existing values whose known TTL
+// differs from a non-zero set TTL are recreated. A set without values deletes
+// all records of the name and type. WithDryRun previews the changes.
+func (c *Client) PutRRSet(ctx context.Context, zone string, set RRSet, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	rtype, err := checkRecordType(set.Type)
+	if err != nil {
+		return nil, err
+	}
+	set.Type = rtype
+
+	members, err := c.rrsetRecords(ctx, zone, set.Name, rtype)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[recordKey]DNSRecord, len(members))
+	for _, rr := range members {
+		present[keyOf(rr)] = rr
+	}
+
+	wanted := make(map[recordKey]bool, len(set.Values))
+	for _, rr := range set.Records() {
+		wanted[keyOf(rr)] = true
+	}
+
+	var results BatchResults
+	for _, rr := range members {
+		if deleteFirst(rr) && !wanted[keyOf(rr)] {
+			results = append(results, c.batchDelete(ctx, zone, rr, o.dryRun))
+		}
+	}
+
+	seen := make(map[recordKey]bool, len(set.Values))
+	for _, rr := range set.Records() {
+		key := keyOf(rr)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if existing, ok := present[key]; ok {
+			if ttlDiffers(existing, rr) {
+				results = append(results, c.batchUpdate(ctx, zone, existing, rr, o.dryRun))
+			}
+			continue
+		}
+		results = append(results, c.batchCreate(ctx, zone, paramsFromRecord(rr), o.dryRun))
+	}
+
+	for _, rr := range members {
+		if !deleteFirst(rr) && !wanted[keyOf(rr)] {
+			results = append(results, c.batchDelete(ctx, zone, rr, o.dryRun))
+		}
+	}
+
+	return results, nil
+}
+
+// DeleteRRSet deletes all records of the zone with the name and type.
+// WithDryRun previews the changes.
+func (c *Client) DeleteRRSet(ctx context.Context, zone, name string, rtype RecordType, opts ...BulkOption) (BatchResults, error) {
+	return c.PutRRSet(ctx, zone, RRSet{Name: name, Type: rtype}, opts...)
+}
+
+// rrsetRecords returns the records of the zone with the name and type.
+func (c *Client) rrsetRecords(ctx context.Context, zone, name string, rtype RecordType) ([]DNSRecord, error) {
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := FilterByName(name)
+	var members []DNSRecord
+	for _, rr := range records {
+		if filter.match(rr) && rr.Type.Canonical() == rtype {
+			members = append(members, rr)
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRRSetRecords returns a zone with a two-address round-robin name.
+func testRRSetRecords() ZoneGetResourceRecordsResponse {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "www", Rectype: "A", Content: "192.0.2.3"},
+	)
+	return records
+}
+
+func TestClient_GetRRSet(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testRRSetRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	set, err := client.GetRRSet(context.Background(), "example.com", "WWW", "a")
+	require.NoError(t, err)
+	assert.Equal(t, RecordTypeA, set.Type)
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.3"}, set.Values)
+
+	set, err = client.GetRRSet(context.Background(), "example.com", "api", RecordTypeA)
+	require.NoError(t, err)
+	assert.Empty(t, set.Values)
+
+	_, err = client.GetRRSet(context.Background(), "example.com", "www", "HINFO")
+	assert.ErrorIs(t, err, ErrUnsupportedRecordType)
+}
+
+func TestClient_PutRRSet(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testRRSetRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.PutRRSet(context.Background(), "example.com", RRSet{
+		Name:   "www",
+		Type:   RecordTypeA,
+		Values: []string{"192.0.2.3", "192.0.2.4", "192.0.2.4"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var summary []string
+	for _, result := range results {
+		summary = append(summary, string(result.Operation)+" "+result.Item.Content)
+	}
+	assert.Equal(t, []string{"create 192.0.2.4", "delete 192.0.2.2"}, summary)
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	assert.Equal(t, []string{"zone/get_resource_records", "zone/add_alias", "zone/remove_record"}, paths)
+}
+
+func TestClient_PutRRSet_CNAME(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{{
+					DName:  "example.com",
+					Result: "success",
+					RRList: []ResourceRecord{{Subname: "blog", Rectype: "CNAME", Content: "old.example.net"}},
+				}},
+			},
+			Result: "success",
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.PutRRSet(context.Background(), "example.com", RRSet{
+		Name:   "blog",
+		Type:   RecordTypeCNAME,
+		Values: []string{"new.example.net"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	assert.Equal(t, []string{"zone/get_resource_records", "zone/remove_record", "zone/add_cname"}, paths)
+}
+
+func TestClient_DeleteRRSet(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testRRSetRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.DeleteRRSet(context.Background(), "example.com", "www", RecordTypeA, WithDryRun())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, BatchOperationDelete, result.Operation)
+		assert.False(t, result.Success)
+	}
+	assert.Zero(t, countCalls(*calls, "zone/remove_record"))
+
+	results, err = client.DeleteRRSet(context.Background(), "example.com", "www", RecordTypeA)
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+	assert.Equal(t, 2, countCalls(*calls, "zone/remove_record"))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/miekg/dns"
+)
+
+// WithSerialNameservers sets the nameservers queried for zone SOA serials, as
+// "host" or "host:port". By default the authoritative nameservers of the zone
+// are looked up via the system resolver.
+func WithSerialNameservers(servers ...string) ClientOption {
+	return func(c *Client) {
+		c.serialNameservers = append([]string(nil), servers...)
+	}
+}
+
+// WithRecordsCacheRevalidation makes the records cache check the SOA serial of a
+// zone when its entry expires. If the serial is unchanged, the entry is renewed
+// without downloading the records again, so a longer-lived cache still notices
+// changes made outside the client. It has no effect without WithRecordsCache.
+func WithRecordsCacheRevalidation() ClientOption {
+	return func(c *Client) {
+		c.cacheRevalidation = true
+	}
+}
+
+// ZoneSerial returns the SOA serial of the zone as served by its nameservers.
+// The reg.ru API does not return the serial, so it is read from live DNS. When
+// the nameservers disagree, for example while a change propagates, the most
+// recent serial (RFC 1982 serial number arithmetic) is returned.
+func (c *Client) ZoneSerial(ctx context.Context, zone string) (uint32, error) {
+	zone = c.asciiName(zone)
+	servers, err := waitNameservers(ctx, zone, c.serialNameservers)
+	if err != nil {
+		return 0, err
+	}
+
+	fqdn := dns.Fqdn(canonicalHost(zone))
+	var (
+		serial uint32
+		found  bool
+		errs   []error
+	)
+	for _, server := range servers {
+		s, err := querySerial(ctx, server, fqdn)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", server, err))
+			continue
+		}
+		if !found || serialAfter(s, serial) {
+			serial = s
+		}
+		found = true
+	}
+	if !found {
+		if len(errs) == 0 {
+			return 0, fmt.Errorf("no nameservers for %s", zone)
+		}
+		return 0, errors.Join(errs...)
+	}
+	return serial, nil
+}
+
+// HasZoneChanged reports whether the SOA serial of the zone differs from
+// lastSerial, and returns the current serial to store for the next check.
+// Use it to skip downloading the records of zones that did not change.
+func (c *Client) HasZoneChanged(ctx context.Context, zone string, lastSerial uint32) (bool, uint32, error) {
+	serial, err := c.ZoneSerial(ctx, zone)
+	if err != nil {
+		return false, 0, err
+	}
+	return serial != lastSerial, serial, nil
+}
+
+// querySerial asks the server for the SOA record of the zone and returns its serial.
+func querySerial(ctx context.Context, server, fqdn string) (uint32, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(fqdn, dns.TypeSOA)
+	m.RecursionDesired = false
+
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, m, server)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return 0, fmt.Errorf("%s", dns.RcodeToString[resp.Rcode])
+	}
+	for _, rr := range resp.Answer {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Serial, nil
+		}
+	}
+	return 0, fmt.Errorf("no SOA record for %s", fqdn)
+}
+
+// serialAfter reports whether serial a is more recent than b (RFC 1982).
+func serialAfter(a, b uint32) bool {
+	return int32(a-b) > 0
+}
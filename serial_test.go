@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSOAServer starts a nameserver answering the example.com SOA query with the current serial.
+func startSOAServer(t *testing.T, serial *atomic.Uint32) string {
+	return startDNSServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Authoritative = true
+		if req.Question[0].Name == "example.com." && req.Question[0].Qtype == dns.TypeSOA {
+			rr, _ := dns.NewRR("example.com. 3600 IN SOA ns1.reg.ru. hostmaster.reg.ru. " +
+				strconv.FormatUint(uint64(serial.Load()), 10) + " 3600 600 86400 300")
+			m.Answer = append(m.Answer, rr)
+		} else {
+			m.Rcode = dns.RcodeRefused
+		}
+		_ = w.WriteMsg(m)
+	})
+}
+
+func TestSerialAfter(t *testing.T) {
+	assert.True(t, serialAfter(2, 1))
+	assert.False(t, serialAfter(1, 2))
+	assert.False(t, serialAfter(5, 5))
+	assert.True(t, serialAfter(1, 4294967295), "serials wrap around")
+}
+
+func TestClient_HasZoneChanged(t *testing.T) {
+	var primary, secondary atomic.Uint32
+	primary.Store(2025010102)
+	secondary.Store(2025010101)
+
+	server, calls := setupRoutedTestServer(t, nil)
+	defer server.Close()
+
+	client := setupTestClient(t, server,
+		WithSerialNameservers(startSOAServer(t, &secondary), startSOAServer(t, &primary)))
+
+	serial, err := client.ZoneSerial(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2025010102), serial, "the most recent serial wins")
+
+	changed, current, err := client.HasZoneChanged(context.Background(), "example.com", serial)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, serial, current)
+
+	primary.Store(2025010103)
+	changed, current, err = client.HasZoneChanged(context.Background(), "example.com", serial)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint32(2025010103), current)
+
+	_, err = client.ZoneSerial(context.Background(), "example.org")
+	assert.Error(t, err)
+
+	assert.Empty(t, *calls, "serials are read from DNS, not the API")
+}
+
+func TestClient_RecordsCacheRevalidation(t *testing.T) {
+	var serial atomic.Uint32
+	serial.Store(1)
+
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{Domains: []DomainWithResourceRecords{{
+				DName:  "example.com",
+				RRList: []ResourceRecord{{Subname: "www", Rectype: "A", Content: "192.0.2.1"}},
+			}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server,
+		WithRecordsCache(10*time.Millisecond),
+		WithRecordsCacheRevalidation(),
+		WithSerialNameservers(startSOAServer(t, &serial)))
+
+	ctx := context.Background()
+	params := ListDNSRecordsParams{ZoneName: "example.com"}
+
+	_, err := client.ListRecords(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countCalls(*calls, "zone/get_resource_records"))
+
+	// Expired, but the serial is unchanged: the entry is renewed
+	time.Sleep(20 * time.Millisecond)
+	records, err := client.ListRecords(ctx, params)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 1, countCalls(*calls, "zone/get_resource_records"))
+
+	// The zone changed: the records are downloaded again
+	serial.Store(2)
+	time.Sleep(20 * time.Millisecond)
+	_, err = client.ListRecords(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countCalls(*calls, "zone/get_resource_records"))
+}
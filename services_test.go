@@ -0,0 +1,298 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ServiceGroups(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	viaService, err := client.Records.List(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	viaWrapper, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, viaService, viaWrapper)
+	assert.Len(t, *calls, 2)
+}
+
+func TestDomainsService_List(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"service/get_list": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"services": []map[string]interface{}{
+					{"dname": "example.com", "servtype": "domain", "service_id": 12345, "state": "A", "creation_date": "2020-03-01", "expiration_date": "2026-03-01"},
+					{"dname": "example.com", "servtype": "srv_hosting_ispmgr", "service_id": "777", "state": "A"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	domains, err := client.Domains.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, domains, 1)
+	assert.Equal(t, "example.com", domains[0].Name)
+	assert.Equal(t, "12345", domains[0].ServiceID)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), domains[0].ExpirationDate)
+	assert.Equal(t, "domain", (*calls)[0].Input["servtype"])
+}
+
+func TestBillingService_ListUnpaidBills(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"bill/get_not_payed": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{
+					{"bill_id": 1001, "bill_date": "2025-01-10", "currency": "RUR", "pay_type": "prepay", "status": "new", "total": "990.00"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	bills, err := client.Billing.ListUnpaidBills(context.Background())
+	require.NoError(t, err)
+	require.Len(t, bills, 1)
+	assert.Equal(t, "1001", bills[0].ID)
+	assert.Equal(t, 990.0, bills[0].Total)
+}
+
+func TestBillingService_PayFromBalance(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"bill/change_pay_type": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{
+					{"bill_id": 1001, "result": "success", "status": "payed", "payment": "990.00", "currency": "RUR"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	bill, err := client.PayFromBalance(context.Background(), "1001")
+	require.NoError(t, err)
+	assert.Equal(t, Bill{ID: "1001", Currency: "RUR", PayType: "prepay", Status: "payed", Total: 990}, bill)
+
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "prepay", (*calls)[0].Input["pay_type"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"bill_id": "1001"}}, (*calls)[0].Input["bills"])
+}
+
+func TestBillingService_PayFromBalance_NoMoney(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"bill/change_pay_type": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"bills": []map[string]interface{}{
+					{"bill_id": 1001, "result": "error", "error_code": "NO_MONEY", "error_text": "Not enough money"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.Billing.PayFromBalance(context.Background(), "1001")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "NO_MONEY", apiErr.Code)
+	assert.Equal(t, "Not enough money", apiErr.Message)
+}
+
+func TestDomainsService_RegisterRenewGet(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/create": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": 2002, "service_id": 3003, "payment": "790.00", "currency": "RUR"},
+		},
+		"service/renew": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"bill_id": "2003", "service_id": "3003", "payment": 890},
+		},
+		"service/get_info": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"services": []map[string]interface{}{
+					{"dname": "example.com", "service_id": 3003, "servtype": "domain", "state": "A", "expiration_date": "2026-05-01"},
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	order, err := client.RegisterDomain(context.Background(), DomainOrder{
+		Name:        "example.com",
+		Nameservers: []string{"ns1.reg.ru", "ns2.reg.ru"},
+		Contacts:    map[string]string{"person": "Ivan Ivanov"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Order{Domain: "example.com", ServiceID: "3003", BillID: "2002", Payment: 790, Currency: "RUR"}, order)
+
+	order, err = client.RenewDomain(context.Background(), "example.com", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2003", order.BillID)
+	assert.Equal(t, 890.0, order.Payment)
+
+	domain, err := client.GetDomain(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "3003", domain.ServiceID)
+	assert.Equal(t, "A", domain.State)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), domain.ExpirationDate)
+
+	require.Len(t, *calls, 3)
+	create := (*calls)[0].Input
+	assert.Equal(t, "example.com", create["domain_name"])
+	assert.Equal(t, float64(1), create["period"])
+	assert.Equal(t, float64(1), create["ok_if_no_money"])
+	assert.Equal(t, map[string]interface{}{"ns0": "ns1.reg.ru", "ns1": "ns2.reg.ru"}, create["nss"])
+	assert.Equal(t, map[string]interface{}{"person": "Ivan Ivanov"}, create["contacts"])
+	assert.Equal(t, float64(2), (*calls)[1].Input["period"])
+	assert.Equal(t, "example.com", (*calls)[1].Input["dname"])
+}
+
+func TestDomainsService_IDNNames(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/create": map[string]interface{}{"result": "success", "answer": map[string]interface{}{"bill_id": 2002}},
+		"service/renew": map[string]interface{}{"result": "success", "answer": map[string]interface{}{"bill_id": 2003}},
+		"service/get_info": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{
+				"services": []map[string]interface{}{{"dname": "xn--e1afmkfd.xn--p1ai", "service_id": 3003}},
+			},
+		},
+		"domain/get_nss": DomainGetNSSResponse{
+			Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+				DName: "xn--e1afmkfd.xn--p1ai", Result: "success", NSS: []NameserverInfo{{NS: "ns1.reg.ru"}},
+			}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := client.RegisterDomain(ctx, DomainOrder{Name: "пример.рф"})
+	require.NoError(t, err)
+	_, err = client.RenewDomain(ctx, "пример.рф", 1)
+	require.NoError(t, err)
+	_, err = client.GetDomain(ctx, "пример.рф")
+	require.NoError(t, err)
+	nameservers, err := client.GetNameservers(ctx, "пример.рф")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns1.reg.ru"}, nameservers)
+
+	require.Len(t, *calls, 4)
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", (*calls)[0].Input["domain_name"])
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", (*calls)[1].Input["dname"])
+	services := (*calls)[2].Input["services"].([]interface{})
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", services[0].(map[string]interface{})["dname"])
+	domains := (*calls)[3].Input["domains"].([]interface{})
+	assert.Equal(t, "xn--e1afmkfd.xn--p1ai", domains[0].(map[string]interface{})["dname"])
+}
+
+func TestDomainsService_GetNameservers_Error(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"domain/get_nss": DomainGetNSSResponse{
+			Answer: DomainGetNSSAnswer{Domains: []DomainNSS{{
+				DName: "example.com", Result: "error", ErrorCode: "DOMAIN_NOT_FOUND", ErrorText: "Domain not found",
+			}}},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	_, err := client.GetNameservers(context.Background(), "example.com")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "DOMAIN_NOT_FOUND", apiErr.Code)
+	assert.Equal(t, "Domain not found", apiErr.Message)
+}
+
+func TestAccountService_GetBalance(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"user/get_balance": map[string]interface{}{
+			"result": "success",
+			"answer": map[string]interface{}{"prepay": "1500.50", "blocked": 0, "credit": "0.00", "currency": "RUR"},
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	balance, err := client.Account.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Balance{Prepay: 1500.5, Currency: "RUR"}, balance)
+}
+
+func TestAccountService_CheckAccess(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	require.NoError(t, client.CheckAccess(context.Background()))
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "user/nop", (*calls)[0].Path)
+}
+
+func TestAccountService_CheckAccess_IPRestricted(t *testing.T) {
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"user/nop": map[string]interface{}{
+			"result":     "error",
+			"error_code": "ACCESS_DENIED_FROM_IP",
+			"error_text": "Access to API from this IP denied",
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	err := client.CheckAccess(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrIPRestricted))
+	assert.Contains(t, err.Error(), "allowlist")
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "ACCESS_DENIED_FROM_IP", apiErr.Code)
+	assert.Equal(t, "Access to API from this IP denied", apiErr.Message)
+}
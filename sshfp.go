@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SSHFP key algorithms (RFC 4255, RFC 6594, RFC 7479, RFC 8709).
+const (
+	SSHFPAlgorithmRSA     = 1
+	SSHFPAlgorithmDSA     = 2
+	SSHFPAlgorithmECDSA   = 3
+	SSHFPAlgorithmEd25519 = 4
+	SSHFPAlgorithmEd448   = 6
+)
+
+// SSHFP fingerprint types.
+const (
+	SSHFPTypeSHA1   = 1
+	SSHFPTypeSHA256 = 2
+)
+
+// sshfpAlgorithms maps OpenSSH public key types to SSHFP algorithms.
+var sshfpAlgorithms = map[string]int{
+	"ssh-rsa":             SSHFPAlgorithmRSA,
+	"ssh-dss":             SSHFPAlgorithmDSA,
+	"ecdsa-sha2-nistp256": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp384": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp521": SSHFPAlgorithmECDSA,
+	"ssh-ed25519":         SSHFPAlgorithmEd25519,
+	"ssh-ed448":           SSHFPAlgorithmEd448,
+}
+
+// SSHFPFromPublicKey returns the SSHFP record with a SHA-256 fingerprint for an
+// OpenSSH public key line, such as the content of /etc/ssh/ssh_host_ed25519_key.pub.
+// Provisioning pipelines can publish the records of every host key so clients
+// can verify them with VerifyHostKeyDNS.
+func SSHFPFromPublicKey(name, publicKey string) (DNSRecord, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return DNSRecord{}, fmt.Errorf("invalid SSH public key: expected \"<type> <base64 key>\"")
+	}
+	algorithm, ok := sshfpAlgorithms[fields[0]]
+	if !ok {
+		return DNSRecord{}, fmt.Errorf("unsupported SSH key type %q", fields[0])
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return DNSRecord{}, fmt.Errorf("invalid SSH public key: %w", err)
+	}
+
+	sum := sha256.Sum256(blob)
+	return DNSRecord{
+		Name:    name,
+		Type:    RecordTypeSSHFP,
+		Content: SSHFPContent(algorithm, SSHFPTypeSHA256, hex.EncodeToString(sum[:])),
+	}, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSSHFPFingerprint = "66402c9468c58941dd19ffd650bf2b42f9226f83d3bd06ad515d0e5104a77020"
+
+func TestSSHFPFromPublicKey(t *testing.T) {
+	record, err := SSHFPFromPublicKey("host",
+		"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f root@host")
+	require.NoError(t, err)
+	assert.Equal(t, DNSRecord{
+		Name:    "host",
+		Type:    RecordTypeSSHFP,
+		Content: "4 2 " + testSSHFPFingerprint,
+	}, record)
+
+	_, err = SSHFPFromPublicKey("host", "ssh-unknown AAAA")
+	assert.ErrorContains(t, err, "unsupported SSH key type")
+	_, err = SSHFPFromPublicKey("host", "ssh-rsa not-base64!")
+	assert.Error(t, err)
+	_, err = SSHFPFromPublicKey("host", "")
+	assert.Error(t, err)
+}
+
+func TestCanonicalize_SSHFP(t *testing.T) {
+	a := DNSRecord{Name: "host", Type: RecordTypeSSHFP, Content: "4  2 " + testSSHFPFingerprint}
+	b := DNSRecord{Name: "HOST", Type: "sshfp", Content: "4 2 66402C9468C58941DD19FFD650BF2B42F9226F83D3BD06AD515D0E5104A77020"}
+	assert.True(t, a.Equal(b))
+}
+
+func TestClient_SSHFPRecords(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "host", Type: RecordTypeSSHFP, Content: SSHFPContent(SSHFPAlgorithmEd25519, SSHFPTypeSHA256, testSSHFPFingerprint),
+		TTL: 3600,
+	})
+	require.NoError(t, err)
+
+	err = client.DeleteRR(ctx, "example.com", DNSRecord{
+		Name: "host", Type: RecordTypeSSHFP, Content: "4 2 " + testSSHFPFingerprint,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/add_sshfp", (*calls)[0].Path)
+	assert.Equal(t, "host", (*calls)[0].Input["subdomain"])
+	assert.Equal(t, float64(4), (*calls)[0].Input["algorithm"])
+	assert.Equal(t, float64(2), (*calls)[0].Input["fp_type"])
+	assert.Equal(t, testSSHFPFingerprint, (*calls)[0].Input["fingerprint"])
+	assert.Equal(t, float64(3600), (*calls)[0].Input["ttl"])
+
+	assert.Equal(t, "zone/remove_record", (*calls)[1].Path)
+	assert.Equal(t, "SSHFP", (*calls)[1].Input["record_type"])
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "host", Type: RecordTypeSSHFP, Content: "4 2 not-hex",
+	})
+	assert.ErrorContains(t, err, "invalid SSHFP content")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"sync"
+	"time"
+)
+
+// quotaWarningThreshold is the share of the per-minute limit at which a quota warning is issued.
+const quotaWarningThreshold = 0.8
+
+// EndpointStats contains call statistics of one API method.
+type EndpointStats struct {
+	// Calls is the number of HTTP requests made, including retries.
+	Calls int64
+	// Errors is the number of requests that failed.
+	Errors int64
+	// LastCall is the time of the most recent request.
+	LastCall time.Time
+}
+
+// ErrorRate returns the share of failed requests in [0, 1].
+func (s EndpointStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// Stats is a snapshot of the client's API call statistics.
+type Stats struct {
+	// Calls and Errors are totals over all endpoints.
+	Calls  int64
+	Errors int64
+	// CallsLastMinute is the number of requests made in the last minute.
+	CallsLastMinute int
+	// Endpoints maps API method paths (such as "zone/get_resource_records") to their statistics.
+	Endpoints map[string]EndpointStats
+}
+
+// QuotaWarning is passed to the quota warning callback.
+type QuotaWarning struct {
+	// CallsLastMinute is the number of requests made in the last minute.
+	CallsLastMinute int
+	// Limit is the configured per-minute limit.
+	Limit int
+}
+
+// WithQuotaWarning calls fn when the number of requests made in the last
+// minute reaches 80% of limit. The callback is invoked once per crossing of
+// the threshold and must not block.
+func WithQuotaWarning(limit int, fn func(QuotaWarning)) ClientOption {
+	return func(c *Client) {
+		c.stats.quotaLimit = limit
+		c.stats.onQuota = fn
+	}
+}
+
+// callStats collects API call statistics.
+type callStats struct {
+	mu        sync.Mutex
+	endpoints map[string]EndpointStats
+	recent    []time.Time
+
+	quotaLimit int
+	onQuota    func(QuotaWarning)
+	warned     bool
+}
+
+// newCallStats returns empty statistics.
+func newCallStats() *callStats {
+	return &callStats{endpoints: make(map[string]EndpointStats)}
+}
+
+// record adds the outcome of a request to the statistics.
+func (s *callStats) record(path string, err error, now time.Time) {
+	s.mu.Lock()
+
+	endpoint := s.endpoints[path]
+	endpoint.Calls++
+	if err != nil {
+		endpoint.Errors++
+	}
+	endpoint.LastCall = now
+	s.endpoints[path] = endpoint
+
+	s.recent = append(s.recent, now)
+	s.trim(now)
+
+	var warning *QuotaWarning
+	if s.quotaLimit > 0 && s.onQuota != nil {
+		reached := float64(len(s.recent)) >= quotaWarningThreshold*float64(s.quotaLimit)
+		if reached && !s.warned {
+			warning = &QuotaWarning{CallsLastMinute: len(s.recent), Limit: s.quotaLimit}
+		}
+		s.warned = reached
+	}
+
+	s.mu.Unlock()
+
+	if warning != nil {
+		s.onQuota(*warning)
+	}
+}
+
+// trim drops request times older than a minute. The caller must hold the lock.
+func (s *callStats) trim(now time.Time) {
+	cutoff := now.Add(-time.Minute)
+	i := 0
+	for i < len(s.recent) && !s.recent[i].After(cutoff) {
+		i++
+	}
+	s.recent = s.recent[i:]
+}
+
+// snapshot returns a copy of the statistics.
+func (s *callStats) snapshot(now time.Time) Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trim(now)
+	stats := Stats{
+		CallsLastMinute: len(s.recent),
+		Endpoints:       make(map[string]EndpointStats, len(s.endpoints)),
+	}
+	for path, endpoint := range s.endpoints {
+		stats.Endpoints[path] = endpoint
+		stats.Calls += endpoint.Calls
+		stats.Errors += endpoint.Errors
+	}
+	return stats
+}
+
+// Stats returns per-endpoint call counts and error rates since the client was created.
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot(time.Now())
+}
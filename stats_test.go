@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Stats(t *testing.T) {
+	server, _ := setupFlakyServer(t, 1, http.StatusInternalServerError)
+
+	client := NewClient("username", "password", WithBaseURL(server.URL))
+
+	_, err := client.apiRequest(context.Background(), "zone/get_resource_records", &ZoneGetResourceRecordsRequest{})
+	require.Error(t, err)
+	_, err = client.apiRequest(context.Background(), "zone/get_resource_records", &ZoneGetResourceRecordsRequest{})
+	require.NoError(t, err)
+	_, err = client.apiRequest(context.Background(), "zone/add_alias", &AddAliasRequest{})
+	require.NoError(t, err)
+
+	stats := client.Stats()
+	assert.EqualValues(t, 3, stats.Calls)
+	assert.EqualValues(t, 1, stats.Errors)
+	assert.Equal(t, 3, stats.CallsLastMinute)
+
+	records := stats.Endpoints["zone/get_resource_records"]
+	assert.EqualValues(t, 2, records.Calls)
+	assert.InDelta(t, 0.5, records.ErrorRate(), 0.001)
+	assert.False(t, records.LastCall.IsZero())
+}
+
+func TestCallStats_QuotaWarning(t *testing.T) {
+	var warnings []QuotaWarning
+	stats := newCallStats()
+	stats.quotaLimit = 10
+	stats.onQuota = func(w QuotaWarning) { warnings = append(warnings, w) }
+
+	now := time.Now()
+	for i := 0; i < 9; i++ {
+		stats.record("service/get_list", nil, now)
+	}
+	require.Len(t, warnings, 1, "warning is issued once when the threshold is crossed")
+	assert.Equal(t, QuotaWarning{CallsLastMinute: 8, Limit: 10}, warnings[0])
+
+	// After the window has passed the warning can be issued again
+	later := now.Add(2 * time.Minute)
+	stats.record("service/get_list", errors.New("failed"), later)
+	for i := 0; i < 7; i++ {
+		stats.record("service/get_list", nil, later)
+	}
+	assert.Len(t, warnings, 2)
+
+	snapshot := stats.snapshot(later)
+	assert.Equal(t, 8, snapshot.CallsLastMinute)
+	assert.EqualValues(t, 17, snapshot.Calls)
+	assert.EqualValues(t, 1, snapshot.Errors)
+}
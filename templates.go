@@ -0,0 +1,333 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ZoneTemplate is a reusable set of records, such as the records required by a
+// hosted mail service. Record names and contents may reference variables as
+// ${name}. The variables "zone" (the zone name) and "zone_dashed" (the zone name
+// with dots replaced by dashes) are always available.
+type ZoneTemplate struct {
+	Name        string
+	Description string
+	// Variables lists the variables that must be supplied when applying the template.
+	Variables []string
+	Records   []DNSRecord
+}
+
+// templateVariable matches a ${name} reference.
+var templateVariable = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// Built-in templates for common hosted services.
+var (
+	// TemplateGoogleWorkspace sets up Google Workspace mail. The verification
+	// variable is the google-site-verification token and dkim is the TXT value
+	// generated in the Admin console.
+	TemplateGoogleWorkspace = ZoneTemplate{
+		Name:        "google-workspace",
+		Description: "Google Workspace mail (MX, SPF, DKIM and site verification)",
+		Variables:   []string{"verification", "dkim"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "1 smtp.google.com"},
+			{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 include:_spf.google.com ~all"},
+			{Name: "google._domainkey", Type: RecordTypeTXT, Content: "${dkim}"},
+			{Name: "@", Type: RecordTypeTXT, Content: "google-site-verification=${verification}"},
+		},
+	}
+
+	// TemplateMicrosoft365 sets up Microsoft 365 mail. The verification variable
+	// is the MS= token and tenant is the onmicrosoft.com tenant name.
+	TemplateMicrosoft365 = ZoneTemplate{
+		Name:        "microsoft-365",
+		Description: "Microsoft 365 mail (MX, SPF, Autodiscover, DKIM and domain verification)",
+		Variables:   []string{"verification", "tenant"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "0 ${zone_dashed}.mail.protection.outlook.com"},
+			{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 include:spf.protection.outlook.com -all"},
+			{Name: "autodiscover", Type: RecordTypeCNAME, Content: "autodiscover.outlook.com"},
+			{Name: "selector1._domainkey", Type: RecordTypeCNAME, Content: "selector1-${zone_dashed}._domainkey.${tenant}.onmicrosoft.com"},
+			{Name: "selector2._domainkey", Type: RecordTypeCNAME, Content: "selector2-${zone_dashed}._domainkey.${tenant}.onmicrosoft.com"},
+			{Name: "@", Type: RecordTypeTXT, Content: "MS=${verification}"},
+		},
+	}
+
+	// TemplateGitHubPages points the apex and www at GitHub Pages. The user
+	// variable is the GitHub user or organization name.
+	TemplateGitHubPages = ZoneTemplate{
+		Name:        "github-pages",
+		Description: "GitHub Pages site on the apex and www",
+		Variables:   []string{"user"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeA, Content: "185.199.108.153"},
+			{Name: "@", Type: RecordTypeA, Content: "185.199.109.153"},
+			{Name: "@", Type: RecordTypeA, Content: "185.199.110.153"},
+			{Name: "@", Type: RecordTypeA, Content: "185.199.111.153"},
+			{Name: "@", Type: RecordTypeAAAA, Content: "2606:50c0:8000::153"},
+			{Name: "@", Type: RecordTypeAAAA, Content: "2606:50c0:8001::153"},
+			{Name: "@", Type: RecordTypeAAAA, Content: "2606:50c0:8002::153"},
+			{Name: "@", Type: RecordTypeAAAA, Content: "2606:50c0:8003::153"},
+			{Name: "www", Type: RecordTypeCNAME, Content: "${user}.github.io"},
+		},
+	}
+
+	// TemplateWebApp points the apex at a web application server and www at
+	// the apex. The ipv4 variable is the server address.
+	TemplateWebApp = ZoneTemplate{
+		Name:        "web-app",
+		Description: "Web application on the apex with www as an alias",
+		Variables:   []string{"ipv4"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeA, Content: "${ipv4}"},
+			{Name: "www", Type: RecordTypeCNAME, Content: "${zone}"},
+		},
+	}
+
+	// TemplateYandex360 sets up Yandex 360 mail. The verification variable is
+	// the yandex-verification token and dkim is the TXT value from the admin panel.
+	TemplateYandex360 = ZoneTemplate{
+		Name:        "yandex-360",
+		Description: "Yandex 360 mail (MX, SPF, DKIM and domain verification)",
+		Variables:   []string{"verification", "dkim"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "10 mx.yandex.net"},
+			{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 redirect=_spf.yandex.net"},
+			{Name: "mail._domainkey", Type: RecordTypeTXT, Content: "${dkim}"},
+			{Name: "@", Type: RecordTypeTXT, Content: "yandex-verification: ${verification}"},
+		},
+	}
+
+	// TemplateZohoMail sets up Zoho Mail. The verification variable is the
+	// zb... code, dkim_selector and dkim are the selector and TXT value of the DKIM key.
+	TemplateZohoMail = ZoneTemplate{
+		Name:        "zoho-mail",
+		Description: "Zoho Mail (MX, SPF, DKIM and domain verification)",
+		Variables:   []string{"verification", "dkim_selector", "dkim"},
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "10 mx.zoho.com"},
+			{Name: "@", Type: RecordTypeMX, Content: "20 mx2.zoho.com"},
+			{Name: "@", Type: RecordTypeMX, Content: "50 mx3.zoho.com"},
+			{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 include:zoho.com ~all"},
+			{Name: "${dkim_selector}._domainkey", Type: RecordTypeTXT, Content: "${dkim}"},
+			{Name: "@", Type: RecordTypeTXT, Content: "zoho-verification=${verification}.zmverify.zoho.com"},
+		},
+	}
+
+	// TemplateFastmail sets up Fastmail mail with DKIM delegated to Fastmail.
+	TemplateFastmail = ZoneTemplate{
+		Name:        "fastmail",
+		Description: "Fastmail mail (MX, SPF and DKIM)",
+		Records: []DNSRecord{
+			{Name: "@", Type: RecordTypeMX, Content: "10 in1-smtp.messagingengine.com"},
+			{Name: "@", Type: RecordTypeMX, Content: "20 in2-smtp.messagingengine.com"},
+			{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 include:spf.messagingengine.com ?all"},
+			{Name: "fm1._domainkey", Type: RecordTypeCNAME, Content: "fm1.${zone}.dkim.fmhosted.com"},
+			{Name: "fm2._domainkey", Type: RecordTypeCNAME, Content: "fm2.${zone}.dkim.fmhosted.com"},
+			{Name: "fm3._domainkey", Type: RecordTypeCNAME, Content: "fm3.${zone}.dkim.fmhosted.com"},
+		},
+	}
+)
+
+// builtinTemplates holds the built-in templates by name.
+var builtinTemplates = map[string]ZoneTemplate{
+	TemplateGoogleWorkspace.Name: TemplateGoogleWorkspace,
+	TemplateMicrosoft365.Name:    TemplateMicrosoft365,
+	TemplateGitHubPages.Name:     TemplateGitHubPages,
+	TemplateYandex360.Name:       TemplateYandex360,
+	TemplateZohoMail.Name:        TemplateZohoMail,
+	TemplateFastmail.Name:        TemplateFastmail,
+	TemplateWebApp.Name:          TemplateWebApp,
+}
+
+// Templates returns the built-in templates sorted by name.
+func Templates() []ZoneTemplate {
+	templates := make([]ZoneTemplate, 0, len(builtinTemplates))
+	for _, t := range builtinTemplates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// LookupTemplate returns the built-in template with the name.
+func LookupTemplate(name string) (ZoneTemplate, bool) {
+	t, ok := builtinTemplates[name]
+	return t, ok
+}
+
+// Render returns the template records for the zone with all variables substituted.
+func (t ZoneTemplate) Render(zone string, vars map[string]string) ([]DNSRecord, error) {
+	zone = canonicalHost(zone)
+	values := map[string]string{
+		"zone":        zone,
+		"zone_dashed": strings.ReplaceAll(zone, ".", "-"),
+	}
+	for _, name := range t.Variables {
+		value, ok := vars[name]
+		if !ok || value == "" {
+			return nil, &TemplateVariableError{Template: t.Name, Variable: name}
+		}
+		values[name] = value
+	}
+
+	var missing string
+	expand := func(s string) string {
+		return templateVariable.ReplaceAllStringFunc(s, func(ref string) string {
+			name := ref[2 : len(ref)-1]
+			value, ok := values[name]
+			if !ok && missing == "" {
+				missing = name
+			}
+			return value
+		})
+	}
+
+	records := make([]DNSRecord, 0, len(t.Records))
+	for _, rr := range t.Records {
+		rr.Name = expand(rr.Name)
+		rr.Content = expand(rr.Content)
+		records = append(records, rr)
+	}
+	if missing != "" {
+		return nil, &TemplateVariableError{Template: t.Name, Variable: missing}
+	}
+
+	return records, nil
+}
+
+// ApplyTemplate renders the template for the zone and creates the records that
+// are not present yet, so applying a template again is harmless. Existing
+// records are never removed; records that conflict with existing ones (such as
+// a CNAME next to other records) fail individually in the results.
+// WithTTL sets the TTL of created records and WithDryRun previews the changes.
+func (c *Client) ApplyTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string, opts ...BulkOption) (BatchResults, error) {
+	o := newBulkOptions(opts)
+
+	records, err := tmpl.Render(zone, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[recordKey]bool, len(current))
+	for _, rr := range current {
+		present[keyOf(rr)] = true
+	}
+
+	var results BatchResults
+	for _, rr := range records {
+		if present[keyOf(rr)] {
+			continue
+		}
+		present[keyOf(rr)] = true
+
+		params := paramsFromRecord(rr)
+		if o.ttl > 0 {
+			params.TTL = o.ttl
+		}
+		results = append(results, c.batchCreate(ctx, zone, params, o.dryRun))
+	}
+
+	return results, nil
+}
+
+// PlanTemplate renders the template for the zone and plans the changes that
+// make the zone match it. Only the record sets of the template records are
+// compared, so other records are never touched; within a TXT record set only
+// values with the same leading tag (such as "v=spf1" or
+// "google-site-verification") are replaced.
+func (c *Client) PlanTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string) (Plan, error) {
+	records, err := tmpl.Render(zone, vars)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	current, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return Plan{}, err
+	}
+
+	type rrset struct {
+		name  string
+		rtype RecordType
+	}
+	managed := make(map[rrset]bool, len(records))
+	tags := make(map[rrset]map[string]bool)
+	for _, rr := range records {
+		key := keyOf(rr)
+		set := rrset{name: key.name, rtype: key.recordType}
+		managed[set] = true
+		if isTXTType(key.recordType) {
+			if tags[set] == nil {
+				tags[set] = make(map[string]bool)
+			}
+			tags[set][txtTag(key.content)] = true
+		}
+	}
+
+	var existing []DNSRecord
+	for _, rr := range current {
+		key := keyOf(rr)
+		set := rrset{name: key.name, rtype: key.recordType}
+		if !managed[set] {
+			continue
+		}
+		if isTXTType(key.recordType) && !tags[set][txtTag(key.content)] {
+			continue
+		}
+		existing = append(existing, rr)
+	}
+
+	return PlanChanges(existing, records), nil
+}
+
+// SyncTemplate applies the plan of PlanTemplate, so unlike ApplyTemplate it
+// also updates records whose content differs from the template and removes
+// superfluous records from the template record sets. Applying a template again
+// makes no changes. WithDryRun previews the changes.
+func (c *Client) SyncTemplate(ctx context.Context, zone string, tmpl ZoneTemplate, vars map[string]string, opts ...BulkOption) (BatchResults, error) {
+	plan, err := c.PlanTemplate(ctx, zone, tmpl, vars)
+	if err != nil {
+		return nil, err
+	}
+	return c.ApplyPlan(ctx, zone, plan, nil, opts...)
+}
+
+// txtTag returns the lowercase leading tag of TXT content that identifies what
+// the value is for: the version tag ("v=spf1") or the name of a key=value or
+// key: value token ("google-site-verification").
+func txtTag(content string) string {
+	tag := strings.ToLower(strings.TrimSpace(content))
+	if i := strings.IndexAny(tag, " ;"); i >= 0 {
+		tag = tag[:i]
+	}
+	if strings.HasPrefix(tag, "v=") {
+		return tag
+	}
+	if i := strings.IndexAny(tag, "=:"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
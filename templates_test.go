@@ -0,0 +1,176 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneTemplate_Render(t *testing.T) {
+	records, err := TemplateMicrosoft365.Render("Example.COM.", map[string]string{"verification": "ms123", "tenant": "contoso"})
+	require.NoError(t, err)
+	assert.Contains(t, records, DNSRecord{Name: "@", Type: RecordTypeMX, Content: "0 example-com.mail.protection.outlook.com"})
+	assert.Contains(t, records, DNSRecord{Name: "selector1._domainkey", Type: RecordTypeCNAME, Content: "selector1-example-com._domainkey.contoso.onmicrosoft.com"})
+	assert.Contains(t, records, DNSRecord{Name: "@", Type: RecordTypeTXT, Content: "MS=ms123"})
+
+	records, err = TemplateFastmail.Render("example.com", nil)
+	require.NoError(t, err)
+	assert.Contains(t, records, DNSRecord{Name: "fm1._domainkey", Type: RecordTypeCNAME, Content: "fm1.example.com.dkim.fmhosted.com"})
+}
+
+func TestZoneTemplate_RenderMissingVariable(t *testing.T) {
+	_, err := TemplateGoogleWorkspace.Render("example.com", map[string]string{"verification": "abc"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMissingTemplateVariable))
+
+	var varErr *TemplateVariableError
+	require.True(t, errors.As(err, &varErr))
+	assert.Equal(t, "dkim", varErr.Variable)
+
+	undeclared := ZoneTemplate{Name: "custom", Records: []DNSRecord{{Name: "@", Type: RecordTypeTXT, Content: "${token}"}}}
+	_, err = undeclared.Render("example.com", nil)
+	assert.ErrorIs(t, err, ErrMissingTemplateVariable)
+}
+
+func TestTemplates(t *testing.T) {
+	templates := Templates()
+	require.NotEmpty(t, templates)
+	for i, tmpl := range templates {
+		if i > 0 {
+			assert.Less(t, templates[i-1].Name, tmpl.Name)
+		}
+		vars := make(map[string]string)
+		for _, name := range tmpl.Variables {
+			vars[name] = "value"
+		}
+		records, err := tmpl.Render("example.com", vars)
+		require.NoError(t, err, tmpl.Name)
+		for _, rr := range records {
+			assert.True(t, rr.Type.Supported(), tmpl.Name)
+		}
+	}
+
+	tmpl, ok := LookupTemplate("github-pages")
+	require.True(t, ok)
+	assert.Equal(t, TemplateGitHubPages.Description, tmpl.Description)
+	_, ok = LookupTemplate("unknown")
+	assert.False(t, ok)
+}
+
+func TestClient_ApplyTemplate(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	tmpl := ZoneTemplate{
+		Name:      "custom",
+		Variables: []string{"ip"},
+		Records: []DNSRecord{
+			{Name: "www", Type: RecordTypeA, Content: "192.0.2.2"},
+			{Name: "app", Type: RecordTypeA, Content: "${ip}"},
+		},
+	}
+	results, err := client.ApplyTemplate(context.Background(), "example.com", tmpl, map[string]string{"ip": "192.0.2.7"}, WithTTL(300))
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+	require.Len(t, results, 1, "existing records are skipped")
+	assert.Equal(t, "app", results[0].Item.Name)
+	assert.Equal(t, 300, results[0].Item.TTL)
+
+	require.Len(t, *calls, 2)
+	assert.Equal(t, "zone/add_alias", (*calls)[1].Path)
+	assert.Equal(t, "192.0.2.7", (*calls)[1].Input["ipaddr"])
+}
+
+func TestTXTTag(t *testing.T) {
+	assert.Equal(t, "v=spf1", txtTag("v=spf1 include:_spf.google.com ~all"))
+	assert.Equal(t, "v=dkim1", txtTag("v=DKIM1; k=rsa; p=abc"))
+	assert.Equal(t, "google-site-verification", txtTag("google-site-verification=abc"))
+	assert.Equal(t, "yandex-verification", txtTag("yandex-verification: 123"))
+	assert.Equal(t, "hello", txtTag("hello world"))
+}
+
+func TestClient_PlanTemplate(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = append(records.Answer.Domains[0].RRList,
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "v=spf1 mx ~all"},
+		ResourceRecord{Subname: "@", Rectype: "TXT", Content: "google-site-verification=old"},
+		ResourceRecord{Subname: "google._domainkey", Rectype: "TXT", Content: "v=DKIM1; k=rsa; p=b2xk"},
+	)
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	plan, err := client.PlanTemplate(context.Background(), "example.com", TemplateGoogleWorkspace, map[string]string{
+		"verification": "new",
+		"dkim":         "v=DKIM1; k=rsa; p=bmV3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, plan.Count(ChangeUpdate), plan.String())
+	assert.Equal(t, 0, plan.Count(ChangeCreate), plan.String())
+	assert.Equal(t, 0, plan.Count(ChangeDelete), plan.String())
+
+	updated := make(map[string]string)
+	for _, change := range plan.Changes {
+		updated[change.Before.Content] = change.After.Content
+	}
+	assert.Equal(t, map[string]string{
+		"10 mail.example.com":          "1 smtp.google.com",
+		"v=spf1 mx ~all":               "v=spf1 include:_spf.google.com ~all",
+		"google-site-verification=old": "google-site-verification=new",
+		"v=DKIM1; k=rsa; p=b2xk":       "v=DKIM1; k=rsa; p=bmV3",
+	}, updated)
+}
+
+func TestClient_SyncTemplate(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].RRList = []ResourceRecord{
+		{Subname: "@", Rectype: "A", Content: "192.0.2.1"},
+		{Subname: "@", Rectype: "A", Content: "192.0.2.9"},
+		{Subname: "@", Rectype: "MX", Content: "10 mail.example.com"},
+	}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	results, err := client.SyncTemplate(context.Background(), "example.com", TemplateWebApp, map[string]string{"ipv4": "192.0.2.1"})
+	require.NoError(t, err)
+	require.NoError(t, results.Err())
+
+	var paths []string
+	for _, call := range *calls {
+		paths = append(paths, call.Path)
+	}
+	// The apex keeps 192.0.2.1, 192.0.2.9 is removed, www is created and MX is kept
+	assert.Equal(t, []string{"zone/get_resource_records", "zone/remove_record", "zone/add_cname"}, paths)
+	assert.Equal(t, "192.0.2.9", (*calls)[1].Input["content"])
+	assert.Equal(t, "www", (*calls)[2].Input["subdomain"])
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ttlUnits maps BIND-style TTL unit suffixes to their length in seconds.
+var ttlUnits = map[byte]int{
+	's': 1,
+	'm': 60,
+	'h': 60 * 60,
+	'd': 24 * 60 * 60,
+	'w': 7 * 24 * 60 * 60,
+}
+
+// ParseTTL parses a TTL value as returned by reg.ru API into seconds.
+// It accepts plain seconds ("3600") as well as BIND-style values with
+// unit suffixes ("1d", "12h", "1h30m"). Units are case-insensitive and
+// surrounding whitespace is ignored.
+func ParseTTL(s string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, &InvalidTTLError{Value: s}
+	}
+
+	// Plain number of seconds
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, &InvalidTTLError{Value: s}
+		}
+		return seconds, nil
+	}
+
+	// BIND-style value: one or more <number><unit> pairs
+	total := 0
+	start := 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			continue
+		}
+		unit, ok := ttlUnits[c]
+		if !ok || i == start {
+			return 0, &InvalidTTLError{Value: s}
+		}
+		n, err := strconv.Atoi(value[start:i])
+		if err != nil {
+			return 0, &InvalidTTLError{Value: s}
+		}
+		total += n * unit
+		start = i + 1
+	}
+
+	// Trailing digits without a unit are not allowed in BIND-style values
+	if start != len(value) {
+		return 0, &InvalidTTLError{Value: s}
+	}
+
+	return total, nil
+}
+
+// FormatTTL formats a TTL in seconds as a BIND-style value using the largest
+// units that fit ("1d", "1h30m", "45s"). It is the inverse of ParseTTL.
+func FormatTTL(seconds int) string {
+	if seconds <= 0 {
+		return "0"
+	}
+
+	var b strings.Builder
+	for _, unit := range []byte{'w', 'd', 'h', 'm', 's'} {
+		size := ttlUnits[unit]
+		if n := seconds / size; n > 0 {
+			b.WriteString(strconv.Itoa(n))
+			b.WriteByte(unit)
+			seconds -= n * size
+		}
+	}
+	return b.String()
+}
+
+// durationToTTL converts a duration into whole seconds, rounding up so that
+// a positive sub-second duration never turns into an unset TTL.
+func durationToTTL(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// effectiveTTL returns the TTL in seconds, preferring the duration value when it is set.
+func effectiveTTL(seconds int, d time.Duration) int {
+	if d > 0 {
+		return durationToTTL(d)
+	}
+	return seconds
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "plain seconds", value: "3600", want: 3600},
+		{name: "zero", value: "0", want: 0},
+		{name: "days", value: "1d", want: 86400},
+		{name: "hours", value: "12h", want: 43200},
+		{name: "minutes", value: "5m", want: 300},
+		{name: "weeks", value: "1w", want: 604800},
+		{name: "combined units", value: "1h30m", want: 5400},
+		{name: "upper case unit", value: "1D", want: 86400},
+		{name: "surrounding whitespace", value: " 3600 ", want: 3600},
+		{name: "empty", value: "", wantErr: true},
+		{name: "negative", value: "-1", wantErr: true},
+		{name: "unknown unit", value: "1y", wantErr: true},
+		{name: "unit without number", value: "h", wantErr: true},
+		{name: "trailing digits", value: "1h30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTTL(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrInvalidTTL))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatTTL(t *testing.T) {
+	assert.Equal(t, "1d", FormatTTL(86400))
+	assert.Equal(t, "1h30m", FormatTTL(5400))
+	assert.Equal(t, "1w1d", FormatTTL(691200))
+	assert.Equal(t, "45s", FormatTTL(45))
+	assert.Equal(t, "0", FormatTTL(0))
+
+	for _, seconds := range []int{1, 59, 3600, 3661, 2147483647} {
+		parsed, err := ParseTTL(FormatTTL(seconds))
+		require.NoError(t, err)
+		assert.Equal(t, seconds, parsed)
+	}
+}
+
+func TestSOAInfo_GetTTL(t *testing.T) {
+	soa := SOAInfo{TTL: "1d", MinimumTTL: "12h"}
+
+	ttl, err := soa.GetTTL()
+	require.NoError(t, err)
+	assert.Equal(t, 86400, ttl)
+
+	minTTL, err := soa.GetMinimumTTL()
+	require.NoError(t, err)
+	assert.Equal(t, 43200, minTTL)
+}
+
+func TestEffectiveTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  int
+		duration time.Duration
+		want     int
+	}{
+		{name: "seconds only", seconds: 3600, want: 3600},
+		{name: "duration only", duration: 5 * time.Minute, want: 300},
+		{name: "duration takes precedence", seconds: 3600, duration: time.Hour * 2, want: 7200},
+		{name: "sub-second duration rounds up", duration: 500 * time.Millisecond, want: 1},
+		{name: "neither set", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, effectiveTTL(tt.seconds, tt.duration))
+		})
+	}
+}
+
+func TestCreateAddRecordRequest_TTLDuration(t *testing.T) {
+	params := CreateDNSRecordParams{
+		Name:        "www",
+		Type:        RecordTypeA,
+		Content:     "192.0.2.1",
+		TTLDuration: 10 * time.Minute,
+	}
+
+	req, err := createAddRecordRequest("example.com", params)
+	require.NoError(t, err)
+
+	aliasReq, ok := req.(*AddAliasRequest)
+	require.True(t, ok)
+	assert.Equal(t, 600, aliasReq.TTL)
+	assert.Equal(t, 10*time.Minute, params.GetTTL())
+}
+
+func TestClient_UpdateRR_ListedRecordTTL(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].SOA = &SOAInfo{TTL: "1h"}
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	listed, err := client.ListRecords(context.Background(), ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	rec := listed[1]
+	assert.Zero(t, rec.TTLDuration)
+
+	rec.TTL = 60
+	updated, err := client.UpdateRR(context.Background(), "example.com", rec)
+	require.NoError(t, err)
+	assert.Equal(t, 60, updated.TTL)
+	assert.Equal(t, "zone/add_alias", (*calls)[len(*calls)-1].Path)
+	assert.Equal(t, float64(60), (*calls)[len(*calls)-1].Input["ttl"])
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTXTStringLength is the maximum length in bytes of a single TXT character string (RFC 1035).
+const MaxTXTStringLength = 255
+
+// WithTXTChunking enables or disables the splitting of TXT and SPF content
+// longer than MaxTXTStringLength into quoted character strings when records
+// are added or deleted, and the joining of such strings in ListRecords.
+// Chunking is enabled by default.
+func WithTXTChunking(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.noTXTChunking = !enabled
+	}
+}
+
+// isTXTType reports whether records of the type hold character strings.
+func isTXTType(recordType RecordType) bool {
+	return recordType == RecordTypeTXT || recordType == RecordTypeSPF
+}
+
+// chunkTXT splits content longer than MaxTXTStringLength into quoted strings of
+// at most MaxTXTStringLength bytes ("part1" "part2"), without splitting UTF-8
+// characters. Short content and content that is already quoted is returned unchanged.
+func chunkTXT(content string) string {
+	if len(content) <= MaxTXTStringLength || strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var chunks []string
+	for len(content) > 0 {
+		n := min(len(content), MaxTXTStringLength)
+		for n < len(content) && n > 0 && !utf8.RuneStart(content[n]) {
+			n--
+		}
+		chunks = append(chunks, quoteTXT(content[:n]))
+		content = content[n:]
+	}
+	return strings.Join(chunks, " ")
+}
+
+// quoteTXT returns s as a quoted character string, escaping quotes and backslashes.
+func quoteTXT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// joinTXTChunks joins content split into quoted strings by chunkTXT. Content
+// that does not join into a value longer than MaxTXTStringLength is returned unchanged.
+func joinTXTChunks(content string) string {
+	joined := canonicalTXT(content)
+	if len(joined) <= MaxTXTStringLength || !strings.HasPrefix(strings.TrimSpace(content), `"`) {
+		return content
+	}
+	return joined
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkTXT(t *testing.T) {
+	assert.Equal(t, "short", chunkTXT("short"))
+
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, chunkTXT(long))
+	assert.Equal(t, long, joinTXTChunks(chunkTXT(long)))
+
+	quoted := strings.Repeat(`x"\`, 100)
+	assert.Equal(t, quoted, canonicalTXT(chunkTXT(quoted)))
+
+	// Multi-byte characters are not split across chunks
+	unicode := strings.Repeat("a", 254) + "ж" + "b"
+	assert.Equal(t, `"`+strings.Repeat("a", 254)+`" "жb"`, chunkTXT(unicode))
+
+	assert.Equal(t, `"part1" "part2"`, joinTXTChunks(`"part1" "part2"`))
+}
+
+func TestClient_TXTChunking(t *testing.T) {
+	key := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+	chunked := chunkTXT(key)
+
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": ZoneGetResourceRecordsResponse{
+			Answer: ZoneGetResourceRecordsAnswer{
+				Domains: []DomainWithResourceRecords{
+					{
+						DName:  "example.com",
+						Result: "success",
+						RRList: []ResourceRecord{
+							{Subname: "mail._domainkey", Rectype: "TXT", Content: chunked},
+						},
+					},
+				},
+			},
+			Result: "success",
+		},
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+	ctx := context.Background()
+
+	record, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "mail._domainkey", Type: RecordTypeTXT, Content: key,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, key, record.Content)
+
+	records, err := client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, key, records[0].Content)
+
+	require.NoError(t, client.DeleteRR(ctx, "example.com", records[0]))
+
+	require.Len(t, *calls, 3)
+	assert.Equal(t, chunked, (*calls)[0].Input["text"])
+	assert.Equal(t, chunked, (*calls)[2].Input["content"])
+
+	// Disabled chunking sends and returns content unchanged
+	client = setupTestClient(t, server, WithTXTChunking(false))
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{
+		Name: "mail._domainkey", Type: RecordTypeTXT, Content: key,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, key, (*calls)[3].Input["text"])
+
+	records, err = client.ListRecords(ctx, ListDNSRecordsParams{ZoneName: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, chunked, records[0].Content)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TTL bounds enforced by ValidateRecord. A TTL of 0 is also accepted and
+// leaves the TTL to the zone default.
+const (
+	MinRecordTTL = 60
+	MaxRecordTTL = 2147483647 // RFC 2181, section 8
+)
+
+// WithValidation makes AddRR and UpdateRR check records with ValidateRecord
+// before any API call is made, so invalid records fail with a ValidationError
+// instead of an API error or a partially applied update.
+func WithValidation() ClientOption {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// ValidateRecord checks a record before submission: A and AAAA content must be
+// an IPv4 or IPv6 address, CNAME, NS and MX targets must be valid hostnames, a
+// CNAME cannot be placed at the zone apex, and the TTL must be 0 or between
+// MinRecordTTL and MaxRecordTTL. It returns a *ValidationError for the first
+// problem found.
+func ValidateRecord(params CreateDNSRecordParams) error {
+	recordType := params.Type.Canonical()
+	invalid := func(field, format string, args ...interface{}) error {
+		return &ValidationError{Name: params.Name, Type: recordType, Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+
+	content := strings.TrimSpace(params.Content)
+	switch recordType {
+	case RecordTypeA:
+		if ip := net.ParseIP(content); ip == nil || ip.To4() == nil {
+			return invalid("content", "%q is not an IPv4 address", content)
+		}
+	case RecordTypeAAAA:
+		if ip := net.ParseIP(content); ip == nil || ip.To4() != nil {
+			return invalid("content", "%q is not an IPv6 address", content)
+		}
+	case RecordTypeCNAME:
+		if canonicalName(params.Name) == "@" {
+			return invalid("name", "CNAME records cannot be placed at the zone apex")
+		}
+		if !isValidHostname(content) {
+			return invalid("content", "%q is not a valid hostname", content)
+		}
+	case RecordTypeNS:
+		if !isValidHostname(content) {
+			return invalid("content", "%q is not a valid hostname", content)
+		}
+	case RecordTypeMX:
+		host, _ := mxParams(params)
+		if !isValidHostname(host) {
+			return invalid("content", "%q is not a valid mail server hostname", host)
+		}
+	}
+
+	ttl := effectiveTTL(params.TTL, params.TTLDuration)
+	if ttl != 0 && (ttl < MinRecordTTL || ttl > MaxRecordTTL) {
+		return invalid("ttl", "TTL %d is outside of %d..%d seconds", ttl, MinRecordTTL, MaxRecordTTL)
+	}
+
+	return nil
+}
+
+// isValidHostname reports whether host is a valid hostname: labels of 1 to 63
+// letters, digits, hyphens or underscores, not starting or ending with a
+// hyphen, at most 253 characters in total, with an optional trailing dot.
+// Internationalized names are checked in their punycode form.
+func isValidHostname(host string) bool {
+	if !isASCII(host) {
+		ascii, err := idnaProfile.ToASCII(host)
+		if err != nil {
+			return false
+		}
+		host = ascii
+	}
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		params CreateDNSRecordParams
+		field  string
+	}{
+		{"valid A", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300}, ""},
+		{"IPv6 in A", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "2001:db8::1"}, "content"},
+		{"invalid A", CreateDNSRecordParams{Name: "www", Type: "a", Content: "192.0.2.256"}, "content"},
+		{"valid AAAA", CreateDNSRecordParams{Name: "www", Type: RecordTypeAAAA, Content: "2001:db8::1"}, ""},
+		{"IPv4 in AAAA", CreateDNSRecordParams{Name: "www", Type: RecordTypeAAAA, Content: "192.0.2.1"}, "content"},
+		{"valid CNAME", CreateDNSRecordParams{Name: "blog", Type: RecordTypeCNAME, Content: "example.github.io."}, ""},
+		{"CNAME at apex", CreateDNSRecordParams{Name: "@", Type: RecordTypeCNAME, Content: "example.github.io"}, "name"},
+		{"invalid CNAME target", CreateDNSRecordParams{Name: "blog", Type: RecordTypeCNAME, Content: "bad host"}, "content"},
+		{"IDN NS target", CreateDNSRecordParams{Name: "@", Type: RecordTypeNS, Content: "ns1.пример.рф"}, ""},
+		{"hyphen label", CreateDNSRecordParams{Name: "@", Type: RecordTypeNS, Content: "-ns1.example.com"}, "content"},
+		{"valid MX", CreateDNSRecordParams{Name: "@", Type: RecordTypeMX, Content: "10 mail.example.com"}, ""},
+		{"invalid MX", CreateDNSRecordParams{Name: "@", Type: RecordTypeMX, Content: "10 mail..example.com"}, "content"},
+		{"TXT is not checked", CreateDNSRecordParams{Name: "@", Type: RecordTypeTXT, Content: "anything goes"}, ""},
+		{"low TTL", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTL: 30}, "ttl"},
+		{"TTL duration", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1", TTLDuration: 10 * time.Second}, "ttl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecord(tt.params)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), "got %v", err)
+			assert.Equal(t, tt.field, validationErr.Field)
+			assert.ErrorIs(t, err, ErrInvalidRecord)
+		})
+	}
+}
+
+func TestClient_WithValidation(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	client := setupTestClient(t, server, WithValidation())
+	ctx := context.Background()
+
+	_, err := client.AddRR(ctx, "example.com", CreateDNSRecordParams{Name: "@", Type: RecordTypeCNAME, Content: "example.net"})
+	assert.EqualError(t, err, `invalid CNAME record "@": CNAME records cannot be placed at the zone apex`)
+
+	_, err = client.UpdateRR(ctx, "example.com", DNSRecord{Name: "www", Type: RecordTypeA, Content: "not-an-ip"})
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+	assert.Empty(t, *calls)
+
+	_, err = client.AddRR(ctx, "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Len(t, *calls, 1)
+
+	// Without the option records are sent unchecked
+	_, err = setupTestClient(t, server).AddRR(ctx, "example.com", CreateDNSRecordParams{Name: "www", Type: RecordTypeA, Content: "not-an-ip"})
+	require.NoError(t, err)
+}
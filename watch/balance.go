@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+const (
+	// DefaultBalanceInterval is the delay between balance polls.
+	DefaultBalanceInterval = time.Hour
+	// DefaultRenewalWindow is how far ahead renewals are counted against the balance.
+	DefaultRenewalWindow = 30 * 24 * time.Hour
+)
+
+// BalanceClient is the subset of regru.Client methods used by BalanceWatcher.
+type BalanceClient interface {
+	GetBalance(ctx context.Context) (regru.Balance, error)
+	ListDomains(ctx context.Context) ([]regru.Domain, error)
+}
+
+// WithMinBalance sets the balance below which BalanceWatcher emits EventLowBalance.
+func WithMinBalance(amount float64) Option {
+	return func(c *config) {
+		c.minBalance = amount
+	}
+}
+
+// WithRenewalPrice sets the renewal price of a domain. When set, BalanceWatcher
+// emits EventInsufficientFunds if the balance cannot renew every domain expiring
+// within the renewal window.
+func WithRenewalPrice(price float64) Option {
+	return func(c *config) {
+		c.renewalPrice = price
+	}
+}
+
+// WithRenewalWindow sets how far ahead BalanceWatcher counts domain renewals.
+func WithRenewalWindow(window time.Duration) Option {
+	return func(c *config) {
+		c.renewalWindow = window
+	}
+}
+
+// BalanceWatcher emits events when the account balance runs low. Each condition
+// is reported once when it starts and re-armed once it clears, for example
+// after a top-up.
+type BalanceWatcher struct {
+	client BalanceClient
+	config
+
+	mu           sync.Mutex
+	low          bool
+	insufficient bool
+}
+
+// NewBalanceWatcher creates a balance watcher. Without WithMinBalance or
+// WithRenewalPrice it never emits balance events.
+func NewBalanceWatcher(client BalanceClient, opts ...Option) *BalanceWatcher {
+	w := &BalanceWatcher{
+		client: client,
+		config: newConfig(DefaultBalanceInterval, opts),
+	}
+	if w.renewalWindow == 0 {
+		w.renewalWindow = DefaultRenewalWindow
+	}
+
+	return w
+}
+
+// Check fetches the balance once, emits and returns the new events.
+func (w *BalanceWatcher) Check(ctx context.Context) ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	balance, err := w.client.GetBalance(ctx)
+	if err != nil {
+		w.emit(ctx, Event{Type: EventError, Err: err})
+		return nil, err
+	}
+
+	now := w.now()
+	var events []Event
+
+	low := balance.Prepay < w.minBalance
+	if low && !w.low {
+		events = append(events, Event{
+			Type:     EventLowBalance,
+			Balance:  balance.Prepay,
+			Required: w.minBalance,
+			Currency: balance.Currency,
+			Time:     now,
+		})
+	}
+	w.low = low
+
+	if w.renewalPrice > 0 {
+		domains, err := w.client.ListDomains(ctx)
+		if err != nil {
+			w.emit(ctx, Event{Type: EventError, Err: err})
+			return nil, err
+		}
+
+		var due []string
+		for _, domain := range domains {
+			if !domain.ExpirationDate.IsZero() && domain.ExpirationDate.Sub(now) <= w.renewalWindow {
+				due = append(due, domain.Name)
+			}
+		}
+
+		required := float64(len(due)) * w.renewalPrice
+		insufficient := balance.Prepay < required
+		if insufficient && !w.insufficient {
+			events = append(events, Event{
+				Type:     EventInsufficientFunds,
+				Balance:  balance.Prepay,
+				Required: required,
+				Currency: balance.Currency,
+				Domains:  due,
+				Time:     now,
+			})
+		}
+		w.insufficient = insufficient
+	}
+
+	for _, event := range events {
+		w.emit(ctx, event)
+	}
+	return events, nil
+}
+
+// Run calls Check on every interval until the context is done.
+// Errors are reported as events and do not stop the loop.
+func (w *BalanceWatcher) Run(ctx context.Context) error {
+	return run(ctx, w.interval, func(ctx context.Context) {
+		_, _ = w.Check(ctx)
+	})
+}
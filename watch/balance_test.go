@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBalance struct {
+	fakeDomains
+	balance regru.Balance
+}
+
+func (f *fakeBalance) GetBalance(ctx context.Context) (regru.Balance, error) {
+	return f.balance, nil
+}
+
+func TestBalanceWatcher_LowBalance(t *testing.T) {
+	client := &fakeBalance{balance: regru.Balance{Prepay: 500, Currency: "RUR"}}
+	w := NewBalanceWatcher(client, WithMinBalance(1000))
+
+	events, err := w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventLowBalance, events[0].Type)
+	assert.Equal(t, 500.0, events[0].Balance)
+	assert.Equal(t, 1000.0, events[0].Required)
+	assert.Equal(t, "RUR", events[0].Currency)
+
+	// Reported once while the balance stays low
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// A top-up re-arms the event
+	client.balance.Prepay = 2000
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	client.balance.Prepay = 100
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestBalanceWatcher_Renewals(t *testing.T) {
+	now := time.Now()
+	client := &fakeBalance{balance: regru.Balance{Prepay: 1000}}
+	client.domains = []regru.Domain{
+		{Name: "example.com", ExpirationDate: now.Add(10 * 24 * time.Hour)},
+		{Name: "example.org", ExpirationDate: now.Add(20 * 24 * time.Hour)},
+		{Name: "example.net", ExpirationDate: now.Add(90 * 24 * time.Hour)},
+	}
+
+	var handled []Event
+	w := NewBalanceWatcher(client, WithRenewalPrice(600), WithEventHandler(func(e Event) { handled = append(handled, e) }))
+
+	events, err := w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInsufficientFunds, events[0].Type)
+	assert.Equal(t, 1200.0, events[0].Required)
+	assert.Equal(t, []string{"example.com", "example.org"}, events[0].Domains)
+	assert.Equal(t, events, handled)
+
+	w = NewBalanceWatcher(client, WithRenewalPrice(600), WithRenewalWindow(15*24*time.Hour))
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// DefaultExpiryInterval is the delay between expiry polls.
+const DefaultExpiryInterval = 12 * time.Hour
+
+// DefaultThresholds are the days before expiration at which EventExpiring is emitted.
+var DefaultThresholds = []int{30, 14, 7, 1}
+
+// DomainLister is the subset of regru.Client methods used by ExpiryWatcher.
+type DomainLister interface {
+	ListDomains(ctx context.Context) ([]regru.Domain, error)
+}
+
+// WithThresholds sets the days before expiration at which ExpiryWatcher emits EventExpiring.
+func WithThresholds(days ...int) Option {
+	return func(c *config) {
+		c.thresholds = append([]int(nil), days...)
+	}
+}
+
+// expiryState records the events already emitted for a domain.
+type expiryState struct {
+	expiresAt time.Time
+	// threshold is the smallest threshold emitted so far, or 0 if none.
+	threshold int
+	expired   bool
+}
+
+// ExpiryWatcher emits events as registered domains approach their expiration date.
+// Each threshold is reported once per expiration date, so a renewal re-arms
+// the thresholds. When several thresholds are crossed between polls, only the
+// smallest one is reported.
+type ExpiryWatcher struct {
+	client DomainLister
+	config
+
+	mu    sync.Mutex
+	state map[string]expiryState
+}
+
+// NewExpiryWatcher creates a watcher for the domains of the account.
+func NewExpiryWatcher(client DomainLister, opts ...Option) *ExpiryWatcher {
+	w := &ExpiryWatcher{
+		client: client,
+		config: newConfig(DefaultExpiryInterval, opts),
+		state:  make(map[string]expiryState),
+	}
+	if w.thresholds == nil {
+		w.thresholds = append([]int(nil), DefaultThresholds...)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(w.thresholds)))
+
+	return w
+}
+
+// Check fetches the domains once, emits and returns the new events.
+func (w *ExpiryWatcher) Check(ctx context.Context) ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	domains, err := w.client.ListDomains(ctx)
+	if err != nil {
+		w.emit(ctx, Event{Type: EventError, Err: err})
+		return nil, err
+	}
+
+	now := w.now()
+	var events []Event
+	for _, domain := range domains {
+		if domain.ExpirationDate.IsZero() {
+			continue
+		}
+		if event, ok := w.check(domain, now); ok {
+			events = append(events, event)
+		}
+	}
+
+	for _, event := range events {
+		w.emit(ctx, event)
+	}
+	return events, nil
+}
+
+// check updates the state of a domain and returns the event to emit, if any.
+func (w *ExpiryWatcher) check(domain regru.Domain, now time.Time) (Event, bool) {
+	state := w.state[domain.Name]
+	if !state.expiresAt.Equal(domain.ExpirationDate) {
+		state = expiryState{expiresAt: domain.ExpirationDate}
+	}
+	defer func() { w.state[domain.Name] = state }()
+
+	event := Event{
+		Domain:    domain.Name,
+		ExpiresAt: domain.ExpirationDate,
+		DaysLeft:  daysUntil(now, domain.ExpirationDate),
+		Time:      now,
+	}
+
+	if !now.Before(domain.ExpirationDate) {
+		if state.expired {
+			return Event{}, false
+		}
+		state.expired = true
+		event.Type = EventExpired
+		return event, true
+	}
+
+	// Find the smallest threshold the domain is within
+	crossed := 0
+	for _, threshold := range w.thresholds {
+		if domain.ExpirationDate.Sub(now) <= time.Duration(threshold)*24*time.Hour {
+			crossed = threshold
+		}
+	}
+	if crossed == 0 || (state.threshold != 0 && crossed >= state.threshold) {
+		return Event{}, false
+	}
+	state.threshold = crossed
+	event.Type = EventExpiring
+	event.Threshold = crossed
+	return event, true
+}
+
+// Run calls Check on every interval until the context is done.
+// Errors are reported as events and do not stop the loop.
+func (w *ExpiryWatcher) Run(ctx context.Context) error {
+	return run(ctx, w.interval, func(ctx context.Context) {
+		_, _ = w.Check(ctx)
+	})
+}
+
+// daysUntil returns the number of whole days from now until t.
+func daysUntil(now, t time.Time) int {
+	return int(t.Sub(now).Hours() / 24)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDomains struct {
+	domains []regru.Domain
+	err     error
+}
+
+func (f *fakeDomains) ListDomains(ctx context.Context) ([]regru.Domain, error) {
+	return f.domains, f.err
+}
+
+func TestExpiryWatcher(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	client := &fakeDomains{domains: []regru.Domain{
+		{Name: "example.com", ExpirationDate: start.Add(20 * 24 * time.Hour)},
+		{Name: "example.org", ExpirationDate: start.Add(200 * 24 * time.Hour)},
+		{Name: "unknown.net"},
+	}}
+
+	var handled []Event
+	w := NewExpiryWatcher(client, WithEventHandler(func(e Event) { handled = append(handled, e) }))
+	w.now = func() time.Time { return now }
+
+	// 20 days left: only the 30-day threshold is reported
+	events, err := w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventExpiring, events[0].Type)
+	assert.Equal(t, "example.com", events[0].Domain)
+	assert.Equal(t, 30, events[0].Threshold)
+	assert.Equal(t, 20, events[0].DaysLeft)
+	assert.Equal(t, events, handled)
+
+	// Nothing new on the next poll
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// Several thresholds crossed at once: only the smallest is reported
+	now = start.Add(19*24*time.Hour + time.Hour)
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, events[0].Threshold)
+
+	// Expired once
+	now = start.Add(21 * 24 * time.Hour)
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventExpired, events[0].Type)
+	assert.Equal(t, -1, events[0].DaysLeft)
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// Renewal re-arms the thresholds
+	client.domains[0].ExpirationDate = now.Add(10 * 24 * time.Hour)
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, 14, events[0].Threshold)
+}
+
+func TestExpiryWatcher_Channel(t *testing.T) {
+	client := &fakeDomains{domains: []regru.Domain{
+		{Name: "example.com", ExpirationDate: time.Now().Add(-time.Hour)},
+	}}
+
+	ch := make(chan Event, 1)
+	w := NewExpiryWatcher(client, WithEventChannel(ch), WithThresholds(3))
+
+	_, err := w.Check(context.Background())
+	require.NoError(t, err)
+	event := <-ch
+	assert.Equal(t, EventExpired, event.Type)
+}
+
+func TestExpiryWatcher_Error(t *testing.T) {
+	client := &fakeDomains{err: errors.New("unavailable")}
+
+	var handled []Event
+	w := NewExpiryWatcher(client, WithEventHandler(func(e Event) { handled = append(handled, e) }))
+
+	_, err := w.Check(context.Background())
+	require.Error(t, err)
+	require.Len(t, handled, 1)
+	assert.Equal(t, EventError, handled[0].Type)
+	assert.Equal(t, client.err, handled[0].Err)
+}
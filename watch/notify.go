@@ -0,0 +1,214 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTelegramURL is the Telegram Bot API endpoint.
+const DefaultTelegramURL = "https://api.telegram.org"
+
+// Notifier pushes watcher events to an external system.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, event Event) error
+
+// Notify calls f(ctx, event).
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// eventPayload is the JSON form of an event sent by WebhookNotifier.
+type eventPayload struct {
+	Type      EventType  `json:"type"`
+	Message   string     `json:"message"`
+	Time      time.Time  `json:"time"`
+	Domain    string     `json:"domain,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	DaysLeft  int        `json:"days_left,omitempty"`
+	Threshold int        `json:"threshold,omitempty"`
+	Balance   float64    `json:"balance,omitempty"`
+	Required  float64    `json:"required,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Domains   []string   `json:"domains,omitempty"`
+	Zone      string     `json:"zone,omitempty"`
+	Serial    uint32     `json:"serial,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// WebhookNotifier posts events as JSON to a URL.
+type WebhookNotifier struct {
+	URL string
+	// Header is added to every request, for example for authentication.
+	Header http.Header
+	// Client is the HTTP client used for requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	payload := eventPayload{
+		Type:      event.Type,
+		Message:   event.String(),
+		Time:      event.Time,
+		Domain:    event.Domain,
+		DaysLeft:  event.DaysLeft,
+		Threshold: event.Threshold,
+		Balance:   event.Balance,
+		Required:  event.Required,
+		Currency:  event.Currency,
+		Domains:   event.Domains,
+		Zone:      event.Zone,
+		Serial:    event.Serial,
+	}
+	if !event.ExpiresAt.IsZero() {
+		payload.ExpiresAt = &event.ExpiresAt
+	}
+	if event.Err != nil {
+		payload.Error = event.Err.Error()
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for key, values := range n.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return send(n.Client, req)
+}
+
+// SMTPNotifier sends events by email.
+type SMTPNotifier struct {
+	// Addr is the SMTP server address in host:port form.
+	Addr string
+	// Auth is the authentication mechanism, or nil for none.
+	Auth smtp.Auth
+	From string
+	To   []string
+	// SubjectPrefix is prepended to the subject. Defaults to "[regru]".
+	SubjectPrefix string
+}
+
+// Notify implements Notifier. The context is only checked before sending,
+// since net/smtp does not support cancellation.
+func (n *SMTPNotifier) Notify(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	prefix := n.SubjectPrefix
+	if prefix == "" {
+		prefix = "[regru]"
+	}
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(fmt.Sprintf("%s %s", prefix, event.String()))
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\nEvent: %s\r\nTime: %s\r\n", event.String(), event.Type, event.Time.Format(time.RFC3339))
+
+	return smtp.SendMail(n.Addr, n.Auth, n.From, n.To, []byte(msg.String()))
+}
+
+// TelegramNotifier sends events to a Telegram chat through a bot.
+type TelegramNotifier struct {
+	// Token is the bot token issued by BotFather.
+	Token  string
+	ChatID string
+	// BaseURL is the Bot API endpoint. Defaults to DefaultTelegramURL.
+	BaseURL string
+	// Client is the HTTP client used for requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (n *TelegramNotifier) Notify(ctx context.Context, event Event) error {
+	baseURL := n.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultTelegramURL
+	}
+
+	form := url.Values{}
+	form.Set("chat_id", n.ChatID)
+	form.Set("text", event.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimSuffix(baseURL, "/")+"/bot"+n.Token+"/sendMessage", strings.NewReader(form.Encode()))
+	if err != nil {
+		return redactToken(err, n.Token)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return redactToken(send(n.Client, req), n.Token)
+}
+
+// redactToken removes the bot token from the URL of a *url.Error, so it does
+// not end up in logs.
+func redactToken(err error, token string) error {
+	var urlErr *url.Error
+	if token == "" || !errors.As(err, &urlErr) {
+		return err
+	}
+	urlErr.URL = strings.ReplaceAll(urlErr.URL, token, "REDACTED")
+	return err
+}
+
+// send performs the request and fails on non-2xx responses.
+func send(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() Event {
+	return Event{
+		Type:      EventExpiring,
+		Domain:    "example.com",
+		ExpiresAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		DaysLeft:  14,
+		Threshold: 14,
+		Time:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestEvent_String(t *testing.T) {
+	assert.Equal(t, "example.com expires in 14 days (2025-01-15)", testEvent().String())
+	assert.Equal(t, "balance 500.00 RUR is below 1000.00",
+		Event{Type: EventLowBalance, Balance: 500, Required: 1000, Currency: "RUR"}.String())
+	assert.Equal(t, "error: boom", Event{Type: EventError, Err: errors.New("boom")}.String())
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	n := &WebhookNotifier{URL: server.URL, Header: http.Header{"Authorization": {"Bearer secret"}}}
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+	assert.Equal(t, "expiring", payload["type"])
+	assert.Equal(t, "example.com", payload["domain"])
+	assert.Equal(t, "2025-01-15T00:00:00Z", payload["expires_at"])
+	assert.Equal(t, "example.com expires in 14 days (2025-01-15)", payload["message"])
+}
+
+func TestWebhookNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	n := &WebhookNotifier{URL: server.URL}
+	assert.ErrorContains(t, n.Notify(context.Background(), testEvent()), "HTTP 502")
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bot123:abc/sendMessage", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "-100", r.PostForm.Get("chat_id"))
+		assert.Equal(t, "example.com expires in 14 days (2025-01-15)", r.PostForm.Get("text"))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	n := &TelegramNotifier{Token: "123:abc", ChatID: "-100", BaseURL: server.URL}
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+}
+
+func TestTelegramNotifier_ErrorHidesToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	n := &TelegramNotifier{Token: "123:abc", ChatID: "-100", BaseURL: baseURL}
+	err := n.Notify(context.Background(), testEvent())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "123:abc")
+	assert.Contains(t, err.Error(), "/botREDACTED/sendMessage")
+}
+
+// startTestSMTPServer accepts a single message and returns it on the channel.
+func startTestSMTPServer(t *testing.T) (string, <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost")
+
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					messages <- data.String()
+					reply("250 OK")
+					continue
+				}
+				data.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				inData = true
+				reply("354 go ahead")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), messages
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, messages := startTestSMTPServer(t)
+
+	n := &SMTPNotifier{Addr: addr, From: "watch@example.com", To: []string{"ops@example.com"}}
+	require.NoError(t, n.Notify(context.Background(), testEvent()))
+
+	msg := <-messages
+	assert.Contains(t, msg, "To: ops@example.com\r\n")
+	assert.Contains(t, msg, "Subject: [regru] example.com expires in 14 days (2025-01-15)\r\n")
+}
+
+func TestSMTPNotifier_EncodedSubject(t *testing.T) {
+	addr, messages := startTestSMTPServer(t)
+
+	n := &SMTPNotifier{Addr: addr, From: "watch@example.com", To: []string{"ops@example.com"}}
+	event := testEvent()
+	event.Domain = "пример.рф"
+	require.NoError(t, n.Notify(context.Background(), event))
+
+	msg := <-messages
+	assert.Contains(t, msg, "Subject: =?utf-8?q?")
+	assert.NotContains(t, msg, "Subject: [regru] пример.рф")
+}
+
+func TestWithNotifier(t *testing.T) {
+	client := &fakeDomains{err: errors.New("unavailable")}
+
+	var notified, handled []Event
+	failing := NotifierFunc(func(ctx context.Context, e Event) error { return errors.New("offline") })
+	w := NewExpiryWatcher(client,
+		WithNotifier(NotifierFunc(func(ctx context.Context, e Event) error {
+			notified = append(notified, e)
+			return nil
+		})),
+		WithNotifier(failing),
+		WithEventHandler(func(e Event) { handled = append(handled, e) }),
+	)
+
+	_, err := w.Check(context.Background())
+	require.Error(t, err)
+	require.Len(t, notified, 1)
+	require.Len(t, handled, 2)
+	assert.ErrorContains(t, handled[0].Err, "offline")
+	assert.Equal(t, client.err, handled[1].Err)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watch polls account data and emits events about it, such as domains
+// approaching their expiration date, so alerting does not need its own polling
+// loop and date math.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// EventType identifies a watcher event.
+type EventType string
+
+// Watcher events
+const (
+	// EventExpiring is emitted when a domain crosses one of the expiry thresholds.
+	EventExpiring EventType = "expiring"
+	// EventExpired is emitted once a domain has expired.
+	EventExpired EventType = "expired"
+	// EventLowBalance is emitted when the balance drops below the minimum.
+	EventLowBalance EventType = "low_balance"
+	// EventInsufficientFunds is emitted when the balance cannot cover the
+	// renewals of domains expiring within the renewal window.
+	EventInsufficientFunds EventType = "insufficient_funds"
+	// EventZoneChanged is emitted when the SOA serial of a zone changes.
+	EventZoneChanged EventType = "zone_changed"
+	// EventError is emitted when data could not be fetched.
+	EventError EventType = "error"
+)
+
+// Event describes a condition observed by a watcher.
+type Event struct {
+	Type EventType
+	// Domain is the domain name for expiry events.
+	Domain string
+	// ExpiresAt is the expiration date for expiry events.
+	ExpiresAt time.Time
+	// DaysLeft is the number of whole days until expiration (negative once expired).
+	DaysLeft int
+	// Threshold is the number of days of the crossed threshold for EventExpiring.
+	Threshold int
+	// Balance is the available balance for balance events.
+	Balance float64
+	// Required is the minimum balance for EventLowBalance and the cost of
+	// upcoming renewals for EventInsufficientFunds.
+	Required float64
+	// Currency is the account currency for balance events.
+	Currency string
+	// Domains lists the domains due for renewal for EventInsufficientFunds.
+	Domains []string
+	// Zone is the zone name for EventZoneChanged.
+	Zone string
+	// Serial is the new SOA serial for EventZoneChanged.
+	Serial uint32
+	// Records are the zone records after the change for EventZoneChanged.
+	Records []regru.DNSRecord
+	// Err is the API error for EventError.
+	Err  error
+	Time time.Time
+}
+
+// String returns a human-readable description of the event.
+func (e Event) String() string {
+	switch e.Type {
+	case EventExpiring:
+		return fmt.Sprintf("%s expires in %d days (%s)", e.Domain, e.DaysLeft, e.ExpiresAt.Format(time.DateOnly))
+	case EventExpired:
+		return fmt.Sprintf("%s expired on %s", e.Domain, e.ExpiresAt.Format(time.DateOnly))
+	case EventLowBalance:
+		return fmt.Sprintf("balance %.2f %s is below %.2f", e.Balance, e.Currency, e.Required)
+	case EventInsufficientFunds:
+		return fmt.Sprintf("balance %.2f %s cannot cover %.2f for renewing %s",
+			e.Balance, e.Currency, e.Required, strings.Join(e.Domains, ", "))
+	case EventZoneChanged:
+		return fmt.Sprintf("zone %s changed (serial %d, %d records)", e.Zone, e.Serial, len(e.Records))
+	default:
+		return fmt.Sprintf("%s: %v", e.Type, e.Err)
+	}
+}
+
+// Option represents an option for configuring a watcher. Options that do not
+// apply to a watcher are ignored.
+type Option func(*config)
+
+// config holds settings shared by watchers.
+type config struct {
+	interval   time.Duration
+	onEvent    func(Event)
+	events     chan<- Event
+	notifiers  []Notifier
+	thresholds []int
+	now        func() time.Time
+
+	minBalance    float64
+	renewalPrice  float64
+	renewalWindow time.Duration
+}
+
+// WithInterval sets the delay between polls in Run.
+func WithInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithEventHandler sets a callback invoked for every event.
+func WithEventHandler(fn func(Event)) Option {
+	return func(c *config) {
+		c.onEvent = fn
+	}
+}
+
+// WithEventChannel sets a channel every event is sent to. Sends block until
+// the event is received or the context of the poll is done.
+func WithEventChannel(ch chan<- Event) Option {
+	return func(c *config) {
+		c.events = ch
+	}
+}
+
+// WithNotifier adds a notifier every event is pushed to. Notification failures
+// are reported to the event handler and channel as EventError.
+func WithNotifier(n Notifier) Option {
+	return func(c *config) {
+		c.notifiers = append(c.notifiers, n)
+	}
+}
+
+// newConfig applies the options over the defaults.
+func newConfig(interval time.Duration, opts []Option) config {
+	c := config{interval: interval, now: time.Now}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// emit delivers the event to the notifiers, handler and channel, if any.
+func (c *config) emit(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = c.now()
+	}
+	for _, n := range c.notifiers {
+		if err := n.Notify(ctx, event); err != nil {
+			c.deliver(ctx, Event{Type: EventError, Err: fmt.Errorf("notify %s event: %w", event.Type, err), Time: c.now()})
+		}
+	}
+	c.deliver(ctx, event)
+}
+
+// deliver passes the event to the handler and channel, if any.
+func (c *config) deliver(ctx context.Context, event Event) {
+	if c.onEvent != nil {
+		c.onEvent(event)
+	}
+	if c.events != nil {
+		select {
+		case c.events <- event:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// run calls poll on every interval until the context is done.
+func run(ctx context.Context, interval time.Duration, poll func(ctx context.Context)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mixanemca/regru-go"
+)
+
+// DefaultZoneInterval is the delay between zone serial polls.
+const DefaultZoneInterval = time.Minute
+
+// ZoneClient is the subset of regru.Client methods used by ZoneWatcher.
+type ZoneClient interface {
+	HasZoneChanged(ctx context.Context, zone string, lastSerial uint32) (bool, uint32, error)
+	ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error)
+}
+
+// ZoneWatcher emits events when zones change. Every poll only compares the SOA
+// serials of the zones; records are downloaded for changed zones only.
+type ZoneWatcher struct {
+	client ZoneClient
+	zones  []string
+	config
+
+	mu      sync.Mutex
+	serials map[string]uint32
+}
+
+// NewZoneWatcher creates a watcher for the zones.
+func NewZoneWatcher(client ZoneClient, zones []string, opts ...Option) *ZoneWatcher {
+	return &ZoneWatcher{
+		client:  client,
+		zones:   append([]string(nil), zones...),
+		config:  newConfig(DefaultZoneInterval, opts),
+		serials: make(map[string]uint32),
+	}
+}
+
+// Check compares the zone serials once, emits and returns the new events.
+// The first check of a zone records its serial without an event. Zones that
+// fail are reported as EventError and checked again on the next poll.
+func (w *ZoneWatcher) Check(ctx context.Context) ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var (
+		events []Event
+		errs   []error
+	)
+	for _, zone := range w.zones {
+		last, seen := w.serials[zone]
+		changed, serial, err := w.client.HasZoneChanged(ctx, zone, last)
+		if err != nil {
+			err = fmt.Errorf("check %s: %w", zone, err)
+			w.emit(ctx, Event{Type: EventError, Zone: zone, Err: err})
+			errs = append(errs, err)
+			continue
+		}
+		if !seen {
+			w.serials[zone] = serial
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		// Cached records of the zone are stale now
+		if inv, ok := w.client.(interface{ InvalidateRecordsCache(zone string) }); ok {
+			inv.InvalidateRecordsCache(zone)
+		}
+		records, err := w.client.ListRecords(ctx, regru.ListDNSRecordsParams{ZoneName: zone})
+		if err != nil {
+			err = fmt.Errorf("list records of %s: %w", zone, err)
+			w.emit(ctx, Event{Type: EventError, Zone: zone, Err: err})
+			errs = append(errs, err)
+			continue
+		}
+		w.serials[zone] = serial
+
+		event := Event{
+			Type:    EventZoneChanged,
+			Zone:    zone,
+			Serial:  serial,
+			Records: records,
+			Time:    w.now(),
+		}
+		events = append(events, event)
+		w.emit(ctx, event)
+	}
+
+	return events, errors.Join(errs...)
+}
+
+// Run calls Check on every interval until the context is done.
+// Errors are reported as events and do not stop the loop.
+func (w *ZoneWatcher) Run(ctx context.Context) error {
+	return run(ctx, w.interval, func(ctx context.Context) {
+		_, _ = w.Check(ctx)
+	})
+}
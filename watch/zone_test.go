@@ -0,0 +1,96 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mixanemca/regru-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeZones struct {
+	serials     map[string]uint32
+	err         error
+	listed      []string
+	invalidated []string
+}
+
+func (f *fakeZones) HasZoneChanged(ctx context.Context, zone string, lastSerial uint32) (bool, uint32, error) {
+	if f.err != nil {
+		return false, 0, f.err
+	}
+	return f.serials[zone] != lastSerial, f.serials[zone], nil
+}
+
+func (f *fakeZones) ListRecords(ctx context.Context, params regru.ListDNSRecordsParams) ([]regru.DNSRecord, error) {
+	f.listed = append(f.listed, params.ZoneName)
+	return []regru.DNSRecord{{Name: "www", Type: regru.RecordTypeA, Content: "192.0.2.1"}}, nil
+}
+
+func (f *fakeZones) InvalidateRecordsCache(zone string) {
+	f.invalidated = append(f.invalidated, zone)
+}
+
+func TestZoneWatcher(t *testing.T) {
+	client := &fakeZones{serials: map[string]uint32{"example.com": 1, "example.org": 7}}
+
+	var handled []Event
+	w := NewZoneWatcher(client, []string{"example.com", "example.org"},
+		WithEventHandler(func(e Event) { handled = append(handled, e) }))
+
+	// The first check only records the serials
+	events, err := w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Empty(t, client.listed)
+
+	// Unchanged zones are not downloaded
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Empty(t, client.listed)
+
+	client.serials["example.org"] = 8
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventZoneChanged, events[0].Type)
+	assert.Equal(t, "example.org", events[0].Zone)
+	assert.Equal(t, uint32(8), events[0].Serial)
+	assert.Len(t, events[0].Records, 1)
+	assert.Equal(t, "zone example.org changed (serial 8, 1 records)", events[0].String())
+	assert.Equal(t, []string{"example.org"}, client.listed)
+	assert.Equal(t, []string{"example.org"}, client.invalidated)
+	assert.Equal(t, events, handled)
+
+	// Reported once
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	client.err = errors.New("timeout")
+	handled = nil
+	_, err = w.Check(context.Background())
+	require.Error(t, err)
+	require.Len(t, handled, 2)
+	assert.Equal(t, EventError, handled[0].Type)
+	assert.Equal(t, "example.com", handled[0].Zone)
+}
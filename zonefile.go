@@ -0,0 +1,487 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ZoneFileRecord represents a resource record parsed from an RFC 1035 zone file.
+type ZoneFileRecord struct {
+	DNSRecord
+	// Line is the line number where the record starts.
+	Line int
+	// Comment contains the text of a trailing comment, if any, without the leading ';'.
+	Comment string
+}
+
+// zoneFileLine is a logical zone file line: tokens of one entry, which may span
+// several physical lines when parentheses are used.
+type zoneFileLine struct {
+	tokens     []string
+	comment    string
+	line       int
+	ownerBlank bool
+}
+
+// recordTypeSOA is the SOA record type, which zone files start with but the
+// client cannot manage.
+const recordTypeSOA RecordType = "SOA"
+
+// targetFields maps record types to the index of the RDATA field holding a
+// domain name, which is made absolute when parsing zone files.
+var targetFields = map[RecordType]int{
+	RecordTypeCNAME: 0,
+	RecordTypeNS:    0,
+	RecordTypePTR:   0,
+	RecordTypeMX:    1,
+	RecordTypeSRV:   3,
+}
+
+// ParseZoneFile parses an RFC 1035 (BIND) zone file for the zone origin.
+// Owner names are returned relative to origin ("@" for the apex; names outside
+// the zone stay fully qualified with a trailing dot), hostname
+// targets are made absolute (with a trailing dot), and TXT character strings
+// are unquoted and joined. $ORIGIN and $TTL directives are supported; $INCLUDE
+// is not. Records of all types, including SOA, are returned as found.
+func ParseZoneFile(r io.Reader, origin string) ([]ZoneFileRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := splitZoneFile(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	zoneOrigin := canonicalHost(origin)
+	currentOrigin := zoneOrigin
+	defaultTTL := -1
+	lastTTL := 0
+	lastOwner := ""
+
+	var records []ZoneFileRecord
+	for _, line := range lines {
+		tokens := line.tokens
+
+		// Directives
+		if strings.HasPrefix(tokens[0], "$") && !line.ownerBlank {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) < 2 {
+					return nil, &ZoneFileError{Line: line.line, Message: "$ORIGIN requires a domain name"}
+				}
+				currentOrigin = canonicalHost(absoluteName(tokens[1], currentOrigin))
+			case "$TTL":
+				if len(tokens) < 2 {
+					return nil, &ZoneFileError{Line: line.line, Message: "$TTL requires a value"}
+				}
+				ttl, err := ParseTTL(tokens[1])
+				if err != nil {
+					return nil, &ZoneFileError{Line: line.line, Message: err.Error()}
+				}
+				defaultTTL = ttl
+			default:
+				return nil, &ZoneFileError{Line: line.line, Message: "unsupported directive " + tokens[0]}
+			}
+			continue
+		}
+
+		// Owner name
+		owner := lastOwner
+		if !line.ownerBlank {
+			owner = absoluteName(tokens[0], currentOrigin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			return nil, &ZoneFileError{Line: line.line, Message: "record without owner name"}
+		}
+		lastOwner = owner
+
+		// Optional TTL and class in any order
+		ttl := -1
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			token := tokens[0]
+			if isZoneFileClass(token) {
+				tokens = tokens[1:]
+				continue
+			}
+			if token[0] >= '0' && token[0] <= '9' {
+				value, err := ParseTTL(token)
+				if err != nil {
+					return nil, &ZoneFileError{Line: line.line, Message: err.Error()}
+				}
+				ttl = value
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		switch {
+		case ttl >= 0:
+			lastTTL = ttl
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		default:
+			ttl = lastTTL
+		}
+
+		if len(tokens) == 0 {
+			return nil, &ZoneFileError{Line: line.line, Message: "missing record type"}
+		}
+		recordType := RecordType(tokens[0]).Canonical()
+		if !recordType.IsValid() {
+			return nil, &ZoneFileError{Line: line.line, Message: "invalid record type " + tokens[0]}
+		}
+		rdata := tokens[1:]
+		if len(rdata) == 0 {
+			return nil, &ZoneFileError{Line: line.line, Message: "missing record data"}
+		}
+
+		records = append(records, ZoneFileRecord{
+			DNSRecord: DNSRecord{
+				Name:    relativeName(owner, zoneOrigin),
+				Type:    recordType,
+				Content: zoneFileContent(recordType, rdata, currentOrigin),
+				TTL:     ttl,
+			},
+			Line:    line.line,
+			Comment: line.comment,
+		})
+	}
+
+	return records, nil
+}
+
+// zoneFileContent converts RDATA fields into record content.
+func zoneFileContent(recordType RecordType, rdata []string, origin string) string {
+	if recordType == RecordTypeTXT || recordType == RecordTypeSPF {
+		return canonicalTXT(strings.Join(rdata, " "))
+	}
+
+	fields := append([]string(nil), rdata...)
+	if i, ok := targetFields[recordType]; ok && i < len(fields) {
+		fields[i] = absoluteName(fields[i], origin)
+	}
+	return strings.Join(fields, " ")
+}
+
+// absoluteName returns the fully qualified form of a zone file name, with a trailing dot.
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + origin + "."
+	}
+}
+
+// isZoneFileClass reports whether the token is a DNS class mnemonic.
+func isZoneFileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	default:
+		return false
+	}
+}
+
+// splitZoneFile splits zone file text into logical lines of tokens, handling
+// comments, quoted strings and parentheses.
+func splitZoneFile(text string) ([]zoneFileLine, error) {
+	var (
+		lines   []zoneFileLine
+		current zoneFileLine
+		token   strings.Builder
+		inToken bool
+		quoted  bool
+		escaped bool
+		parens  int
+		lineNo  = 1
+		atStart = true
+	)
+
+	flushToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	flushLine := func() {
+		flushToken()
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = zoneFileLine{}
+		atStart = true
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if atStart {
+			current.line = lineNo
+			current.ownerBlank = c == ' ' || c == '\t'
+			atStart = false
+		}
+
+		switch {
+		case escaped:
+			token.WriteByte(c)
+			escaped = false
+		case c == '\\':
+			token.WriteByte(c)
+			inToken = true
+			escaped = true
+		case quoted:
+			token.WriteByte(c)
+			if c == '"' {
+				quoted = false
+			}
+			if c == '\n' {
+				lineNo++
+			}
+		case c == '"':
+			token.WriteByte(c)
+			inToken = true
+			quoted = true
+		case c == ';':
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			}
+			comment := strings.TrimSpace(text[i+1 : i+end])
+			if current.comment != "" {
+				current.comment += " "
+			}
+			current.comment += comment
+			i += end - 1
+		case c == '(':
+			flushToken()
+			parens++
+		case c == ')':
+			flushToken()
+			if parens == 0 {
+				return nil, &ZoneFileError{Line: lineNo, Message: "unbalanced parenthesis"}
+			}
+			parens--
+		case c == '\n':
+			lineNo++
+			if parens > 0 {
+				flushToken()
+				continue
+			}
+			flushLine()
+		case c == ' ' || c == '\t' || c == '\r':
+			flushToken()
+		default:
+			token.WriteByte(c)
+			inToken = true
+		}
+	}
+
+	if quoted {
+		return nil, &ZoneFileError{Line: lineNo, Message: "unterminated quoted string"}
+	}
+	if parens > 0 {
+		return nil, &ZoneFileError{Line: lineNo, Message: "unbalanced parenthesis"}
+	}
+	flushLine()
+
+	return lines, nil
+}
+
+// ZoneImportReport describes the outcome of ImportZoneFile.
+type ZoneImportReport struct {
+	// Results holds the outcome of each record created or deleted.
+	Results BatchResults
+	// Skipped lists the records of the file that were not imported, such as
+	// SOA, apex NS, out-of-zone records and unsupported record types.
+	Skipped []ImportWarning
+}
+
+// ImportZoneFile reads an RFC 1035 (BIND) zone file and creates its records in
+// the zone. By default the file is merged: records already in the zone are kept
+// and only missing ones are created. With WithReplace records not in the file
+// are deleted as well, as with SyncZone. Records that cannot be managed at
+// reg.ru are reported in Skipped. A malformed file is rejected before any
+// change is made. WithDryRun is supported; with WithReplace the other SyncZone
+// options apply as well.
+func (c *Client) ImportZoneFile(ctx context.Context, zone string, r io.Reader, opts ...BulkOption) (ZoneImportReport, error) {
+	o := newBulkOptions(opts)
+
+	records, err := ParseZoneFile(r, c.asciiName(zone))
+	if err != nil {
+		return ZoneImportReport{}, err
+	}
+
+	var imported ImportResult
+	for _, record := range records {
+		rr := record.DNSRecord
+		rr.Name = c.unicodeName(rr.Name)
+		imported.add(rr)
+	}
+	report := ZoneImportReport{Skipped: imported.Warnings}
+
+	if o.replace {
+		report.Results, err = c.SyncZone(ctx, zone, imported.Records, opts...)
+		return report, err
+	}
+
+	current, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return report, err
+	}
+	present := make(map[recordKey]bool, len(current))
+	for _, rr := range current {
+		present[keyOf(rr)] = true
+	}
+	for _, rr := range imported.Records {
+		key := keyOf(rr)
+		if present[key] {
+			continue
+		}
+		present[key] = true
+		report.Results = append(report.Results, c.batchCreate(ctx, zone, paramsFromRecord(rr), o.dryRun))
+	}
+
+	return report, nil
+}
+
+// ExportZoneFile renders the records of the zone, including the SOA record
+// where the API lists one, as RFC 1035 (BIND) zone file text. See FormatZoneFile.
+func (c *Client) ExportZoneFile(ctx context.Context, zone string) (string, error) {
+	records, err := c.ListRecords(ctx, ListDNSRecordsParams{ZoneName: zone})
+	if err != nil {
+		return "", err
+	}
+
+	ascii := make([]DNSRecord, 0, len(records))
+	for _, rr := range records {
+		rr.Name = c.asciiName(rr.Name)
+		ascii = append(ascii, rr)
+	}
+	return FormatZoneFile(c.asciiName(zone), ascii), nil
+}
+
+// FormatZoneFile renders records of the zone origin as RFC 1035 (BIND) zone
+// file text that ParseZoneFile reads back. The most common TTL becomes the
+// $TTL default and other TTLs are written per record. SOA records come first,
+// the others are ordered by name and type. Hostname targets are written fully
+// qualified and TXT values as quoted strings of at most MaxTXTStringLength bytes.
+func FormatZoneFile(origin string, records []DNSRecord) string {
+	origin = canonicalHost(origin)
+	records = append([]DNSRecord(nil), records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := Canonicalize(records[i]), Canonicalize(records[j])
+		if (a.Type == recordTypeSOA) != (b.Type == recordTypeSOA) {
+			return a.Type == recordTypeSOA
+		}
+		if a.Name != b.Name {
+			return a.Name == "@" || (b.Name != "@" && a.Name < b.Name)
+		}
+		return a.Type < b.Type
+	})
+	defaultTTL := commonTTL(records)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", origin)
+	if defaultTTL > 0 {
+		fmt.Fprintf(&b, "$TTL %d\n", defaultTTL)
+	}
+
+	w := tabwriter.NewWriter(&b, 0, 8, 1, ' ', 0)
+	for _, rr := range records {
+		ttl := ""
+		if value := effectiveTTL(rr.TTL, rr.TTLDuration); value > 0 && value != defaultTTL {
+			ttl = strconv.Itoa(value)
+		}
+		recordType := rr.Type.Canonical()
+		fmt.Fprintf(w, "%s\t%s\tIN\t%s\t%s\n", canonicalName(rr.Name), ttl, recordType, zoneFileRData(rr, origin))
+	}
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// commonTTL returns the most frequent TTL of the records, preferring the
+// smaller value on ties, or 0 if no record has a TTL.
+func commonTTL(records []DNSRecord) int {
+	counts := make(map[int]int)
+	best := 0
+	for _, rr := range records {
+		ttl := effectiveTTL(rr.TTL, rr.TTLDuration)
+		if ttl <= 0 {
+			continue
+		}
+		counts[ttl]++
+		if best == 0 || counts[ttl] > counts[best] || (counts[ttl] == counts[best] && ttl < best) {
+			best = ttl
+		}
+	}
+	return best
+}
+
+// zoneFileRData converts record content into zone file RDATA.
+func zoneFileRData(rr DNSRecord, origin string) string {
+	recordType := rr.Type.Canonical()
+	content := strings.TrimSpace(rr.Content)
+
+	switch recordType {
+	case RecordTypeTXT, RecordTypeSPF:
+		if strings.HasPrefix(content, `"`) {
+			return content
+		}
+		if len(content) <= MaxTXTStringLength {
+			return quoteTXT(content)
+		}
+		return chunkTXT(content)
+	case RecordTypeMX:
+		if len(strings.Fields(content)) == 1 {
+			content = strconv.Itoa(rr.Priority) + " " + content
+		}
+	}
+
+	fields := strings.Fields(content)
+	if i, ok := targetFields[recordType]; ok && i < len(fields) {
+		fields[i] = fqdnTarget(fields[i], origin)
+	}
+	return strings.Join(fields, " ")
+}
+
+// fqdnTarget returns a hostname target as a fully qualified name. Targets
+// without a trailing dot are taken as absolute names, as the API stores them.
+func fqdnTarget(target, origin string) string {
+	switch {
+	case target == "@":
+		return origin + "."
+	case strings.HasSuffix(target, "."):
+		return target
+	default:
+		return target + "."
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.reg.ru. hostmaster.example.com. (
+		2025010101 ; serial
+		3h 1h 1w 1h )
+@	300	IN	A	192.0.2.1
+	IN	300	AAAA	2001:db8::1
+www	IN	CNAME	@
+mail	IN	MX	10 mx
+@	IN	TXT	"v=spf1 include:_spf.example.com" " ~all" ; spf record
+_sip._tcp	IN	SRV	10 0 5060 sip
+Ext.Example.Com.	IN	A	192.0.2.3
+other.org.	IN	A	192.0.2.4
+$ORIGIN sub.example.com.
+host	IN	A	192.0.2.5
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := ParseZoneFile(strings.NewReader(testZoneFile), "example.com")
+	require.NoError(t, err)
+	require.Len(t, records, 10)
+
+	soa := records[0]
+	assert.Equal(t, "@", soa.Name)
+	assert.Equal(t, RecordType("SOA"), soa.Type)
+	assert.Equal(t, "ns1.reg.ru. hostmaster.example.com. 2025010101 3h 1h 1w 1h", soa.Content)
+	assert.Equal(t, 3600, soa.TTL)
+	assert.Equal(t, 3, soa.Line)
+
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300}, records[1].DNSRecord)
+	assert.Equal(t, DNSRecord{Name: "@", Type: RecordTypeAAAA, Content: "2001:db8::1", TTL: 300}, records[2].DNSRecord, "blank owner repeats the previous one")
+	assert.Equal(t, DNSRecord{Name: "www", Type: RecordTypeCNAME, Content: "example.com.", TTL: 3600}, records[3].DNSRecord)
+	assert.Equal(t, DNSRecord{Name: "mail", Type: RecordTypeMX, Content: "10 mx.example.com.", TTL: 3600}, records[4].DNSRecord)
+
+	txt := records[5]
+	assert.Equal(t, "v=spf1 include:_spf.example.com ~all", txt.Content)
+	assert.Equal(t, "spf record", txt.Comment)
+
+	assert.Equal(t, "10 0 5060 sip.example.com.", records[6].Content)
+	assert.Equal(t, "ext", records[7].Name)
+	assert.Equal(t, "other.org.", records[8].Name, "names outside the zone stay absolute")
+	assert.Equal(t, "host.sub", records[9].Name)
+}
+
+func TestParseZoneFile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing type", data: "www IN 300\n"},
+		{name: "missing data", data: "www IN A\n"},
+		{name: "unbalanced parenthesis", data: "www IN TXT ( \"a\"\n"},
+		{name: "unterminated string", data: "www IN TXT \"a\n"},
+		{name: "unsupported directive", data: "$INCLUDE other.zone\n"},
+		{name: "invalid TTL", data: "www 1y IN A 192.0.2.1\n"},
+		{name: "no owner", data: " IN A 192.0.2.1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseZoneFile(strings.NewReader(tt.data), "example.com")
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidZoneFile))
+		})
+	}
+}
+
+func TestFormatZoneFile(t *testing.T) {
+	long := strings.Repeat("a", MaxTXTStringLength+10)
+	records := []DNSRecord{
+		{Name: "www", Type: RecordTypeCNAME, Content: "example.com", TTL: 3600},
+		{Name: "@", Type: RecordTypeMX, Content: "mail.example.com", Priority: 10, TTL: 3600},
+		{Name: "@", Type: "soa", Content: "ns1.reg.ru. hostmaster.example.com. 1 10800 3600 604800 3600", TTL: 3600},
+		{Name: "@", Type: RecordTypeA, Content: "192.0.2.1", TTL: 300},
+		{Name: "@", Type: RecordTypeTXT, Content: "v=spf1 -all", TTL: 3600},
+		{Name: "dkim", Type: RecordTypeTXT, Content: long, TTL: 3600},
+		{Name: "_sip._tcp", Type: RecordTypeSRV, Content: "10 0 5060 sip.example.com", TTL: 3600},
+	}
+
+	text := FormatZoneFile("Example.com.", records)
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	assert.Equal(t, "$ORIGIN example.com.", lines[0])
+	assert.Equal(t, "$TTL 3600", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "@"))
+	assert.Contains(t, lines[2], "SOA")
+	assert.Contains(t, text, "10 mail.example.com.")
+	assert.Contains(t, text, `"v=spf1 -all"`)
+
+	parsed, err := ParseZoneFile(strings.NewReader(text), "example.com")
+	require.NoError(t, err)
+	require.Len(t, parsed, len(records))
+
+	byKey := make(map[string]DNSRecord)
+	for _, rr := range parsed {
+		byKey[rr.Name+" "+string(rr.Type)] = rr.DNSRecord
+	}
+	assert.Equal(t, 300, byKey["@ A"].TTL)
+	assert.Equal(t, 3600, byKey["www CNAME"].TTL)
+	assert.Equal(t, "example.com.", byKey["www CNAME"].Content)
+	assert.Equal(t, "10 0 5060 sip.example.com.", byKey["_sip._tcp SRV"].Content)
+	assert.Equal(t, long, byKey["dkim TXT"].Content)
+}
+
+func TestClient_ExportZoneFile(t *testing.T) {
+	records := testZoneRecords()
+	records.Answer.Domains[0].SOA = &SOAInfo{TTL: "1h"}
+	server, _ := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": records,
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	text, err := client.ExportZoneFile(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Contains(t, text, "$TTL 3600\n")
+
+	parsed, err := ParseZoneFile(strings.NewReader(text), "example.com")
+	require.NoError(t, err)
+	require.Len(t, parsed, 4)
+	assert.Equal(t, "@", parsed[0].Name)
+	assert.Equal(t, RecordTypeA, parsed[0].Type)
+	assert.Equal(t, "10 mail.example.com.", parsed[1].Content)
+}
+
+func TestClient_ImportZoneFile(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	file := `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.reg.ru. hostmaster.example.com. 1 3h 1h 1w 1h
+@	IN	NS	ns1.reg.ru.
+@	IN	A	192.0.2.1
+api	IN	A	192.0.2.5
+@	IN	HINFO	"PC" "Linux"
+`
+
+	report, err := client.ImportZoneFile(context.Background(), "example.com", strings.NewReader(file))
+	require.NoError(t, err)
+	require.NoError(t, report.Results.Err())
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, BatchOperationCreate, report.Results[0].Operation)
+	assert.Equal(t, "api", report.Results[0].Item.Name)
+	assert.Equal(t, 3600, report.Results[0].Item.TTL)
+
+	var skipped []RecordType
+	for _, warning := range report.Skipped {
+		skipped = append(skipped, warning.Type)
+	}
+	assert.Equal(t, []RecordType{"SOA", RecordTypeNS, "HINFO"}, skipped)
+	assert.Equal(t, 1, countCalls(*calls, "zone/add_alias"))
+	assert.Zero(t, countCalls(*calls, "zone/remove_record"))
+}
+
+func TestClient_ImportZoneFile_Replace(t *testing.T) {
+	server, calls := setupRoutedTestServer(t, map[string]interface{}{
+		"zone/get_resource_records": testZoneRecords(),
+	})
+	defer server.Close()
+
+	client := setupTestClient(t, server)
+
+	file := "@ IN A 192.0.2.1\nwww IN A 192.0.2.2\n"
+	report, err := client.ImportZoneFile(context.Background(), "example.com", strings.NewReader(file), WithReplace())
+	require.NoError(t, err)
+	assert.Empty(t, report.Skipped)
+	require.Len(t, report.Results, 2)
+	for _, result := range report.Results {
+		assert.Equal(t, BatchOperationDelete, result.Operation)
+	}
+	assert.Equal(t, 2, countCalls(*calls, "zone/remove_record"))
+
+	_, err = client.ImportZoneFile(context.Background(), "example.com", strings.NewReader("@ IN A ("))
+	assert.ErrorIs(t, err, ErrInvalidZoneFile)
+}
@@ -0,0 +1,326 @@
+/*
+Copyright © 2025 Michael Bruskov <mixanemca@yandex.ru>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regru
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ZonesService groups the DNS zone methods of the API.
+type ZonesService struct {
+	client *Client
+}
+
+// List returns a list of all zones in the account.
+func (s *ZonesService) List(ctx context.Context) ([]Zone, error) {
+	// Prepare API request
+	apiReq := ServiceListRequest{
+		BaseRequest: BaseRequest{},
+		PageSize:    1000, // Maximum number of zones per request
+	}
+
+	resp, err := Call[ServiceListResponse](ctx, s.client, "service/get_list", &apiReq)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var zones []Zone
+	for _, service := range resp.Answer.Services {
+		serviceType := service.GetServiceType()
+		if serviceType == "domain" {
+			expiration := parseAPIDate(service.ExpirationDate)
+			zones = append(zones, Zone{
+				Name:           s.client.unicodeName(service.GetDomain()),
+				ID:             service.GetServiceID(),
+				Status:         zoneStatus(service.State, expiration, now),
+				State:          service.State,
+				ExpirationDate: expiration,
+			})
+		}
+	}
+
+	return zones, nil
+}
+
+// zoneStatus derives the zone status from the service state and expiration date.
+func zoneStatus(state string, expiration, now time.Time) string {
+	switch state {
+	case "":
+		return ""
+	case stateActive:
+		if !expiration.IsZero() && expiration.Before(now) {
+			return ZoneStatusExpired
+		}
+		return ZoneStatusActive
+	case "S":
+		return ZoneStatusSuspended
+	default:
+		return ZoneStatusInactive
+	}
+}
+
+// ListByName returns a list of zones by name.
+func (s *ZonesService) ListByName(ctx context.Context, name string) ([]Zone, error) {
+	zones, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Names are compared in punycode form, so both Unicode and ASCII names match
+	name = s.client.asciiName(name)
+	var filtered []Zone
+	for _, zone := range zones {
+		if s.client.asciiName(zone.Name) == name {
+			filtered = append(filtered, zone)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Clear removes all resource records of the zone with a single zone/clear call,
+// for example before a bulk import. Depending on the service, reg.ru may restore
+// its default records such as apex NS.
+func (s *ZonesService) Clear(ctx context.Context, zone string) error {
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneClearRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneClearDomain{
+			{DName: apiZone},
+		},
+	}
+
+	return s.mutate(ctx, "zone/clear", zone, &apiReq)
+}
+
+// Create enables reg.ru DNS hosting for the domain with zone/create, which
+// (re)initializes the zone with the default records, and returns the records
+// of the new zone.
+func (s *ZonesService) Create(ctx context.Context, domain string) ([]DNSRecord, error) {
+	apiZone := s.client.asciiName(domain)
+	apiReq := ZoneCreateRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+
+	if err := s.mutate(ctx, "zone/create", domain, &apiReq); err != nil {
+		return nil, err
+	}
+
+	return s.client.Records.List(ctx, ListDNSRecordsParams{ZoneName: domain})
+}
+
+// IsDelegated reports whether the domain is delegated to the reg.ru
+// nameservers, as checked by zone/is_delegated. Record changes of a zone that
+// is not delegated have no effect in DNS.
+func (s *ZonesService) IsDelegated(ctx context.Context, zone string) (bool, error) {
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneIsDelegatedRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+
+	resp, err := Call[ZoneIsDelegatedResponse](ctx, s.client, "zone/is_delegated", &apiReq)
+	if err != nil {
+		return false, err
+	}
+
+	for _, domain := range resp.Answer.Domains {
+		if domain.DName != apiZone {
+			continue
+		}
+		if domain.Result != "" && domain.Result != "success" {
+			return false, newAPIError(domain.ErrorCode, domain.ErrorText)
+		}
+		switch domain.GetIsDelegated() {
+		case "1", "true":
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("no zone/is_delegated result for zone %s", apiZone)
+}
+
+// SOAParams holds the zone-level TTL settings read by GetSOA and changed by
+// UpdateSOA. Zero values are left unchanged by UpdateSOA.
+type SOAParams struct {
+	// TTL is the zone TTL in seconds, which reg.ru applies to all records.
+	TTL int
+	// MinimumTTL is the SOA minimum TTL in seconds, used for negative caching.
+	MinimumTTL int
+}
+
+// GetSOA returns the zone TTL settings reported by zone/get_resource_records.
+// Values the API does not report are zero.
+func (s *ZonesService) GetSOA(ctx context.Context, zone string) (SOAParams, error) {
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneGetResourceRecordsRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+
+	resp, err := Call[ZoneGetResourceRecordsResponse](ctx, s.client, "zone/get_resource_records", &apiReq)
+	if err != nil {
+		return SOAParams{}, err
+	}
+
+	for _, domain := range resp.Answer.Domains {
+		if domain.DName != apiZone {
+			continue
+		}
+		var params SOAParams
+		if domain.SOA == nil {
+			return params, nil
+		}
+		if domain.SOA.TTL != "" {
+			if params.TTL, err = domain.SOA.GetTTL(); err != nil {
+				return SOAParams{}, err
+			}
+		}
+		if domain.SOA.MinimumTTL != "" {
+			if params.MinimumTTL, err = domain.SOA.GetMinimumTTL(); err != nil {
+				return SOAParams{}, err
+			}
+		}
+		return params, nil
+	}
+	return SOAParams{}, fmt.Errorf("no zone/get_resource_records result for zone %s", apiZone)
+}
+
+// UpdateSOA changes the zone TTL and SOA minimum TTL with zone/update_soa.
+func (s *ZonesService) UpdateSOA(ctx context.Context, zone string, params SOAParams) error {
+	if params.TTL < 0 {
+		return &InvalidTTLError{Value: strconv.Itoa(params.TTL)}
+	}
+	if params.MinimumTTL < 0 {
+		return &InvalidTTLError{Value: strconv.Itoa(params.MinimumTTL)}
+	}
+
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneUpdateSOARequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+	if params.TTL > 0 {
+		apiReq.TTL = FormatTTL(params.TTL)
+	}
+	if params.MinimumTTL > 0 {
+		apiReq.MinimumTTL = FormatTTL(params.MinimumTTL)
+	}
+
+	return s.mutate(ctx, "zone/update_soa", zone, &apiReq)
+}
+
+// ForwardingParams selects the name that SetForwarding points at the reg.ru
+// web forwarding servers.
+type ForwardingParams struct {
+	// Subdomain is the name relative to the zone; empty or "@" is the apex.
+	Subdomain string
+}
+
+// SetForwarding points the name at the reg.ru web forwarding servers with
+// zone/tune_forwarding, replacing its address records. The redirect target
+// itself is configured on the domain's web forwarding service.
+func (s *ZonesService) SetForwarding(ctx context.Context, zone string, params ForwardingParams) error {
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneTuneForwardingRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+	if params.Subdomain != "" && params.Subdomain != "@" {
+		apiReq.Subdomain = s.client.asciiName(params.Subdomain)
+	}
+
+	return s.mutate(ctx, "zone/tune_forwarding", zone, &apiReq)
+}
+
+// RemoveForwarding removes the web forwarding records of the zone with
+// zone/remove_forwarding.
+func (s *ZonesService) RemoveForwarding(ctx context.Context, zone string) error {
+	apiZone := s.client.asciiName(zone)
+	apiReq := ZoneRemoveForwardingRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: apiZone},
+		},
+	}
+
+	return s.mutate(ctx, "zone/remove_forwarding", zone, &apiReq)
+}
+
+// EnableParking parks the domain with zone/tune_parking, pointing it at the
+// reg.ru parking page.
+func (s *ZonesService) EnableParking(ctx context.Context, zone string) error {
+	apiReq := ZoneTuneParkingRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: s.client.asciiName(zone)},
+		},
+	}
+	return s.mutate(ctx, "zone/tune_parking", zone, &apiReq)
+}
+
+// DisableParking removes the parking records of the domain with zone/remove_parking.
+func (s *ZonesService) DisableParking(ctx context.Context, zone string) error {
+	apiReq := ZoneRemoveParkingRequest{
+		BaseRequest: BaseRequest{},
+		Domains: []ZoneGetResourceRecordsDomain{
+			{DName: s.client.asciiName(zone)},
+		},
+	}
+	return s.mutate(ctx, "zone/remove_parking", zone, &apiReq)
+}
+
+// mutate sends a zone-changing request, invalidates the records cache of the
+// zone and returns the error reported for it.
+func (s *ZonesService) mutate(ctx context.Context, path, zone string, apiReq APIRequest) error {
+	resp, err := Call[AddNSResponse](ctx, s.client, path, apiReq)
+	s.client.InvalidateRecordsCache(zone)
+	if err != nil {
+		return err
+	}
+	return domainResult(resp, path, s.client.asciiName(zone))
+}
+
+// domainResult returns the error reported for the domain in a per-domain answer.
+func domainResult(resp AddNSResponse, path, apiZone string) error {
+	for _, domain := range resp.Answer.Domains {
+		if domain.DName != apiZone {
+			continue
+		}
+		if domain.Result != "" && domain.Result != "success" {
+			return newAPIError(domain.ErrorCode, domain.ErrorText)
+		}
+		return nil
+	}
+	return fmt.Errorf("no %s result for zone %s", path, apiZone)
+}